@@ -0,0 +1,146 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"goclean/internal/application/authz"
+	"goclean/internal/application/bus"
+	"goclean/internal/application/commands"
+	"goclean/internal/application/validation"
+	"goclean/internal/domain/services"
+	"goclean/internal/infrastructure/metrics"
+	"goclean/internal/infrastructure/persistence"
+	gormPersistence "goclean/internal/infrastructure/persistence/gorm"
+	"goclean/pkg/config"
+	"goclean/pkg/logger"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"text/tabwriter"
+	"time"
+
+	"github.com/joho/godotenv"
+	"gorm.io/gorm"
+)
+
+const usage = `Usage: goclean-cli <command> [flags]
+
+Commands:
+  purge    Hard delete soft deleted records whose retention period is over
+
+Run "goclean-cli <command> -h" for the flags of a command.
+`
+
+func main() {
+	flag.Usage = func() { fmt.Fprint(os.Stderr, usage) }
+	flag.Parse()
+	if flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	// Load environment variables from .env file
+	if err := godotenv.Load("../../.env"); err != nil {
+		log.Println("Warning: .env file not found or could not be loaded")
+	}
+
+	// Load configuration
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		log.Fatalf("Failed to load configuration: %v", err)
+	}
+
+	// Log to stderr so command output on stdout can be piped
+	appLogger, err := logger.New(logger.Config{
+		Level:  logger.LogLevel(cfg.App.LogLevel),
+		Format: "text",
+		Output: "stderr",
+	})
+	if err != nil {
+		log.Fatalf("Failed to initialize logger: %v", err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	switch name, args := flag.Arg(0), flag.Args()[1:]; name {
+	case "purge":
+		err = runPurge(ctx, cfg, appLogger, args)
+	default:
+		fmt.Fprintf(os.Stderr, "Unknown command %q\n\n", name)
+		flag.Usage()
+		os.Exit(2)
+	}
+	if err != nil {
+		appLogger.Error("Command failed", "error", err)
+		os.Exit(1)
+	}
+}
+
+// runPurge hard deletes the soft deleted records whose retention period is over, or with
+// -dry-run lists them without removing anything
+func runPurge(ctx context.Context, cfg *config.Config, appLogger *logger.Logger, args []string) error {
+	flags := flag.NewFlagSet("purge", flag.ExitOnError)
+	dryRun := flags.Bool("dry-run", false, "report what would be purged without removing anything")
+	flags.Parse(args)
+
+	db, err := openDatabase(cfg)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if sqlDB, err := db.DB(); err == nil {
+			sqlDB.Close()
+		}
+	}()
+
+	// Initialize repositories and the soft delete services that purge through them
+	unitOfWork := persistence.NewGormUnitOfWork(db)
+	userSoftDeleteService := services.NewUserSoftDeleteService(unitOfWork, gormPersistence.NewUserRepository(db))
+	profileSoftDeleteService := services.NewProfileSoftDeleteService(unitOfWork, persistence.NewProfileGormRepository(db))
+	productSoftDeleteService := services.NewProductSoftDeleteService(unitOfWork, persistence.NewProductGormRepository(db))
+	orderSoftDeleteService := services.NewOrderSoftDeleteService(unitOfWork, persistence.NewOrderGormRepository(db))
+
+	// Commands go through the same middleware as in the servers
+	authorizer := authz.NewDefaultAuthorizer()
+	commandBus := bus.NewDefaultCommandBus(appLogger.Logger, metrics.NewExpvarRecorder("bus"), validation.New(), unitOfWork)
+	commands.NewSoftDeleteCommandHandler(userSoftDeleteService, authorizer, authz.ResourceUser).Register(commandBus)
+	commands.NewSoftDeleteCommandHandler(profileSoftDeleteService, authorizer, authz.ResourceProfile).Register(commandBus)
+	commands.NewSoftDeleteCommandHandler(productSoftDeleteService, authorizer, authz.ResourceProduct).Register(commandBus)
+	commands.NewSoftDeleteCommandHandler(orderSoftDeleteService, authorizer, authz.ResourceOrder).Register(commandBus)
+
+	retentionJob := commands.NewRetentionJob(commandBus, commands.NewRetentionPolicy(cfg.Retention), appLogger)
+	purged, err := retentionJob.Purge(ctx, *dryRun)
+	printPurged(purged, *dryRun)
+	return err
+}
+
+// printPurged writes a table of the purged records to stdout
+func printPurged(purged []commands.PurgedEntity, dryRun bool) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "RESOURCE\tID\tDELETED AT\tREASON")
+	for _, entity := range purged {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", entity.ResourceType, entity.ID, entity.DeletedAt.Format(time.RFC3339), entity.Reason)
+	}
+	w.Flush()
+
+	if dryRun {
+		fmt.Printf("\n%d record(s) would be purged (dry run)\n", len(purged))
+		return
+	}
+	fmt.Printf("\n%d record(s) purged\n", len(purged))
+}
+
+// openDatabase connects to the database; the servers run the migrations
+func openDatabase(cfg *config.Config) (*gorm.DB, error) {
+	return persistence.NewDatabase(persistence.DatabaseConfig{
+		Host:     cfg.Database.Host,
+		Port:     cfg.Database.Port,
+		User:     cfg.Database.User,
+		Password: cfg.Database.Password,
+		DBName:   cfg.Database.DBName,
+		SSLMode:  cfg.Database.SSLMode,
+	})
+}
@@ -2,17 +2,29 @@ package main
 
 import (
 	"context"
-	"fmt"
+	"goclean/internal/application/authz"
+	"goclean/internal/application/bus"
+	"goclean/internal/application/commands"
+	"goclean/internal/application/queries"
+	"goclean/internal/application/validation"
+	"goclean/internal/domain/events"
+	"goclean/internal/domain/services"
+	"goclean/internal/infrastructure/auth"
+	"goclean/internal/infrastructure/cache"
+	"goclean/internal/infrastructure/metrics"
+	"goclean/internal/infrastructure/outbox"
+	"goclean/internal/infrastructure/persistence"
+	gormPersistence "goclean/internal/infrastructure/persistence/gorm"
+	grpcServer "goclean/internal/interfaces/grpc"
+	"goclean/pkg/config"
+	"goclean/pkg/logger"
 	"log"
-	"net"
 	"os"
 	"os/signal"
 	"syscall"
 	"time"
 
 	"github.com/joho/godotenv"
-	"google.golang.org/grpc"
-	"google.golang.org/grpc/reflection"
 )
 
 func main() {
@@ -21,63 +33,233 @@ func main() {
 		log.Println("Warning: .env file not found or could not be loaded")
 	}
 
-	log.Println("Starting gRPC server...")
+	// Load configuration
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		log.Fatalf("Failed to load configuration: %v", err)
+	}
+
+	// Initialize logger
+	loggerConfig := logger.Config{
+		Level:  logger.LogLevel(cfg.App.LogLevel),
+		Format: "text",
+		Output: "stdout",
+	}
+	if cfg.IsProduction() {
+		loggerConfig.Format = "json"
+	}
 
-	// Create gRPC server
-	grpcServer := grpc.NewServer()
+	appLogger, err := logger.New(loggerConfig)
+	if err != nil {
+		log.Fatalf("Failed to initialize logger: %v", err)
+	}
 
-	// Enable reflection for development (helps with tools like grpcurl)
-	reflection.Register(grpcServer)
-	log.Println("gRPC reflection enabled")
+	appLogger.Info("Starting GoClean gRPC API",
+		"version", cfg.App.Version,
+		"environment", cfg.App.Environment,
+	)
 
-	// Create listener on port 50051
-	lis, err := net.Listen("tcp", ":50051")
+	// Initialize database
+	db, err := persistence.NewDatabase(persistence.DatabaseConfig{
+		Host:     cfg.Database.Host,
+		Port:     cfg.Database.Port,
+		User:     cfg.Database.User,
+		Password: cfg.Database.Password,
+		DBName:   cfg.Database.DBName,
+		SSLMode:  cfg.Database.SSLMode,
+	})
 	if err != nil {
-		log.Fatalf("Failed to create listener: %v", err)
+		appLogger.Error("Failed to connect to database", "error", err)
+		os.Exit(1)
 	}
 
-	// Start server in goroutine
+	// Run database migrations
+	if err := persistence.MigrateDatabase(db); err != nil {
+		appLogger.Error("Failed to migrate database", "error", err)
+		os.Exit(1)
+	}
+	appLogger.Info("Database migrations completed successfully")
+
+	// Initialize cache service
+	cacheService := cache.NewCacheService(cache.RedisConfig{
+		Addr:     cfg.Redis.Addr,
+		Password: cfg.Redis.Password,
+		DB:       cfg.Redis.DB,
+	})
+
+	// Test cache connection
+	if err := cacheService.Ping(context.Background()); err != nil {
+		appLogger.Error("Failed to connect to Redis", "error", err)
+		os.Exit(1)
+	}
+	appLogger.Info("Connected to Redis successfully")
+
+	// Initialize auth provider
+	authService, err := auth.NewProvider(auth.ProviderConfig{
+		Type: cfg.Auth.Provider,
+		Keycloak: auth.KeycloakConfig{
+			BaseURL:                cfg.Keycloak.BaseURL,
+			Realm:                  cfg.Keycloak.Realm,
+			ClientID:               cfg.Keycloak.ClientID,
+			ClientSecret:           cfg.Keycloak.ClientSecret,
+			JWKSCacheTTL:           cfg.Keycloak.JWKSCacheTTL,
+			JWKSMinRefreshInterval: cfg.Keycloak.JWKSMinRefreshInterval,
+		},
+		OIDC: auth.OIDCConfig{
+			IssuerURL:              cfg.Auth.OIDCIssuerURL,
+			ClientID:               cfg.Auth.OIDCClientID,
+			ClientSecret:           cfg.Auth.OIDCClientSecret,
+			JWKSCacheTTL:           cfg.Keycloak.JWKSCacheTTL,
+			JWKSMinRefreshInterval: cfg.Keycloak.JWKSMinRefreshInterval,
+		},
+		Local: auth.LocalConfig{
+			Issuer:          cfg.Auth.LocalIssuer,
+			ClientID:        cfg.Keycloak.ClientID,
+			SigningMethod:   cfg.Auth.LocalSigningMethod,
+			Secret:          cfg.Auth.LocalSecret,
+			PrivateKeyFile:  cfg.Auth.LocalPrivateKeyFile,
+			AccessTokenTTL:  cfg.Auth.LocalAccessTokenTTL,
+			RefreshTokenTTL: cfg.Auth.LocalRefreshTokenTTL,
+		},
+	})
+	if err != nil {
+		appLogger.Error("Failed to initialize auth provider", "error", err)
+		os.Exit(1)
+	}
+	appLogger.Info("Auth provider initialized", "provider", cfg.Auth.Provider)
+
+	// Initialize token revocation backed by Redis
+	revocations := auth.NewRevocationService(auth.NewRedisRevocationStore(cacheService), authService, auth.RevocationConfig{
+		FailOpen:         cfg.Auth.RevocationFailOpen,
+		MaxTokenLifetime: cfg.Auth.MaxTokenLifetime,
+	}, appLogger)
+
+	// Initialize domain event dispatcher and outbox relay; relays in several
+	// processes are safe because rows are claimed with SKIP LOCKED
+	eventDispatcher := events.NewDomainEventDispatcher(nil)
+	eventDispatcher.RegisterHandler(events.NewUserCreatedEventHandler(appLogger))
+	eventDispatcher.RegisterHandler(events.NewUserDeletedEventHandler(appLogger))
+	eventDispatcher.RegisterHandler(events.NewProductCreatedEventHandler(appLogger))
+	eventDispatcher.RegisterHandler(events.NewStockDepletedEventHandler(appLogger))
+
+	outboxRelay := outbox.NewRelay(db, events.NewDefaultEventRegistry(), eventDispatcher, outbox.RelayConfig{
+		PollInterval: cfg.Outbox.PollInterval,
+		BatchSize:    cfg.Outbox.BatchSize,
+		MaxAttempts:  cfg.Outbox.MaxAttempts,
+		BaseBackoff:  cfg.Outbox.BaseBackoff,
+		MaxBackoff:   cfg.Outbox.MaxBackoff,
+	}, appLogger)
+
+	relayCtx, stopRelay := context.WithCancel(context.Background())
+	relayDone := make(chan struct{})
 	go func() {
-		log.Println("gRPC server starting on port 50051")
-		if err := grpcServer.Serve(lis); err != nil {
-			log.Fatalf("gRPC server failed: %v", err)
+		defer close(relayDone)
+		outboxRelay.Run(relayCtx)
+	}()
+
+	// Initialize repositories
+	userRepo := gormPersistence.NewUserRepository(db)
+	profileRepo := persistence.NewProfileGormRepository(db)
+	productRepo := persistence.NewProductGormRepository(db)
+	orderRepo := persistence.NewOrderGormRepository(db)
+	inventoryRepo := persistence.NewInventoryGormRepository(db)
+	reservationRepo := persistence.NewStockReservationGormRepository(db)
+	unitOfWork := persistence.NewGormUnitOfWork(db)
+
+	// Initialize domain services
+	userDomainService := services.NewUserDomainService(unitOfWork, userRepo, profileRepo)
+	inventoryDomainService := services.NewInventoryDomainService(unitOfWork, inventoryRepo, reservationRepo)
+	productDomainService := services.NewProductDomainService(unitOfWork, productRepo, inventoryRepo)
+	orderDomainService := services.NewOrderDomainService(unitOfWork, orderRepo, productRepo, userRepo, inventoryDomainService)
+
+	// Initialize the authorization policy consulted by command and query handlers
+	authorizer := authz.NewDefaultAuthorizer()
+
+	// Initialize the command and query buses; messages are checked against their validate
+	// tags and commands run in a transaction that is retried on optimistic locking conflicts
+	busMetrics := metrics.NewExpvarRecorder("bus")
+	validator := validation.New()
+	commandBus := bus.NewDefaultCommandBus(appLogger.Logger, busMetrics, validator, unitOfWork)
+	queryBus := bus.NewDefaultQueryBus(appLogger.Logger, busMetrics, validator)
+
+	// Initialize command handlers
+	userCommandHandler := commands.NewUserCommandHandler(userDomainService, authorizer)
+	productCommandHandler := commands.NewProductCommandHandler(productDomainService, authorizer)
+	orderCommandHandler := commands.NewOrderCommandHandler(orderDomainService, authorizer)
+
+	// Initialize just-in-time user provisioning from token claims
+	userProvisioner := commands.NewUserProvisioner(commandBus, userDomainService, cfg.Auth.UserSyncInterval)
+
+	// Initialize query handlers
+	userQueryHandler := queries.NewUserQueryHandler(userRepo, profileRepo, authorizer)
+	productQueryHandler := queries.NewProductQueryHandler(productRepo)
+	orderQueryHandler := queries.NewOrderQueryHandler(orderRepo, authorizer)
+
+	// Register handlers with the buses
+	userCommandHandler.Register(commandBus)
+	productCommandHandler.Register(commandBus)
+	orderCommandHandler.Register(commandBus)
+	userQueryHandler.Register(queryBus)
+	productQueryHandler.Register(queryBus)
+	orderQueryHandler.Register(queryBus)
+
+	// Initialize gRPC server
+	server := grpcServer.NewServer(
+		appLogger,
+		authService,
+		revocations,
+		userProvisioner,
+		grpcServer.NewUserService(commandBus, queryBus),
+		grpcServer.NewProductService(commandBus, queryBus),
+		grpcServer.NewOrderService(commandBus, queryBus),
+	)
+
+	// Start gRPC server in a goroutine
+	go func() {
+		if err := server.Start(cfg.GetGRPCAddress()); err != nil {
+			appLogger.Error("Failed to start gRPC server", "error", err)
+			os.Exit(1)
 		}
 	}()
 
-	fmt.Println("gRPC server is running on port 50051")
-	fmt.Println("Note: This is a basic gRPC server setup.")
-	fmt.Println("To complete the setup:")
-	fmt.Println("1. Define your .proto files in the proto/ directory")
-	fmt.Println("2. Generate Go code using protoc")
-	fmt.Println("3. Implement your gRPC service handlers")
-	fmt.Println("4. Register your services with the server")
+	appLogger.Info("gRPC server started", "address", cfg.GetGRPCAddress())
 
-	// Wait for interrupt signal
+	// Wait for interrupt signal to gracefully shutdown the server
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
 
-	log.Println("Shutting down gRPC server...")
-
-	// Graceful shutdown
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-	defer cancel()
-
-	// Create a channel to signal shutdown completion
-	done := make(chan bool, 1)
+	appLogger.Info("Shutting down server...")
 
+	// Graceful shutdown with a deadline
+	done := make(chan struct{})
 	go func() {
-		grpcServer.GracefulStop()
-		done <- true
+		server.Stop()
+		close(done)
 	}()
 
 	select {
 	case <-done:
-		log.Println("gRPC server shutdown completed")
-	case <-ctx.Done():
-		log.Println("gRPC server shutdown timeout, forcing stop")
-		grpcServer.Stop()
+		appLogger.Info("gRPC server shutdown completed")
+	case <-time.After(30 * time.Second):
+		appLogger.Warn("gRPC server shutdown timeout, forcing stop")
+		server.ForceStop()
+	}
+
+	// Stop outbox relay
+	stopRelay()
+	<-relayDone
+
+	// Close cache connection
+	if err := cacheService.Close(); err != nil {
+		appLogger.Error("Failed to close cache connection", "error", err)
+	}
+
+	// Close database connection
+	sqlDB, err := db.DB()
+	if err == nil {
+		sqlDB.Close()
 	}
 
-	log.Println("gRPC server stopped")
+	appLogger.Info("Server exited")
 }
@@ -2,11 +2,17 @@ package main
 
 import (
 	"context"
+	"goclean/internal/application/authz"
+	"goclean/internal/application/bus"
 	"goclean/internal/application/commands"
 	"goclean/internal/application/queries"
+	"goclean/internal/application/validation"
+	"goclean/internal/domain/events"
 	"goclean/internal/domain/services"
 	"goclean/internal/infrastructure/auth"
 	"goclean/internal/infrastructure/cache"
+	"goclean/internal/infrastructure/metrics"
+	"goclean/internal/infrastructure/outbox"
 	"goclean/internal/infrastructure/persistence"
 	gormPersistence "goclean/internal/infrastructure/persistence/gorm"
 	httpServer "goclean/internal/interfaces/http"
@@ -45,6 +51,11 @@ import (
 // @name Authorization
 // @description Type "Bearer" followed by a space and JWT token.
 
+// @securityDefinitions.apikey ApiKeyAuth
+// @in header
+// @name X-API-Key
+// @description API key for service-to-service access.
+
 func main() {
 	// Load environment variables from .env file
 	if err := godotenv.Load("../../.env"); err != nil {
@@ -112,49 +123,192 @@ func main() {
 	}
 	appLogger.Info("Connected to Redis successfully")
 
-	// Initialize auth service
-	authService := auth.NewAuthService(auth.KeycloakConfig{
-		BaseURL:      cfg.Keycloak.BaseURL,
-		Realm:        cfg.Keycloak.Realm,
-		ClientID:     cfg.Keycloak.ClientID,
-		ClientSecret: cfg.Keycloak.ClientSecret,
+	// Initialize auth provider
+	authService, err := auth.NewProvider(auth.ProviderConfig{
+		Type: cfg.Auth.Provider,
+		Keycloak: auth.KeycloakConfig{
+			BaseURL:                cfg.Keycloak.BaseURL,
+			Realm:                  cfg.Keycloak.Realm,
+			ClientID:               cfg.Keycloak.ClientID,
+			ClientSecret:           cfg.Keycloak.ClientSecret,
+			JWKSCacheTTL:           cfg.Keycloak.JWKSCacheTTL,
+			JWKSMinRefreshInterval: cfg.Keycloak.JWKSMinRefreshInterval,
+		},
+		OIDC: auth.OIDCConfig{
+			IssuerURL:              cfg.Auth.OIDCIssuerURL,
+			ClientID:               cfg.Auth.OIDCClientID,
+			ClientSecret:           cfg.Auth.OIDCClientSecret,
+			JWKSCacheTTL:           cfg.Keycloak.JWKSCacheTTL,
+			JWKSMinRefreshInterval: cfg.Keycloak.JWKSMinRefreshInterval,
+		},
+		Local: auth.LocalConfig{
+			Issuer:          cfg.Auth.LocalIssuer,
+			ClientID:        cfg.Keycloak.ClientID,
+			SigningMethod:   cfg.Auth.LocalSigningMethod,
+			Secret:          cfg.Auth.LocalSecret,
+			PrivateKeyFile:  cfg.Auth.LocalPrivateKeyFile,
+			AccessTokenTTL:  cfg.Auth.LocalAccessTokenTTL,
+			RefreshTokenTTL: cfg.Auth.LocalRefreshTokenTTL,
+		},
 	})
+	if err != nil {
+		appLogger.Error("Failed to initialize auth provider", "error", err)
+		os.Exit(1)
+	}
+	appLogger.Info("Auth provider initialized", "provider", cfg.Auth.Provider)
+
+	// Initialize token revocation backed by Redis
+	revocations := auth.NewRevocationService(auth.NewRedisRevocationStore(cacheService), authService, auth.RevocationConfig{
+		FailOpen:         cfg.Auth.RevocationFailOpen,
+		MaxTokenLifetime: cfg.Auth.MaxTokenLifetime,
+	}, appLogger)
+
+	// Initialize domain event dispatcher and outbox relay
+	eventDispatcher := events.NewDomainEventDispatcher(nil)
+	eventDispatcher.RegisterHandler(events.NewUserCreatedEventHandler(appLogger))
+	eventDispatcher.RegisterHandler(events.NewUserDeletedEventHandler(appLogger))
+	eventDispatcher.RegisterHandler(events.NewProductCreatedEventHandler(appLogger))
+	eventDispatcher.RegisterHandler(events.NewStockDepletedEventHandler(appLogger))
+
+	outboxRelay := outbox.NewRelay(db, events.NewDefaultEventRegistry(), eventDispatcher, outbox.RelayConfig{
+		PollInterval: cfg.Outbox.PollInterval,
+		BatchSize:    cfg.Outbox.BatchSize,
+		MaxAttempts:  cfg.Outbox.MaxAttempts,
+		BaseBackoff:  cfg.Outbox.BaseBackoff,
+		MaxBackoff:   cfg.Outbox.MaxBackoff,
+	}, appLogger)
+
+	relayCtx, stopRelay := context.WithCancel(context.Background())
+	relayDone := make(chan struct{})
+	go func() {
+		defer close(relayDone)
+		outboxRelay.Run(relayCtx)
+	}()
 
 	// Initialize repositories
 	userRepo := gormPersistence.NewUserRepository(db)
 	profileRepo := persistence.NewProfileGormRepository(db)
 	productRepo := persistence.NewProductGormRepository(db)
 	orderRepo := persistence.NewOrderGormRepository(db)
+	inventoryRepo := persistence.NewInventoryGormRepository(db)
+	reservationRepo := persistence.NewStockReservationGormRepository(db)
+	apiKeyRepo := persistence.NewAPIKeyGormRepository(db)
+	unitOfWork := persistence.NewGormUnitOfWork(db)
 
 	// Initialize domain services
-	userDomainService := services.NewUserDomainService(userRepo, profileRepo)
-	productDomainService := services.NewProductDomainService(productRepo)
-	orderDomainService := services.NewOrderDomainService(orderRepo, productRepo)
+	userDomainService := services.NewUserDomainService(unitOfWork, userRepo, profileRepo)
+	inventoryDomainService := services.NewInventoryDomainService(unitOfWork, inventoryRepo, reservationRepo)
+	productDomainService := services.NewProductDomainService(unitOfWork, productRepo, inventoryRepo)
+	orderDomainService := services.NewOrderDomainService(unitOfWork, orderRepo, productRepo, userRepo, inventoryDomainService)
+	apiKeyDomainService := services.NewAPIKeyDomainService(apiKeyRepo, userRepo)
+	userSoftDeleteService := services.NewUserSoftDeleteService(unitOfWork, userRepo)
+	profileSoftDeleteService := services.NewProfileSoftDeleteService(unitOfWork, profileRepo)
+	productSoftDeleteService := services.NewProductSoftDeleteService(unitOfWork, productRepo)
+	orderSoftDeleteService := services.NewOrderSoftDeleteService(unitOfWork, orderRepo)
+	userPrivacyService := services.NewUserPrivacyService(unitOfWork, userRepo, profileRepo, orderRepo, apiKeyRepo)
+
+	// Initialize the authorization policy consulted by command and query handlers
+	authorizer := authz.NewDefaultAuthorizer()
+
+	// Initialize the command and query buses; messages are checked against their validate
+	// tags and commands run in a transaction that is retried on optimistic locking conflicts
+	busMetrics := metrics.NewExpvarRecorder("bus")
+	validator := validation.New()
+	commandBus := bus.NewDefaultCommandBus(appLogger.Logger, busMetrics, validator, unitOfWork)
+	queryBus := bus.NewDefaultQueryBus(appLogger.Logger, busMetrics, validator)
 
 	// Initialize command handlers
-	userCommandHandler := commands.NewUserCommandHandler(userDomainService)
-	productCommandHandler := commands.NewProductCommandHandler(productDomainService)
-	orderCommandHandler := commands.NewOrderCommandHandler(orderDomainService)
+	userCommandHandler := commands.NewUserCommandHandler(userDomainService, authorizer)
+	productCommandHandler := commands.NewProductCommandHandler(productDomainService, authorizer)
+	orderCommandHandler := commands.NewOrderCommandHandler(orderDomainService, authorizer)
+	inventoryCommandHandler := commands.NewInventoryCommandHandler(inventoryDomainService, authorizer)
+	apiKeyCommandHandler := commands.NewAPIKeyCommandHandler(apiKeyDomainService, authorizer)
+
+	sessionCommandHandler := commands.NewSessionCommandHandler(revocations, apiKeyDomainService, authorizer)
+	privacyCommandHandler := commands.NewPrivacyCommandHandler(userPrivacyService, revocations, authorizer)
+
+	userSoftDeleteHandler := commands.NewSoftDeleteCommandHandler(userSoftDeleteService, authorizer, authz.ResourceUser)
+	profileSoftDeleteHandler := commands.NewSoftDeleteCommandHandler(profileSoftDeleteService, authorizer, authz.ResourceProfile)
+	productSoftDeleteHandler := commands.NewSoftDeleteCommandHandler(productSoftDeleteService, authorizer, authz.ResourceProduct)
+	orderSoftDeleteHandler := commands.NewSoftDeleteCommandHandler(orderSoftDeleteService, authorizer, authz.ResourceOrder)
+
+	// Initialize just-in-time user provisioning from token claims
+	userProvisioner := commands.NewUserProvisioner(commandBus, userDomainService, cfg.Auth.UserSyncInterval)
+
+	// Initialize the retention job purging soft deleted records past their retention period
+	retentionJob := commands.NewRetentionJob(commandBus, commands.NewRetentionPolicy(cfg.Retention), appLogger)
 
 	// Initialize query handlers
-	userQueryHandler := queries.NewUserQueryHandler(userRepo, profileRepo)
+	userQueryHandler := queries.NewUserQueryHandler(userRepo, profileRepo, authorizer)
 	productQueryHandler := queries.NewProductQueryHandler(productRepo)
-	orderQueryHandler := queries.NewOrderQueryHandler(orderRepo)
+	orderQueryHandler := queries.NewOrderQueryHandler(orderRepo, authorizer)
+	inventoryQueryHandler := queries.NewInventoryQueryHandler(inventoryDomainService, authorizer)
+	apiKeyQueryHandler := queries.NewAPIKeyQueryHandler(apiKeyDomainService, authorizer)
+	deletedUserQueryHandler := queries.NewDeletedQueryHandler(userSoftDeleteService, authorizer, authz.ResourceUser)
+	deletedProfileQueryHandler := queries.NewDeletedQueryHandler(profileSoftDeleteService, authorizer, authz.ResourceProfile)
+	deletedProductQueryHandler := queries.NewDeletedQueryHandler(productSoftDeleteService, authorizer, authz.ResourceProduct)
+	deletedOrderQueryHandler := queries.NewDeletedQueryHandler(orderSoftDeleteService, authorizer, authz.ResourceOrder)
+
+	// Register handlers with the buses
+	userCommandHandler.Register(commandBus)
+	productCommandHandler.Register(commandBus)
+	orderCommandHandler.Register(commandBus)
+	inventoryCommandHandler.Register(commandBus)
+	apiKeyCommandHandler.Register(commandBus)
+	sessionCommandHandler.Register(commandBus)
+	privacyCommandHandler.Register(commandBus)
+	userSoftDeleteHandler.Register(commandBus)
+	profileSoftDeleteHandler.Register(commandBus)
+	productSoftDeleteHandler.Register(commandBus)
+	orderSoftDeleteHandler.Register(commandBus)
+	userQueryHandler.Register(queryBus)
+	productQueryHandler.Register(queryBus)
+	orderQueryHandler.Register(queryBus)
+	inventoryQueryHandler.Register(queryBus)
+	apiKeyQueryHandler.Register(queryBus)
+	deletedUserQueryHandler.Register(queryBus)
+	deletedProfileQueryHandler.Register(queryBus)
+	deletedProductQueryHandler.Register(queryBus)
+	deletedOrderQueryHandler.Register(queryBus)
+
+	// Start the retention job when this instance opted in; by default purging is left to the CLI
+	retentionCtx, stopRetention := context.WithCancel(context.Background())
+	retentionDone := make(chan struct{})
+	go func() {
+		defer close(retentionDone)
+		if cfg.Retention.Interval > 0 {
+			retentionJob.Run(retentionCtx, cfg.Retention.Interval)
+		}
+	}()
 
 	// Initialize HTTP handlers
-	userHandler := handlers.NewUserHandler(userCommandHandler, userQueryHandler)
-	productHandler := handlers.NewProductHandler(productCommandHandler, productQueryHandler)
-
-	// Note: Order handlers are available but not yet integrated into the server
-	_ = orderCommandHandler // TODO: Integrate order handlers
-	_ = orderQueryHandler   // TODO: Integrate order handlers
+	authHandler := handlers.NewAuthHandler(authService, revocations, commandBus, handlers.RefreshCookieConfig{
+		Enabled: cfg.Auth.RefreshCookieEnabled,
+		Name:    cfg.Auth.RefreshCookieName,
+		Domain:  cfg.Auth.RefreshCookieDomain,
+		Secure:  cfg.Auth.RefreshCookieSecure,
+	})
+	apiKeyHandler := handlers.NewAPIKeyHandler(commandBus, queryBus)
+	userHandler := handlers.NewUserHandler(commandBus, queryBus)
+	productHandler := handlers.NewProductHandler(commandBus, queryBus)
+	orderHandler := handlers.NewOrderHandler(commandBus, queryBus)
+	softDeleteHandlers := handlers.NewSoftDeleteHandlers(commandBus, queryBus)
+	privacyHandler := handlers.NewPrivacyHandler(commandBus)
 
 	// Initialize HTTP server
 	server := httpServer.NewServer(
 		appLogger,
 		authService,
+		revocations,
+		auth.NewAPIKeyAuthenticator(apiKeyDomainService),
+		userProvisioner,
+		authHandler,
+		apiKeyHandler,
 		userHandler,
 		productHandler,
+		orderHandler,
+		softDeleteHandlers,
+		privacyHandler,
 	)
 
 	// Start HTTP server in a goroutine
@@ -183,6 +337,12 @@ func main() {
 		appLogger.Error("Failed to gracefully shutdown HTTP server", "error", err)
 	}
 
+	// Stop retention job and outbox relay
+	stopRetention()
+	<-retentionDone
+	stopRelay()
+	<-relayDone
+
 	// Close cache connection
 	if err := cacheService.Close(); err != nil {
 		appLogger.Error("Failed to close cache connection", "error", err)
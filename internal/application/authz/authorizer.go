@@ -0,0 +1,137 @@
+package authz
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"goclean/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrUnauthenticated = errors.New("authentication required")
+	ErrForbidden       = domain.NewForbidden("permission_denied", "permission denied")
+)
+
+// Action is an operation a principal wants to perform
+type Action string
+
+// ActionAny matches every action in a rule
+const ActionAny Action = "*"
+
+// Actions checked by the command and query handlers
+const (
+	ActionUserRead        Action = "user:read"
+	ActionUserList        Action = "user:list"
+	ActionUserUpdate      Action = "user:update"
+	ActionUserDelete      Action = "user:delete"
+	ActionUserRestore     Action = "user:restore"
+	ActionUserReadDeleted Action = "user:read_deleted"
+	ActionUserPurge       Action = "user:purge"
+	ActionUserExport      Action = "user:export"
+	ActionUserErase       Action = "user:erase"
+
+	ActionProfileDelete      Action = "profile:delete"
+	ActionProfileRestore     Action = "profile:restore"
+	ActionProfileReadDeleted Action = "profile:read_deleted"
+	ActionProfilePurge       Action = "profile:purge"
+
+	ActionProductCreate      Action = "product:create"
+	ActionProductUpdate      Action = "product:update"
+	ActionProductDelete      Action = "product:delete"
+	ActionProductRestore     Action = "product:restore"
+	ActionProductReadDeleted Action = "product:read_deleted"
+	ActionProductPurge       Action = "product:purge"
+	ActionProductRestock     Action = "product:restock"
+	ActionProductReadStock   Action = "product:read_stock"
+
+	ActionOrderCreate       Action = "order:create"
+	ActionOrderRead         Action = "order:read"
+	ActionOrderList         Action = "order:list"
+	ActionOrderCancel       Action = "order:cancel"
+	ActionOrderUpdateStatus Action = "order:update_status"
+	ActionOrderDelete       Action = "order:delete"
+	ActionOrderRestore      Action = "order:restore"
+	ActionOrderReadDeleted  Action = "order:read_deleted"
+	ActionOrderPurge        Action = "order:purge"
+
+	ActionAPIKeyManage   Action = "api_key:manage"
+	ActionSessionsRevoke Action = "session:revoke"
+)
+
+// Resource types
+const (
+	ResourceUser    = "user"
+	ResourceProfile = "profile"
+	ResourceProduct = "product"
+	ResourceOrder   = "order"
+	ResourceAPIKey  = "api_key"
+)
+
+// LifecycleActions are the actions that guard the soft delete lifecycle of a resource type
+type LifecycleActions struct {
+	Delete      Action
+	Restore     Action
+	ReadDeleted Action
+	Purge       Action
+}
+
+// Lifecycle returns the soft delete lifecycle actions of a resource type, e.g. "order:restore"
+func Lifecycle(resourceType string) LifecycleActions {
+	return LifecycleActions{
+		Delete:      Action(resourceType + ":delete"),
+		Restore:     Action(resourceType + ":restore"),
+		ReadDeleted: Action(resourceType + ":read_deleted"),
+		Purge:       Action(resourceType + ":purge"),
+	}
+}
+
+// Resource describes what an action is performed on. OwnerID is the user the resource
+// belongs to; it is uuid.Nil for resources without an owner, such as collections.
+type Resource struct {
+	Type       string
+	ID         uuid.UUID
+	OwnerID    uuid.UUID
+	Attributes map[string]string
+}
+
+// OwnedBy returns a resource of the given type owned by ownerID
+func OwnedBy(resourceType string, id, ownerID uuid.UUID) Resource {
+	return Resource{Type: resourceType, ID: id, OwnerID: ownerID}
+}
+
+// Authorizer decides whether a principal may perform an action on a resource
+type Authorizer interface {
+	// Can returns nil when allowed, ErrUnauthenticated for a nil principal and ErrForbidden otherwise
+	Can(ctx context.Context, principal *Principal, action Action, resource Resource) error
+}
+
+// Authorize checks the action for the principal carried by ctx
+func Authorize(ctx context.Context, authorizer Authorizer, action Action, resource Resource) error {
+	principal, _ := PrincipalFromContext(ctx)
+	return authorizer.Can(ctx, principal, action, resource)
+}
+
+// PolicyAuthorizer grants an action when any of its rules allows it; everything else is denied
+type PolicyAuthorizer struct {
+	rules []Rule
+}
+
+// NewPolicyAuthorizer creates a new policy authorizer
+func NewPolicyAuthorizer(rules ...Rule) *PolicyAuthorizer {
+	return &PolicyAuthorizer{rules: rules}
+}
+
+// Can implements Authorizer
+func (a *PolicyAuthorizer) Can(ctx context.Context, principal *Principal, action Action, resource Resource) error {
+	if principal == nil {
+		return ErrUnauthenticated
+	}
+	for _, rule := range a.rules {
+		if rule.allows(ctx, principal, action, resource) {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %s", ErrForbidden, action)
+}
@@ -0,0 +1,146 @@
+package authz
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// Condition is an extra check a rule applies to the principal and resource
+type Condition func(ctx context.Context, principal *Principal, resource Resource) bool
+
+// Rule allows its actions to principals that hold any of its roles (or to any principal
+// when Roles is empty) and, if set, satisfy its condition
+type Rule struct {
+	Actions   []Action
+	Roles     []string
+	Condition Condition
+}
+
+// allows checks whether the rule grants the action
+func (r Rule) allows(ctx context.Context, principal *Principal, action Action, resource Resource) bool {
+	if !r.matchesAction(action) {
+		return false
+	}
+	if len(r.Roles) > 0 && !hasAnyRole(principal, r.Roles) {
+		return false
+	}
+	return r.Condition == nil || r.Condition(ctx, principal, resource)
+}
+
+func (r Rule) matchesAction(action Action) bool {
+	for _, a := range r.Actions {
+		if a == action || a == ActionAny {
+			return true
+		}
+	}
+	return false
+}
+
+func hasAnyRole(principal *Principal, roles []string) bool {
+	for _, role := range roles {
+		if principal.HasRole(role) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsOwner matches resources owned by the principal
+func IsOwner() Condition {
+	return func(ctx context.Context, principal *Principal, resource Resource) bool {
+		return resource.OwnerID != uuid.Nil && principal.ID == resource.OwnerID.String()
+	}
+}
+
+// PrincipalAttribute matches principals whose attribute has one of the values
+func PrincipalAttribute(key string, values ...string) Condition {
+	return func(ctx context.Context, principal *Principal, resource Resource) bool {
+		return containsValue(values, principal.Attributes[key])
+	}
+}
+
+// ResourceAttribute matches resources whose attribute has one of the values
+func ResourceAttribute(key string, values ...string) Condition {
+	return func(ctx context.Context, principal *Principal, resource Resource) bool {
+		return containsValue(values, resource.Attributes[key])
+	}
+}
+
+// Not negates a condition
+func Not(condition Condition) Condition {
+	return func(ctx context.Context, principal *Principal, resource Resource) bool {
+		return !condition(ctx, principal, resource)
+	}
+}
+
+// All matches when every condition matches
+func All(conditions ...Condition) Condition {
+	return func(ctx context.Context, principal *Principal, resource Resource) bool {
+		for _, condition := range conditions {
+			if !condition(ctx, principal, resource) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+func containsValue(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// NewDefaultAuthorizer creates the authorizer enforcing DefaultPolicy, shared by the servers
+// and the CLI
+func NewDefaultAuthorizer() *PolicyAuthorizer {
+	return NewPolicyAuthorizer(DefaultPolicy()...)
+}
+
+// DefaultPolicy returns the application's authorization rules
+func DefaultPolicy() []Rule {
+	return []Rule{
+		// Jobs and CLI commands run as the system principal; only they purge deleted resources.
+		// The role alone is not enough, since token and API key roles come from outside.
+		{
+			Actions:   []Action{ActionAny},
+			Roles:     []string{RoleSystem},
+			Condition: PrincipalAttribute(AttrAuthMethod, AuthMethodSystem),
+		},
+
+		// Admins manage users, products and their stock, and orders, including their soft
+		// delete lifecycle, and answer data export and erasure requests
+		{
+			Actions: []Action{
+				ActionUserRead, ActionUserList, ActionUserUpdate, ActionUserDelete, ActionUserRestore, ActionUserReadDeleted,
+				ActionUserExport, ActionUserErase,
+				ActionProfileDelete, ActionProfileRestore, ActionProfileReadDeleted,
+				ActionProductCreate, ActionProductUpdate, ActionProductDelete, ActionProductRestore, ActionProductReadDeleted,
+				ActionProductRestock, ActionProductReadStock,
+				ActionOrderCreate, ActionOrderRead, ActionOrderList, ActionOrderCancel, ActionOrderUpdateStatus,
+				ActionOrderDelete, ActionOrderRestore, ActionOrderReadDeleted,
+			},
+			Roles: []string{"admin"},
+		},
+
+		// Credentials are managed by interactive admins only, so a leaked API key cannot mint new ones
+		{
+			Actions:   []Action{ActionAPIKeyManage, ActionSessionsRevoke},
+			Roles:     []string{"admin"},
+			Condition: Not(PrincipalAttribute(AttrAuthMethod, AuthMethodAPIKey)),
+		},
+
+		// Users read and update their own account and place, read and cancel their own orders
+		{
+			Actions:   []Action{ActionUserRead, ActionUserUpdate, ActionOrderCreate, ActionOrderRead, ActionOrderList, ActionOrderCancel},
+			Condition: IsOwner(),
+		},
+
+		// Any authenticated user may list products for sale
+		{Actions: []Action{ActionProductCreate}},
+	}
+}
@@ -0,0 +1,78 @@
+package authz
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// Principal attribute keys and values
+const (
+	AttrAuthMethod = "auth_method"
+
+	AuthMethodToken  = "token"
+	AuthMethodAPIKey = "api_key"
+	AuthMethodSystem = "system"
+)
+
+// RoleSystem is held by the system principal used for jobs and CLI commands
+const RoleSystem = "system"
+
+// reservedRoles are granted by the application itself and never by a credential
+var reservedRoles = []string{RoleSystem}
+
+// IsReservedRole checks if a role is reserved for in-process principals
+func IsReservedRole(role string) bool {
+	return containsValue(reservedRoles, role)
+}
+
+// Principal is the caller an authorization decision is made for. It is independent of
+// how the caller authenticated, so REST, gRPC and the CLI are judged by the same policy.
+type Principal struct {
+	ID         string            // Subject ID, normally the local user ID
+	Roles      []string          // Roles and scopes granted to the caller
+	Attributes map[string]string // Additional facts about the caller, e.g. AttrAuthMethod
+}
+
+// NewPrincipal creates a new principal
+func NewPrincipal(id string, roles []string, attributes map[string]string) *Principal {
+	if attributes == nil {
+		attributes = make(map[string]string)
+	}
+	return &Principal{
+		ID:         id,
+		Roles:      roles,
+		Attributes: attributes,
+	}
+}
+
+// SystemPrincipal returns the principal for trusted in-process callers such as jobs and CLI commands
+func SystemPrincipal() *Principal {
+	return NewPrincipal(uuid.Nil.String(), []string{RoleSystem}, map[string]string{
+		AttrAuthMethod: AuthMethodSystem,
+	})
+}
+
+// HasRole checks if the principal has the role
+func (p *Principal) HasRole(role string) bool {
+	for _, r := range p.Roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+// principalContextKey is the context key for the current principal
+type principalContextKey struct{}
+
+// WithPrincipal returns a copy of ctx carrying the principal
+func WithPrincipal(ctx context.Context, principal *Principal) context.Context {
+	return context.WithValue(ctx, principalContextKey{}, principal)
+}
+
+// PrincipalFromContext returns the principal stored in ctx
+func PrincipalFromContext(ctx context.Context) (*Principal, bool) {
+	principal, ok := ctx.Value(principalContextKey{}).(*Principal)
+	return principal, ok && principal != nil
+}
@@ -0,0 +1,156 @@
+package bus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+)
+
+var (
+	ErrHandlerNotFound  = errors.New("no handler registered")
+	ErrUnexpectedResult = errors.New("unexpected handler result")
+)
+
+// Kind tells commands and queries apart
+type Kind string
+
+const (
+	KindCommand Kind = "command"
+	KindQuery   Kind = "query"
+)
+
+// Message is a command or query travelling through a bus
+type Message struct {
+	Kind    Kind
+	Name    string // Type name of the payload, e.g. "CreateOrderCommand" or "SoftDeleteCommand[*entities.Order]"
+	Payload any
+}
+
+// HandlerFunc handles a message and returns its result
+type HandlerFunc func(ctx context.Context, msg Message) (any, error)
+
+// Middleware wraps a handler with cross-cutting behaviour such as logging or transactions
+type Middleware func(next HandlerFunc) HandlerFunc
+
+// bus routes messages to the handler registered for their payload type. Handlers are
+// registered at startup, before the bus is used, and wrapped in the bus middleware once.
+type bus struct {
+	kind       Kind
+	middleware []Middleware
+	handlers   map[reflect.Type]HandlerFunc
+}
+
+func newBus(kind Kind, middleware []Middleware) bus {
+	return bus{
+		kind:       kind,
+		middleware: middleware,
+		handlers:   make(map[reflect.Type]HandlerFunc),
+	}
+}
+
+// register adds the handler for payloads of type t. Registering a type twice is a
+// programming error and panics, like http.ServeMux does for duplicate patterns.
+func (b *bus) register(t reflect.Type, handler HandlerFunc) {
+	if _, exists := b.handlers[t]; exists {
+		panic(fmt.Sprintf("bus: %s handler for %s registered twice", b.kind, messageName(t)))
+	}
+
+	// The first middleware is the outermost
+	for i := len(b.middleware) - 1; i >= 0; i-- {
+		handler = b.middleware[i](handler)
+	}
+	b.handlers[t] = handler
+}
+
+// dispatch runs the handler registered for the payload type
+func (b *bus) dispatch(ctx context.Context, payload any) (any, error) {
+	t := reflect.TypeOf(payload)
+	handler, ok := b.handlers[t]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s %v", ErrHandlerNotFound, b.kind, t)
+	}
+	return handler(ctx, Message{Kind: b.kind, Name: messageName(t), Payload: payload})
+}
+
+// CommandBus dispatches commands to their handlers
+type CommandBus struct {
+	bus
+}
+
+// NewCommandBus creates a new command bus running every command through the middleware in order
+func NewCommandBus(middleware ...Middleware) *CommandBus {
+	return &CommandBus{bus: newBus(KindCommand, middleware)}
+}
+
+// Dispatch runs the handler registered for the command
+func (b *CommandBus) Dispatch(ctx context.Context, cmd any) error {
+	_, err := b.dispatch(ctx, cmd)
+	return err
+}
+
+// QueryBus dispatches queries to their handlers
+type QueryBus struct {
+	bus
+}
+
+// NewQueryBus creates a new query bus running every query through the middleware in order
+func NewQueryBus(middleware ...Middleware) *QueryBus {
+	return &QueryBus{bus: newBus(KindQuery, middleware)}
+}
+
+// RegisterCommand registers the handler for commands of type C
+func RegisterCommand[C any](b *CommandBus, handler func(ctx context.Context, cmd C) error) {
+	b.register(reflect.TypeFor[C](), func(ctx context.Context, msg Message) (any, error) {
+		return nil, handler(ctx, msg.Payload.(C))
+	})
+}
+
+// RegisterCommandWithResult registers the handler for commands of type C that return a result
+func RegisterCommandWithResult[C, R any](b *CommandBus, handler func(ctx context.Context, cmd C) (R, error)) {
+	b.register(reflect.TypeFor[C](), func(ctx context.Context, msg Message) (any, error) {
+		return handler(ctx, msg.Payload.(C))
+	})
+}
+
+// RegisterQuery registers the handler for queries of type Q
+func RegisterQuery[Q, R any](b *QueryBus, handler func(ctx context.Context, query Q) (R, error)) {
+	b.register(reflect.TypeFor[Q](), func(ctx context.Context, msg Message) (any, error) {
+		return handler(ctx, msg.Payload.(Q))
+	})
+}
+
+// DispatchWithResult runs the handler registered for the command and returns its result
+func DispatchWithResult[R any](ctx context.Context, b *CommandBus, cmd any) (R, error) {
+	result, err := b.dispatch(ctx, cmd)
+	return resultAs[R](result, err)
+}
+
+// Ask runs the handler registered for the query and returns its result
+func Ask[R any](ctx context.Context, b *QueryBus, query any) (R, error) {
+	result, err := b.dispatch(ctx, query)
+	return resultAs[R](result, err)
+}
+
+func resultAs[R any](result any, err error) (R, error) {
+	var zero R
+	if err != nil {
+		return zero, err
+	}
+	typed, ok := result.(R)
+	if !ok {
+		return zero, fmt.Errorf("%w: got %T, want %v", ErrUnexpectedResult, result, reflect.TypeFor[R]())
+	}
+	return typed, nil
+}
+
+// packagePath matches the import path prefixes reflect puts in the type arguments of
+// generic type names, e.g. "goclean/internal/domain/" in "[*goclean/internal/domain/entities.User]"
+var packagePath = regexp.MustCompile(`[\w.-]+(/[\w.-]+)*/`)
+
+// messageName returns the name of a message type, with package paths stripped from
+// any type arguments
+func messageName(t reflect.Type) string {
+	return packagePath.ReplaceAllString(t.Name(), "")
+}
@@ -0,0 +1,39 @@
+package bus
+
+import (
+	"goclean/internal/domain/repositories"
+	"log/slog"
+	"time"
+)
+
+// Commands failing with an optimistic locking conflict are retried this often, backing off
+// between attempts
+const (
+	retryAttempts = 3
+	retryBackoff  = 50 * time.Millisecond
+)
+
+// NewDefaultCommandBus creates the command bus shared by the servers and the CLI. Commands are
+// logged, measured, authorized and checked against their validate tags, and run in a
+// transaction that is retried on optimistic locking conflicts.
+func NewDefaultCommandBus(logger *slog.Logger, recorder MetricsRecorder, validator Validator, uow repositories.UnitOfWork) *CommandBus {
+	return NewCommandBus(
+		Logging(logger),
+		Metrics(recorder),
+		Authorization(),
+		Validation(validator),
+		Retry(retryAttempts, retryBackoff, IsConcurrentModification),
+		Transaction(uow),
+	)
+}
+
+// NewDefaultQueryBus creates the query bus shared by the servers. Queries are logged,
+// measured, authorized and checked against their validate tags.
+func NewDefaultQueryBus(logger *slog.Logger, recorder MetricsRecorder, validator Validator) *QueryBus {
+	return NewQueryBus(
+		Logging(logger),
+		Metrics(recorder),
+		Authorization(),
+		Validation(validator),
+	)
+}
@@ -0,0 +1,153 @@
+package bus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"goclean/internal/application/authz"
+	"goclean/internal/domain"
+	"goclean/internal/domain/repositories"
+	"log/slog"
+	"time"
+)
+
+// ErrInvalidMessage is returned for commands and queries that fail validation
+var ErrInvalidMessage = domain.NewValidation("invalid_message", "invalid message")
+
+// Validator validates message payloads, e.g. against their struct tags
+type Validator interface {
+	Validate(payload any) error
+}
+
+// Validatable is implemented by payloads that check their own invariants
+type Validatable interface {
+	Validate() error
+}
+
+// AnonymousAllowed is implemented by payloads that callers without a principal may send,
+// such as registration or catalogue queries
+type AnonymousAllowed interface {
+	AllowAnonymous() bool
+}
+
+// MetricsRecorder records the outcome and duration of handled messages
+type MetricsRecorder interface {
+	ObserveMessage(msg Message, duration time.Duration, err error)
+}
+
+// Logging logs every message with its outcome and duration
+func Logging(logger *slog.Logger) Middleware {
+	return func(next HandlerFunc) HandlerFunc {
+		return func(ctx context.Context, msg Message) (any, error) {
+			start := time.Now()
+			result, err := next(ctx, msg)
+
+			attrs := []any{
+				"kind", msg.Kind,
+				"name", msg.Name,
+				"duration", time.Since(start),
+			}
+			if principal, ok := authz.PrincipalFromContext(ctx); ok {
+				attrs = append(attrs, "principal", principal.ID)
+			}
+			if err != nil {
+				logger.WarnContext(ctx, "Message failed", append(attrs, "error", err)...)
+			} else {
+				logger.DebugContext(ctx, "Message handled", attrs...)
+			}
+			return result, err
+		}
+	}
+}
+
+// Metrics reports every message to the recorder
+func Metrics(recorder MetricsRecorder) Middleware {
+	return func(next HandlerFunc) HandlerFunc {
+		return func(ctx context.Context, msg Message) (any, error) {
+			start := time.Now()
+			result, err := next(ctx, msg)
+			recorder.ObserveMessage(msg, time.Since(start), err)
+			return result, err
+		}
+	}
+}
+
+// Authorization rejects messages from callers without a principal unless the payload
+// allows anonymous access. Checks against a specific resource stay in the handlers,
+// which are the only place the resource is loaded.
+func Authorization() Middleware {
+	return func(next HandlerFunc) HandlerFunc {
+		return func(ctx context.Context, msg Message) (any, error) {
+			if anonymous, ok := msg.Payload.(AnonymousAllowed); ok && anonymous.AllowAnonymous() {
+				return next(ctx, msg)
+			}
+			if _, ok := authz.PrincipalFromContext(ctx); !ok {
+				return nil, authz.ErrUnauthenticated
+			}
+			return next(ctx, msg)
+		}
+	}
+}
+
+// Validation rejects payloads that fail any of the validators or their own Validate method
+func Validation(validators ...Validator) Middleware {
+	return func(next HandlerFunc) HandlerFunc {
+		return func(ctx context.Context, msg Message) (any, error) {
+			for _, validator := range validators {
+				if err := validator.Validate(msg.Payload); err != nil {
+					return nil, fmt.Errorf("%w: %w", ErrInvalidMessage, err)
+				}
+			}
+			if validatable, ok := msg.Payload.(Validatable); ok {
+				if err := validatable.Validate(); err != nil {
+					return nil, fmt.Errorf("%w: %w", ErrInvalidMessage, err)
+				}
+			}
+			return next(ctx, msg)
+		}
+	}
+}
+
+// Transaction handles every message inside a unit of work, so all repository calls
+// made by the handler commit or roll back together
+func Transaction(uow repositories.UnitOfWork) Middleware {
+	return func(next HandlerFunc) HandlerFunc {
+		return func(ctx context.Context, msg Message) (any, error) {
+			var result any
+			err := uow.Do(ctx, func(ctx context.Context) error {
+				var err error
+				result, err = next(ctx, msg)
+				return err
+			})
+			return result, err
+		}
+	}
+}
+
+// Retry handles a message again when it fails with an error accepted by retryable,
+// waiting backoff times the attempt number in between. It must wrap Transaction so
+// every attempt runs in a fresh transaction.
+func Retry(attempts int, backoff time.Duration, retryable func(error) bool) Middleware {
+	return func(next HandlerFunc) HandlerFunc {
+		return func(ctx context.Context, msg Message) (any, error) {
+			for attempt := 1; ; attempt++ {
+				result, err := next(ctx, msg)
+				if err == nil || attempt >= attempts || !retryable(err) {
+					return result, err
+				}
+
+				select {
+				case <-ctx.Done():
+					return nil, err
+				case <-time.After(time.Duration(attempt) * backoff):
+				}
+			}
+		}
+	}
+}
+
+// IsConcurrentModification reports whether err is an optimistic locking conflict,
+// which succeeds on retry once the handler reloads the aggregate
+func IsConcurrentModification(err error) bool {
+	return errors.Is(err, repositories.ErrConcurrentModification)
+}
@@ -0,0 +1,82 @@
+package commands
+
+import (
+	"context"
+	"goclean/internal/application/authz"
+	"goclean/internal/application/bus"
+	"goclean/internal/domain"
+	"goclean/internal/domain/entities"
+	"goclean/internal/domain/services"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ErrReservedScope is returned when an API key would be granted a role reserved for the application
+var ErrReservedScope = domain.NewValidation("reserved_scope", "API key scopes cannot include reserved roles")
+
+// IssueAPIKeyCommand represents a command to issue an API key
+type IssueAPIKeyCommand struct {
+	Name      string     `json:"name" validate:"required,max=100"`
+	OwnerID   uuid.UUID  `json:"owner_id" validate:"required"`
+	Scopes    []string   `json:"scopes"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	CreatedBy uuid.UUID  `json:"created_by" validate:"required"`
+}
+
+// RevokeAPIKeyCommand represents a command to revoke an API key
+type RevokeAPIKeyCommand struct {
+	ID uuid.UUID `json:"id" validate:"required"`
+}
+
+// IssuedAPIKey is the result of issuing an API key. Key holds the plaintext key,
+// which is only available at this point.
+type IssuedAPIKey struct {
+	APIKey *entities.APIKey
+	Key    string
+}
+
+// APIKeyCommandHandler handles API key commands
+type APIKeyCommandHandler struct {
+	apiKeyService *services.APIKeyDomainService
+	authorizer    authz.Authorizer
+}
+
+// NewAPIKeyCommandHandler creates a new API key command handler
+func NewAPIKeyCommandHandler(apiKeyService *services.APIKeyDomainService, authorizer authz.Authorizer) *APIKeyCommandHandler {
+	return &APIKeyCommandHandler{
+		apiKeyService: apiKeyService,
+		authorizer:    authorizer,
+	}
+}
+
+// Register registers the handler's commands with the bus
+func (h *APIKeyCommandHandler) Register(b *bus.CommandBus) {
+	bus.RegisterCommandWithResult(b, h.HandleIssue)
+	bus.RegisterCommand(b, h.HandleRevoke)
+}
+
+// HandleIssue handles IssueAPIKeyCommand
+func (h *APIKeyCommandHandler) HandleIssue(ctx context.Context, cmd IssueAPIKeyCommand) (*IssuedAPIKey, error) {
+	if err := authz.Authorize(ctx, h.authorizer, authz.ActionAPIKeyManage, authz.Resource{Type: authz.ResourceAPIKey}); err != nil {
+		return nil, err
+	}
+	for _, scope := range cmd.Scopes {
+		if authz.IsReservedRole(scope) {
+			return nil, ErrReservedScope
+		}
+	}
+	key, plaintext, err := h.apiKeyService.IssueAPIKey(ctx, cmd.Name, cmd.OwnerID, cmd.Scopes, cmd.ExpiresAt, cmd.CreatedBy)
+	if err != nil {
+		return nil, err
+	}
+	return &IssuedAPIKey{APIKey: key, Key: plaintext}, nil
+}
+
+// HandleRevoke handles RevokeAPIKeyCommand
+func (h *APIKeyCommandHandler) HandleRevoke(ctx context.Context, cmd RevokeAPIKeyCommand) error {
+	if err := authz.Authorize(ctx, h.authorizer, authz.ActionAPIKeyManage, authz.Resource{Type: authz.ResourceAPIKey, ID: cmd.ID}); err != nil {
+		return err
+	}
+	return h.apiKeyService.RevokeAPIKey(ctx, cmd.ID)
+}
@@ -2,6 +2,7 @@ package commands
 
 import (
 	"context"
+	"fmt"
 	"goclean/internal/domain/entities"
 
 	"github.com/google/uuid"
@@ -24,19 +25,33 @@ type CreateUserCommand struct {
 	Profile   *CreateProfileData `json:"profile,omitempty"`
 }
 
+// AllowAnonymous lets visitors register without a principal
+func (c CreateUserCommand) AllowAnonymous() bool {
+	return true
+}
+
 type CreateProfileData struct {
 	Bio         string `json:"bio"`
 	Avatar      string `json:"avatar"`
 	DateOfBirth string `json:"date_of_birth"`
 }
 
-// UpdateUserCommand represents a command to update a user
+// UpdateUserCommand represents a command to partially update a user; nil fields are left unchanged
 type UpdateUserCommand struct {
 	ID        uuid.UUID `json:"id" validate:"required"`
-	Email     string    `json:"email" validate:"email"`
-	Username  string    `json:"username" validate:"min=3,max=50"`
-	FirstName string    `json:"first_name" validate:"min=1,max=100"`
-	LastName  string    `json:"last_name" validate:"min=1,max=100"`
+	Email     *string   `json:"email,omitempty" validate:"omitempty,email"`
+	Username  *string   `json:"username,omitempty" validate:"omitempty,min=3,max=50"`
+	FirstName *string   `json:"first_name,omitempty" validate:"omitempty,min=1,max=100"`
+	LastName  *string   `json:"last_name,omitempty" validate:"omitempty,min=1,max=100"`
+}
+
+// ProvisionUserCommand represents a command to create or sync a user from identity provider claims
+type ProvisionUserCommand struct {
+	ID        uuid.UUID `json:"id" validate:"required"`
+	Email     string    `json:"email" validate:"required,email"`
+	Username  string    `json:"username" validate:"required"`
+	FirstName string    `json:"first_name"`
+	LastName  string    `json:"last_name"`
 }
 
 // DeleteUserCommand represents a command to delete a user
@@ -46,22 +61,24 @@ type DeleteUserCommand struct {
 
 // CreateProductCommand represents a command to create a product
 type CreateProductCommand struct {
-	Name        string    `json:"name" validate:"required,min=1,max=255"`
-	Description string    `json:"description"`
-	Price       float64   `json:"price" validate:"required,gt=0"`
-	SKU         string    `json:"sku" validate:"required,min=1,max=100"`
-	Category    string    `json:"category" validate:"required"`
-	CreatedBy   uuid.UUID `json:"created_by" validate:"required"`
+	Name        string         `json:"name" validate:"required,min=1,max=255"`
+	Description string         `json:"description"`
+	Price       entities.Money `json:"price" validate:"required"`
+	SKU         string         `json:"sku" validate:"required,min=1,max=100"`
+	Category    string         `json:"category" validate:"required"`
+	Stock       int            `json:"stock" validate:"min=0"`
+	CreatedBy   uuid.UUID      `json:"created_by" validate:"required"`
 }
 
-// UpdateProductCommand represents a command to update a product
+// UpdateProductCommand represents a command to partially update a product; nil fields are left unchanged
 type UpdateProductCommand struct {
-	ID          uuid.UUID `json:"id" validate:"required"`
-	Name        string    `json:"name" validate:"min=1,max=255"`
-	Description string    `json:"description"`
-	Price       float64   `json:"price" validate:"gt=0"`
-	Category    string    `json:"category"`
-	IsActive    *bool     `json:"is_active"`
+	ID          uuid.UUID       `json:"id" validate:"required"`
+	Name        *string         `json:"name,omitempty" validate:"omitempty,min=1,max=255"`
+	Description *string         `json:"description,omitempty"`
+	Price       *entities.Money `json:"price,omitempty"`
+	SKU         *string         `json:"sku,omitempty" validate:"omitempty,min=1,max=100"`
+	Category    *string         `json:"category,omitempty"`
+	IsActive    *bool           `json:"is_active,omitempty"`
 }
 
 // DeleteProductCommand represents a command to delete a product
@@ -72,7 +89,7 @@ type DeleteProductCommand struct {
 // CreateOrderCommand represents a command to create an order
 type CreateOrderCommand struct {
 	UserID uuid.UUID             `json:"user_id" validate:"required"`
-	Items  []CreateOrderItemData `json:"items" validate:"required,min=1"`
+	Items  []CreateOrderItemData `json:"items" validate:"required,min=1,dive"`
 }
 
 type CreateOrderItemData struct {
@@ -82,11 +99,23 @@ type CreateOrderItemData struct {
 
 // UpdateOrderStatusCommand represents a command to update order status
 type UpdateOrderStatusCommand struct {
-	ID     uuid.UUID            `json:"id" validate:"required"`
-	Status entities.OrderStatus `json:"status" validate:"required"`
+	ID        uuid.UUID            `json:"id" validate:"required"`
+	Status    entities.OrderStatus `json:"status" validate:"required"`
+	ChangedBy uuid.UUID            `json:"changed_by" validate:"required"`
+	Reason    string               `json:"reason,omitempty"`
+}
+
+// Validate checks that the target status exists
+func (c UpdateOrderStatusCommand) Validate() error {
+	if !c.Status.IsValid() {
+		return fmt.Errorf("invalid order status %q", c.Status)
+	}
+	return nil
 }
 
 // CancelOrderCommand represents a command to cancel an order
 type CancelOrderCommand struct {
-	ID uuid.UUID `json:"id" validate:"required"`
+	ID          uuid.UUID `json:"id" validate:"required"`
+	CancelledBy uuid.UUID `json:"cancelled_by" validate:"required"`
+	Reason      string    `json:"reason,omitempty"`
 }
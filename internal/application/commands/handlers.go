@@ -2,6 +2,8 @@ package commands
 
 import (
 	"context"
+	"goclean/internal/application/authz"
+	"goclean/internal/application/bus"
 	"goclean/internal/domain/entities"
 	"goclean/internal/domain/services"
 	"time"
@@ -12,17 +14,26 @@ import (
 // UserCommandHandler handles user-related commands
 type UserCommandHandler struct {
 	userService *services.UserDomainService
+	authorizer  authz.Authorizer
 }
 
 // NewUserCommandHandler creates a new user command handler
-func NewUserCommandHandler(userService *services.UserDomainService) *UserCommandHandler {
+func NewUserCommandHandler(userService *services.UserDomainService, authorizer authz.Authorizer) *UserCommandHandler {
 	return &UserCommandHandler{
 		userService: userService,
+		authorizer:  authorizer,
 	}
 }
 
-// Handle handles CreateUserCommand
-func (h *UserCommandHandler) Handle(ctx context.Context, cmd CreateUserCommand) error {
+// Register registers the handler's commands with the bus
+func (h *UserCommandHandler) Register(b *bus.CommandBus) {
+	bus.RegisterCommandWithResult(b, h.Handle)
+	bus.RegisterCommandWithResult(b, h.HandleUpdateUser)
+	bus.RegisterCommand(b, h.HandleProvisionUser)
+}
+
+// Handle handles CreateUserCommand and returns the created user with its profile
+func (h *UserCommandHandler) Handle(ctx context.Context, cmd CreateUserCommand) (*entities.User, error) {
 	user := entities.NewUser(cmd.Email, cmd.Username, cmd.FirstName, cmd.LastName)
 
 	var profile *entities.Profile
@@ -36,57 +47,140 @@ func (h *UserCommandHandler) Handle(ctx context.Context, cmd CreateUserCommand)
 		}
 	}
 
-	return h.userService.CreateUserWithProfile(ctx, user, profile)
+	if err := h.userService.CreateUserWithProfile(ctx, user, profile); err != nil {
+		return nil, err
+	}
+	user.Profile = profile
+	return user, nil
+}
+
+// HandleUpdateUser handles UpdateUserCommand and returns the updated user
+func (h *UserCommandHandler) HandleUpdateUser(ctx context.Context, cmd UpdateUserCommand) (*entities.User, error) {
+	if err := authz.Authorize(ctx, h.authorizer, authz.ActionUserUpdate, authz.OwnedBy(authz.ResourceUser, cmd.ID, cmd.ID)); err != nil {
+		return nil, err
+	}
+
+	return h.userService.UpdateUser(ctx, cmd.ID, entities.UserChanges{
+		Email:     cmd.Email,
+		Username:  cmd.Username,
+		FirstName: cmd.FirstName,
+		LastName:  cmd.LastName,
+	})
+}
+
+// HandleProvisionUser handles ProvisionUserCommand
+func (h *UserCommandHandler) HandleProvisionUser(ctx context.Context, cmd ProvisionUserCommand) error {
+	_, err := h.userService.ProvisionUser(ctx, cmd.ID, cmd.Email, cmd.Username, cmd.FirstName, cmd.LastName)
+	return err
 }
 
 // ProductCommandHandler handles product-related commands
 type ProductCommandHandler struct {
 	productService *services.ProductDomainService
+	authorizer     authz.Authorizer
 }
 
 // NewProductCommandHandler creates a new product command handler
-func NewProductCommandHandler(productService *services.ProductDomainService) *ProductCommandHandler {
+func NewProductCommandHandler(productService *services.ProductDomainService, authorizer authz.Authorizer) *ProductCommandHandler {
 	return &ProductCommandHandler{
 		productService: productService,
+		authorizer:     authorizer,
 	}
 }
 
-// Handle handles CreateProductCommand
-func (h *ProductCommandHandler) Handle(ctx context.Context, cmd CreateProductCommand) error {
+// Register registers the handler's commands with the bus
+func (h *ProductCommandHandler) Register(b *bus.CommandBus) {
+	bus.RegisterCommandWithResult(b, h.Handle)
+	bus.RegisterCommandWithResult(b, h.HandleUpdateProduct)
+}
+
+// Handle handles CreateProductCommand and returns the created product
+func (h *ProductCommandHandler) Handle(ctx context.Context, cmd CreateProductCommand) (*entities.Product, error) {
+	if err := authz.Authorize(ctx, h.authorizer, authz.ActionProductCreate, authz.Resource{Type: authz.ResourceProduct}); err != nil {
+		return nil, err
+	}
+
 	product := entities.NewProduct(cmd.Name, cmd.Description, cmd.SKU, cmd.Category, cmd.Price, cmd.CreatedBy)
 
 	if err := h.productService.ValidateProduct(product); err != nil {
-		return err
+		return nil, err
+	}
+
+	if err := h.productService.CreateProduct(ctx, product, cmd.Stock); err != nil {
+		return nil, err
+	}
+	return product, nil
+}
+
+// HandleUpdateProduct handles UpdateProductCommand and returns the updated product
+func (h *ProductCommandHandler) HandleUpdateProduct(ctx context.Context, cmd UpdateProductCommand) (*entities.Product, error) {
+	if err := authz.Authorize(ctx, h.authorizer, authz.ActionProductUpdate, authz.Resource{Type: authz.ResourceProduct, ID: cmd.ID}); err != nil {
+		return nil, err
 	}
 
-	return h.productService.CreateProduct(ctx, product)
+	return h.productService.UpdateProduct(ctx, cmd.ID, entities.ProductChanges{
+		Name:        cmd.Name,
+		Description: cmd.Description,
+		Price:       cmd.Price,
+		SKU:         cmd.SKU,
+		Category:    cmd.Category,
+		IsActive:    cmd.IsActive,
+	})
 }
 
 // OrderCommandHandler handles order-related commands
 type OrderCommandHandler struct {
 	orderService *services.OrderDomainService
+	authorizer   authz.Authorizer
 }
 
 // NewOrderCommandHandler creates a new order command handler
-func NewOrderCommandHandler(orderService *services.OrderDomainService) *OrderCommandHandler {
+func NewOrderCommandHandler(orderService *services.OrderDomainService, authorizer authz.Authorizer) *OrderCommandHandler {
 	return &OrderCommandHandler{
 		orderService: orderService,
+		authorizer:   authorizer,
 	}
 }
 
-// Handle handles CreateOrderCommand
-func (h *OrderCommandHandler) Handle(ctx context.Context, cmd CreateOrderCommand) error {
+// Register registers the handler's commands with the bus
+func (h *OrderCommandHandler) Register(b *bus.CommandBus) {
+	bus.RegisterCommandWithResult(b, h.Handle)
+	bus.RegisterCommandWithResult(b, h.HandleUpdateOrderStatus)
+	bus.RegisterCommandWithResult(b, h.HandleCancelOrder)
+}
+
+// Handle handles CreateOrderCommand and returns the placed order
+func (h *OrderCommandHandler) Handle(ctx context.Context, cmd CreateOrderCommand) (*entities.Order, error) {
+	if err := authz.Authorize(ctx, h.authorizer, authz.ActionOrderCreate, authz.OwnedBy(authz.ResourceOrder, uuid.Nil, cmd.UserID)); err != nil {
+		return nil, err
+	}
+
 	items := make([]entities.OrderItem, len(cmd.Items))
 	for i, item := range cmd.Items {
-		// Note: Price would typically be fetched from ProductRepository
-		items[i] = *entities.NewOrderItem(uuid.Nil, item.ProductID, item.Quantity, 0.0) // OrderID set later
+		// Price is set from the product catalogue by the domain service
+		items[i] = *entities.NewOrderItem(uuid.Nil, item.ProductID, item.Quantity, entities.Money{}) // OrderID set later
 	}
 
-	order := entities.NewOrder(cmd.UserID, items)
-	return h.orderService.CreateOrder(ctx, order)
+	return h.orderService.CreateOrder(ctx, cmd.UserID, items)
+}
+
+// HandleUpdateOrderStatus handles UpdateOrderStatusCommand and returns the updated order
+func (h *OrderCommandHandler) HandleUpdateOrderStatus(ctx context.Context, cmd UpdateOrderStatusCommand) (*entities.Order, error) {
+	if err := authz.Authorize(ctx, h.authorizer, authz.ActionOrderUpdateStatus, authz.Resource{Type: authz.ResourceOrder, ID: cmd.ID}); err != nil {
+		return nil, err
+	}
+	return h.orderService.UpdateOrderStatus(ctx, cmd.ID, cmd.Status, cmd.ChangedBy, cmd.Reason)
 }
 
-// HandleUpdateOrderStatus handles UpdateOrderStatusCommand
-func (h *OrderCommandHandler) HandleUpdateOrderStatus(ctx context.Context, cmd UpdateOrderStatusCommand) error {
-	return h.orderService.UpdateOrderStatus(ctx, cmd.ID, cmd.Status)
+// HandleCancelOrder handles CancelOrderCommand and returns the cancelled order
+func (h *OrderCommandHandler) HandleCancelOrder(ctx context.Context, cmd CancelOrderCommand) (*entities.Order, error) {
+	order, err := h.orderService.GetOrder(ctx, cmd.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := authz.Authorize(ctx, h.authorizer, authz.ActionOrderCancel, authz.OwnedBy(authz.ResourceOrder, order.ID, order.UserID)); err != nil {
+		return nil, err
+	}
+
+	return h.orderService.CancelOrder(ctx, cmd.ID, cmd.CancelledBy, cmd.Reason)
 }
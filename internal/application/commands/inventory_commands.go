@@ -0,0 +1,44 @@
+package commands
+
+import (
+	"context"
+	"goclean/internal/application/authz"
+	"goclean/internal/application/bus"
+	"goclean/internal/domain/entities"
+	"goclean/internal/domain/services"
+
+	"github.com/google/uuid"
+)
+
+// RestockProductCommand represents a command to add stock for a product
+type RestockProductCommand struct {
+	ProductID uuid.UUID `json:"product_id" validate:"required"`
+	Quantity  int       `json:"quantity" validate:"required,gt=0"`
+}
+
+// InventoryCommandHandler handles inventory commands
+type InventoryCommandHandler struct {
+	inventoryService *services.InventoryDomainService
+	authorizer       authz.Authorizer
+}
+
+// NewInventoryCommandHandler creates a new inventory command handler
+func NewInventoryCommandHandler(inventoryService *services.InventoryDomainService, authorizer authz.Authorizer) *InventoryCommandHandler {
+	return &InventoryCommandHandler{
+		inventoryService: inventoryService,
+		authorizer:       authorizer,
+	}
+}
+
+// Register registers the handler's commands with the bus
+func (h *InventoryCommandHandler) Register(b *bus.CommandBus) {
+	bus.RegisterCommandWithResult(b, h.HandleRestock)
+}
+
+// HandleRestock handles RestockProductCommand and returns the updated stock level
+func (h *InventoryCommandHandler) HandleRestock(ctx context.Context, cmd RestockProductCommand) (*entities.InventoryItem, error) {
+	if err := authz.Authorize(ctx, h.authorizer, authz.ActionProductRestock, authz.Resource{Type: authz.ResourceProduct, ID: cmd.ProductID}); err != nil {
+		return nil, err
+	}
+	return h.inventoryService.Restock(ctx, cmd.ProductID, cmd.Quantity)
+}
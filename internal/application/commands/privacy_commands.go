@@ -0,0 +1,62 @@
+package commands
+
+import (
+	"context"
+	"goclean/internal/application/authz"
+	"goclean/internal/application/bus"
+	"goclean/internal/domain/services"
+
+	"github.com/google/uuid"
+)
+
+// ExportUserDataCommand represents a command to export everything stored about a user
+type ExportUserDataCommand struct {
+	UserID uuid.UUID `json:"user_id" validate:"required"`
+}
+
+// EraseUserDataCommand represents a command to erase the personal data of a user
+type EraseUserDataCommand struct {
+	UserID uuid.UUID `json:"user_id" validate:"required"`
+}
+
+// PrivacyCommandHandler handles data export and erasure commands
+type PrivacyCommandHandler struct {
+	privacyService *services.UserPrivacyService
+	revoker        SessionRevoker
+	authorizer     authz.Authorizer
+}
+
+// NewPrivacyCommandHandler creates a new privacy command handler
+func NewPrivacyCommandHandler(privacyService *services.UserPrivacyService, revoker SessionRevoker, authorizer authz.Authorizer) *PrivacyCommandHandler {
+	return &PrivacyCommandHandler{
+		privacyService: privacyService,
+		revoker:        revoker,
+		authorizer:     authorizer,
+	}
+}
+
+// Register registers the handler's commands with the bus
+func (h *PrivacyCommandHandler) Register(b *bus.CommandBus) {
+	bus.RegisterCommandWithResult(b, h.HandleExportUserData)
+	bus.RegisterCommand(b, h.HandleEraseUserData)
+}
+
+// HandleExportUserData handles ExportUserDataCommand and returns the export
+func (h *PrivacyCommandHandler) HandleExportUserData(ctx context.Context, cmd ExportUserDataCommand) (*services.UserDataExport, error) {
+	if err := authz.Authorize(ctx, h.authorizer, authz.ActionUserExport, authz.OwnedBy(authz.ResourceUser, cmd.UserID, cmd.UserID)); err != nil {
+		return nil, err
+	}
+	return h.privacyService.Export(ctx, cmd.UserID)
+}
+
+// HandleEraseUserData handles EraseUserDataCommand. The user's tokens and identity provider
+// sessions are revoked too; if that fails the erasure is rolled back so it can be retried.
+func (h *PrivacyCommandHandler) HandleEraseUserData(ctx context.Context, cmd EraseUserDataCommand) error {
+	if err := authz.Authorize(ctx, h.authorizer, authz.ActionUserErase, authz.OwnedBy(authz.ResourceUser, cmd.UserID, cmd.UserID)); err != nil {
+		return err
+	}
+	if err := h.privacyService.Erase(ctx, cmd.UserID); err != nil {
+		return err
+	}
+	return h.revoker.RevokeUserSessions(ctx, cmd.UserID.String())
+}
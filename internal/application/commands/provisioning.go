@@ -0,0 +1,102 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"goclean/internal/application/bus"
+	"goclean/internal/domain/entities"
+	"goclean/internal/domain/services"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AccountChecker reports whether a user's account has been closed
+type AccountChecker interface {
+	CheckAccountOpen(ctx context.Context, id uuid.UUID) error
+}
+
+// UserProvisioner provisions users just in time from authenticated requests. Each
+// identity is synced at most once per syncInterval unless its claims change, so the
+// user is not written on every request. Accounts are still checked on every request,
+// so a user deleted or erased after the last sync is denied at once.
+type UserProvisioner struct {
+	commandBus   *bus.CommandBus
+	accounts     AccountChecker
+	syncInterval time.Duration
+
+	mu     sync.Mutex
+	synced map[string]provisionedIdentity
+}
+
+// provisionedIdentity records the last identity synced for a subject
+type provisionedIdentity struct {
+	cmd      ProvisionUserCommand
+	syncedAt time.Time
+}
+
+// NewUserProvisioner creates a new user provisioner
+func NewUserProvisioner(commandBus *bus.CommandBus, accounts AccountChecker, syncInterval time.Duration) *UserProvisioner {
+	if syncInterval <= 0 {
+		syncInterval = 5 * time.Minute
+	}
+	return &UserProvisioner{
+		commandBus:   commandBus,
+		accounts:     accounts,
+		syncInterval: syncInterval,
+		synced:       make(map[string]provisionedIdentity),
+	}
+}
+
+// Provision creates or syncs the user unless the same identity was synced recently, in
+// which case it only checks that the account is still open
+func (p *UserProvisioner) Provision(ctx context.Context, cmd ProvisionUserCommand) error {
+	key := cmd.ID.String()
+
+	p.mu.Lock()
+	last, ok := p.synced[key]
+	p.mu.Unlock()
+	if ok && last.cmd == cmd && time.Since(last.syncedAt) < p.syncInterval {
+		err := p.accounts.CheckAccountOpen(ctx, cmd.ID)
+		if err == nil {
+			return nil
+		}
+
+		// Closed or purged accounts are looked up again on the next request
+		p.mu.Lock()
+		delete(p.synced, key)
+		p.mu.Unlock()
+		if IsAccountClosed(err) {
+			return err
+		}
+	}
+
+	if err := p.commandBus.Dispatch(ctx, cmd); err != nil {
+		return err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.synced[key] = provisionedIdentity{cmd: cmd, syncedAt: time.Now()}
+	p.pruneLocked()
+	return nil
+}
+
+// pruneLocked drops expired entries once the cache has grown
+func (p *UserProvisioner) pruneLocked() {
+	if len(p.synced) < 10000 {
+		return
+	}
+	for key, identity := range p.synced {
+		if time.Since(identity.syncedAt) >= p.syncInterval {
+			delete(p.synced, key)
+		}
+	}
+}
+
+// IsAccountClosed checks if provisioning failed because the user was deleted or erased.
+// Such callers must be denied even though their token is still valid.
+func IsAccountClosed(err error) bool {
+	return errors.Is(err, services.ErrUserDeleted) || errors.Is(err, entities.ErrUserErased)
+}
@@ -0,0 +1,137 @@
+package commands
+
+import (
+	"context"
+	"goclean/internal/application/authz"
+	"goclean/internal/application/bus"
+	"goclean/internal/domain/entities"
+	"goclean/pkg/config"
+	"goclean/pkg/logger"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RetentionPolicy is how long soft deleted entities of each aggregate are kept before they
+// are purged. A zero period keeps them forever.
+type RetentionPolicy struct {
+	Users    time.Duration
+	Profiles time.Duration
+	Products time.Duration
+	Orders   time.Duration
+}
+
+// NewRetentionPolicy returns the retention periods configured for each aggregate
+func NewRetentionPolicy(cfg config.RetentionConfig) RetentionPolicy {
+	return RetentionPolicy{
+		Users:    cfg.UsersPeriod,
+		Profiles: cfg.ProfilesPeriod,
+		Products: cfg.ProductsPeriod,
+		Orders:   cfg.OrdersPeriod,
+	}
+}
+
+// PurgedEntity is an entity the retention job purged, or would purge in a dry run
+type PurgedEntity struct {
+	ResourceType string
+	ID           uuid.UUID
+	DeletedAt    time.Time
+	Reason       string
+}
+
+// RetentionJob purges soft deleted entities once their retention period is over. It runs
+// as the system principal, since purging is not granted to any user.
+type RetentionJob struct {
+	commandBus *bus.CommandBus
+	policy     RetentionPolicy
+	logger     *logger.Logger
+}
+
+// NewRetentionJob creates a new retention job
+func NewRetentionJob(commandBus *bus.CommandBus, policy RetentionPolicy, logger *logger.Logger) *RetentionJob {
+	return &RetentionJob{
+		commandBus: commandBus,
+		policy:     policy,
+		logger:     logger,
+	}
+}
+
+// Run purges expired entities every interval until ctx is cancelled
+func (j *RetentionJob) Run(ctx context.Context, interval time.Duration) {
+	j.logger.Info("Retention job started", "interval", interval)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		purged, err := j.Purge(ctx, false)
+		if err != nil {
+			j.logger.Error("Failed to purge deleted entities", "error", err)
+		}
+		if len(purged) > 0 {
+			j.logger.Info("Purged deleted entities", "count", len(purged))
+		}
+
+		select {
+		case <-ctx.Done():
+			j.logger.Info("Retention job stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Purge hard deletes every entity whose retention period is over and returns them. With
+// dryRun nothing is removed and the entities that would be purged are returned. Entities
+// purged before a failure are returned along with the error.
+func (j *RetentionJob) Purge(ctx context.Context, dryRun bool) ([]PurgedEntity, error) {
+	ctx = authz.WithPrincipal(ctx, authz.SystemPrincipal())
+	now := time.Now()
+
+	steps := []struct {
+		period time.Duration
+		purge  purgeFunc
+	}{
+		{j.policy.Users, purgeDeleted[*entities.User](authz.ResourceUser)},
+		{j.policy.Profiles, purgeDeleted[*entities.Profile](authz.ResourceProfile)},
+		{j.policy.Products, purgeDeleted[*entities.Product](authz.ResourceProduct)},
+		{j.policy.Orders, purgeDeleted[*entities.Order](authz.ResourceOrder)},
+	}
+
+	var all []PurgedEntity
+	for _, step := range steps {
+		if step.period <= 0 {
+			continue
+		}
+		purged, err := step.purge(ctx, j.commandBus, now.Add(-step.period), dryRun)
+		all = append(all, purged...)
+		if err != nil {
+			return all, err
+		}
+	}
+	return all, nil
+}
+
+// purgeFunc purges the entities of one aggregate deleted before deletedBefore
+type purgeFunc func(ctx context.Context, commandBus *bus.CommandBus, deletedBefore time.Time, dryRun bool) ([]PurgedEntity, error)
+
+// purgeDeleted returns the purgeFunc dispatching PurgeDeletedCommand for entities of type T
+func purgeDeleted[T entities.SoftDeletable](resourceType string) purgeFunc {
+	return func(ctx context.Context, commandBus *bus.CommandBus, deletedBefore time.Time, dryRun bool) ([]PurgedEntity, error) {
+		cmd := PurgeDeletedCommand[T]{DeletedBefore: deletedBefore, DryRun: dryRun}
+		deleted, err := bus.DispatchWithResult[[]T](ctx, commandBus, cmd)
+		if err != nil {
+			return nil, err
+		}
+
+		purged := make([]PurgedEntity, len(deleted))
+		for i, entity := range deleted {
+			purged[i] = PurgedEntity{ResourceType: resourceType, ID: entity.EntityID()}
+			if deletedAt, reason := entity.DeletionInfo(); deletedAt != nil {
+				purged[i].DeletedAt = *deletedAt
+				purged[i].Reason = reason
+			}
+		}
+		return purged, nil
+	}
+}
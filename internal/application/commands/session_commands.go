@@ -0,0 +1,53 @@
+package commands
+
+import (
+	"context"
+	"goclean/internal/application/authz"
+	"goclean/internal/application/bus"
+	"goclean/internal/domain/services"
+
+	"github.com/google/uuid"
+)
+
+// RevokeUserSessionsCommand represents a command to revoke all sessions, tokens and API keys of a user
+type RevokeUserSessionsCommand struct {
+	UserID uuid.UUID `json:"user_id" validate:"required"`
+}
+
+// SessionRevoker revokes the sessions and tokens of a user at the identity layer
+type SessionRevoker interface {
+	RevokeUserSessions(ctx context.Context, userID string) error
+}
+
+// SessionCommandHandler handles session-related commands
+type SessionCommandHandler struct {
+	revoker    SessionRevoker
+	apiKeys    *services.APIKeyDomainService
+	authorizer authz.Authorizer
+}
+
+// NewSessionCommandHandler creates a new session command handler
+func NewSessionCommandHandler(revoker SessionRevoker, apiKeys *services.APIKeyDomainService, authorizer authz.Authorizer) *SessionCommandHandler {
+	return &SessionCommandHandler{
+		revoker:    revoker,
+		apiKeys:    apiKeys,
+		authorizer: authorizer,
+	}
+}
+
+// Register registers the handler's commands with the bus
+func (h *SessionCommandHandler) Register(b *bus.CommandBus) {
+	bus.RegisterCommand(b, h.Handle)
+}
+
+// Handle handles RevokeUserSessionsCommand. API keys act as their owner without a
+// session, so they are revoked too; a compromised account must not keep working through them.
+func (h *SessionCommandHandler) Handle(ctx context.Context, cmd RevokeUserSessionsCommand) error {
+	if err := authz.Authorize(ctx, h.authorizer, authz.ActionSessionsRevoke, authz.OwnedBy(authz.ResourceUser, cmd.UserID, cmd.UserID)); err != nil {
+		return err
+	}
+	if err := h.apiKeys.RevokeOwnerAPIKeys(ctx, cmd.UserID); err != nil {
+		return err
+	}
+	return h.revoker.RevokeUserSessions(ctx, cmd.UserID.String())
+}
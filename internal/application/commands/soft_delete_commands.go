@@ -2,53 +2,96 @@ package commands
 
 import (
 	"context"
+	"goclean/internal/application/authz"
+	"goclean/internal/application/bus"
+	"goclean/internal/domain/entities"
 	"goclean/internal/domain/services"
+	"time"
 
 	"github.com/google/uuid"
 )
 
-// SoftDeleteUserCommand represents a command to soft delete a user
-type SoftDeleteUserCommand struct {
-	UserID uuid.UUID `json:"user_id" validate:"required"`
-	Reason string    `json:"reason,omitempty"` // Optional reason for deletion
+// SoftDeleteCommand represents a command to soft delete an entity of type T
+type SoftDeleteCommand[T entities.SoftDeletable] struct {
+	ID     uuid.UUID `json:"id" validate:"required"`
+	Reason string    `json:"reason,omitempty" validate:"max=500"` // Optional reason for deletion, kept with the entity
 }
 
-// RestoreUserCommand represents a command to restore a soft deleted user
-type RestoreUserCommand struct {
-	UserID uuid.UUID `json:"user_id" validate:"required"`
-	Reason string    `json:"reason,omitempty"` // Optional reason for restoration
+// RestoreCommand represents a command to restore a soft deleted entity of type T
+type RestoreCommand[T entities.SoftDeletable] struct {
+	ID uuid.UUID `json:"id" validate:"required"`
 }
 
-// SoftDeleteUserCommandHandler handles soft delete user commands
-type SoftDeleteUserCommandHandler struct {
-	userAggregateService *services.UserAggregateService
+// PurgeDeletedCommand represents a command to hard delete the entities of type T that were
+// soft deleted before DeletedBefore. It returns the purged entities, or with DryRun the
+// entities that would be purged without removing them.
+type PurgeDeletedCommand[T entities.SoftDeletable] struct {
+	DeletedBefore time.Time `json:"deleted_before" validate:"required"`
+	DryRun        bool      `json:"dry_run"`
 }
 
-// NewSoftDeleteUserCommandHandler creates a new soft delete user command handler
-func NewSoftDeleteUserCommandHandler(userAggregateService *services.UserAggregateService) *SoftDeleteUserCommandHandler {
-	return &SoftDeleteUserCommandHandler{
-		userAggregateService: userAggregateService,
+type (
+	SoftDeleteUserCommand       = SoftDeleteCommand[*entities.User]
+	RestoreUserCommand          = RestoreCommand[*entities.User]
+	SoftDeleteProfileCommand    = SoftDeleteCommand[*entities.Profile]
+	RestoreProfileCommand       = RestoreCommand[*entities.Profile]
+	SoftDeleteProductCommand    = SoftDeleteCommand[*entities.Product]
+	RestoreProductCommand       = RestoreCommand[*entities.Product]
+	SoftDeleteOrderCommand      = SoftDeleteCommand[*entities.Order]
+	RestoreOrderCommand         = RestoreCommand[*entities.Order]
+	PurgeDeletedUsersCommand    = PurgeDeletedCommand[*entities.User]
+	PurgeDeletedProfilesCommand = PurgeDeletedCommand[*entities.Profile]
+	PurgeDeletedProductsCommand = PurgeDeletedCommand[*entities.Product]
+	PurgeDeletedOrdersCommand   = PurgeDeletedCommand[*entities.Order]
+)
+
+// SoftDeleteCommandHandler handles the soft delete, restore and purge commands of one entity type
+type SoftDeleteCommandHandler[T entities.SoftDeletable] struct {
+	service      *services.SoftDeleteService[T]
+	authorizer   authz.Authorizer
+	resourceType string
+}
+
+// NewSoftDeleteCommandHandler creates a soft delete command handler; resourceType selects
+// the lifecycle actions that are authorized, e.g. authz.ResourceOrder
+func NewSoftDeleteCommandHandler[T entities.SoftDeletable](service *services.SoftDeleteService[T], authorizer authz.Authorizer, resourceType string) *SoftDeleteCommandHandler[T] {
+	return &SoftDeleteCommandHandler[T]{
+		service:      service,
+		authorizer:   authorizer,
+		resourceType: resourceType,
 	}
 }
 
-// Handle handles the soft delete user command
-func (h *SoftDeleteUserCommandHandler) Handle(ctx context.Context, cmd SoftDeleteUserCommand) error {
-	return h.userAggregateService.SoftDeleteUser(ctx, cmd.UserID)
+// Register registers the handler's commands with the bus
+func (h *SoftDeleteCommandHandler[T]) Register(b *bus.CommandBus) {
+	bus.RegisterCommand(b, h.HandleSoftDelete)
+	bus.RegisterCommand(b, h.HandleRestore)
+	bus.RegisterCommandWithResult(b, h.HandlePurgeDeleted)
 }
 
-// RestoreUserCommandHandler handles restore user commands
-type RestoreUserCommandHandler struct {
-	userAggregateService *services.UserAggregateService
+// HandleSoftDelete handles SoftDeleteCommand
+func (h *SoftDeleteCommandHandler[T]) HandleSoftDelete(ctx context.Context, cmd SoftDeleteCommand[T]) error {
+	action := authz.Lifecycle(h.resourceType).Delete
+	if err := authz.Authorize(ctx, h.authorizer, action, authz.Resource{Type: h.resourceType, ID: cmd.ID}); err != nil {
+		return err
+	}
+	return h.service.Delete(ctx, cmd.ID, cmd.Reason)
 }
 
-// NewRestoreUserCommandHandler creates a new restore user command handler
-func NewRestoreUserCommandHandler(userAggregateService *services.UserAggregateService) *RestoreUserCommandHandler {
-	return &RestoreUserCommandHandler{
-		userAggregateService: userAggregateService,
+// HandleRestore handles RestoreCommand
+func (h *SoftDeleteCommandHandler[T]) HandleRestore(ctx context.Context, cmd RestoreCommand[T]) error {
+	action := authz.Lifecycle(h.resourceType).Restore
+	if err := authz.Authorize(ctx, h.authorizer, action, authz.Resource{Type: h.resourceType, ID: cmd.ID}); err != nil {
+		return err
 	}
+	return h.service.Restore(ctx, cmd.ID)
 }
 
-// Handle handles the restore user command
-func (h *RestoreUserCommandHandler) Handle(ctx context.Context, cmd RestoreUserCommand) error {
-	return h.userAggregateService.RestoreUser(ctx, cmd.UserID)
+// HandlePurgeDeleted handles PurgeDeletedCommand
+func (h *SoftDeleteCommandHandler[T]) HandlePurgeDeleted(ctx context.Context, cmd PurgeDeletedCommand[T]) ([]T, error) {
+	action := authz.Lifecycle(h.resourceType).Purge
+	if err := authz.Authorize(ctx, h.authorizer, action, authz.Resource{Type: h.resourceType}); err != nil {
+		return nil, err
+	}
+	return h.service.Purge(ctx, cmd.DeletedBefore, cmd.DryRun)
 }
@@ -1,6 +1,7 @@
 package dto
 
 import (
+	"goclean/internal/application/validation"
 	"time"
 
 	"github.com/google/uuid"
@@ -30,12 +31,18 @@ type ProfileDTO struct {
 	UpdatedAt   time.Time  `json:"updated_at"`
 }
 
+// MoneyDTO represents a monetary amount in minor units (e.g. cents) of an ISO 4217 currency
+type MoneyDTO struct {
+	Amount   int64  `json:"amount" example:"1999"`
+	Currency string `json:"currency" example:"USD"`
+}
+
 // ProductDTO represents product data transfer object
 type ProductDTO struct {
 	ID          uuid.UUID `json:"id"`
 	Name        string    `json:"name"`
 	Description string    `json:"description"`
-	Price       float64   `json:"price"`
+	Price       MoneyDTO  `json:"price"`
 	SKU         string    `json:"sku"`
 	Category    string    `json:"category"`
 	IsActive    bool      `json:"is_active"`
@@ -44,15 +51,25 @@ type ProductDTO struct {
 	UpdatedAt   time.Time `json:"updated_at"`
 }
 
+// StockLevelDTO represents the stock level of a product
+type StockLevelDTO struct {
+	ProductID uuid.UUID `json:"product_id"`
+	OnHand    int       `json:"on_hand"`
+	Reserved  int       `json:"reserved"`
+	Available int       `json:"available"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
 // OrderDTO represents order data transfer object
 type OrderDTO struct {
-	ID         uuid.UUID      `json:"id"`
-	UserID     uuid.UUID      `json:"user_id"`
-	Status     string         `json:"status"`
-	TotalPrice float64        `json:"total_price"`
-	Items      []OrderItemDTO `json:"items"`
-	CreatedAt  time.Time      `json:"created_at"`
-	UpdatedAt  time.Time      `json:"updated_at"`
+	ID            uuid.UUID               `json:"id"`
+	UserID        uuid.UUID               `json:"user_id"`
+	Status        string                  `json:"status"`
+	TotalPrice    MoneyDTO                `json:"total_price"`
+	Items         []OrderItemDTO          `json:"items"`
+	StatusHistory []OrderStatusHistoryDTO `json:"status_history"`
+	CreatedAt     time.Time               `json:"created_at"`
+	UpdatedAt     time.Time               `json:"updated_at"`
 }
 
 // OrderItemDTO represents order item data transfer object
@@ -61,10 +78,85 @@ type OrderItemDTO struct {
 	OrderID   uuid.UUID `json:"order_id"`
 	ProductID uuid.UUID `json:"product_id"`
 	Quantity  int       `json:"quantity"`
-	Price     float64   `json:"price"`
+	Price     MoneyDTO  `json:"price"`
 	CreatedAt time.Time `json:"created_at"`
 }
 
+// OrderStatusHistoryDTO represents order status history data transfer object
+type OrderStatusHistoryDTO struct {
+	ID         uuid.UUID `json:"id"`
+	FromStatus string    `json:"from_status,omitempty"`
+	ToStatus   string    `json:"to_status"`
+	ChangedBy  uuid.UUID `json:"changed_by"`
+	Reason     string    `json:"reason,omitempty"`
+	ChangedAt  time.Time `json:"changed_at"`
+}
+
+// AuditEntryDTO represents an order status change made by a user
+type AuditEntryDTO struct {
+	OrderID uuid.UUID `json:"order_id"`
+	OrderStatusHistoryDTO
+}
+
+// UserDataExportDTO represents the archive of everything stored about a user; soft
+// deleted records are included
+type UserDataExportDTO struct {
+	ExportedAt   time.Time       `json:"exported_at"`
+	User         *UserDTO        `json:"user"`
+	ErasedAt     *time.Time      `json:"erased_at,omitempty"`
+	DeletedAt    *time.Time      `json:"deleted_at,omitempty"`
+	Orders       []*OrderDTO     `json:"orders"`
+	APIKeys      []APIKeyDTO     `json:"api_keys"`
+	AuditEntries []AuditEntryDTO `json:"audit_entries"`
+}
+
+// TokenDTO represents issued tokens; refresh_token is omitted when delivered as a cookie
+type TokenDTO struct {
+	AccessToken      string `json:"access_token"`
+	RefreshToken     string `json:"refresh_token,omitempty"`
+	TokenType        string `json:"token_type"`
+	ExpiresIn        int    `json:"expires_in"`
+	RefreshExpiresIn int    `json:"refresh_expires_in,omitempty"`
+}
+
+// UserInfoDTO represents the authenticated user's identity from token claims
+type UserInfoDTO struct {
+	ID            string   `json:"id"`
+	Email         string   `json:"email"`
+	Username      string   `json:"username"`
+	FirstName     string   `json:"first_name"`
+	LastName      string   `json:"last_name"`
+	EmailVerified bool     `json:"email_verified"`
+	Roles         []string `json:"roles"`
+}
+
+// APIKeyDTO represents API key data transfer object; the secret is never included
+type APIKeyDTO struct {
+	ID         uuid.UUID  `json:"id"`
+	Name       string     `json:"name"`
+	KeyID      string     `json:"key_id"`
+	OwnerID    uuid.UUID  `json:"owner_id"`
+	Scopes     []string   `json:"scopes"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+	CreatedBy  uuid.UUID  `json:"created_by"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// IssuedAPIKeyDTO represents a newly issued API key including its plaintext key
+type IssuedAPIKeyDTO struct {
+	APIKeyDTO
+	Key string `json:"key"`
+}
+
+// DeletedResourceDTO represents a soft deleted entity with when and why it was deleted
+type DeletedResourceDTO[T any] struct {
+	Resource       T          `json:"resource"`
+	DeletedAt      *time.Time `json:"deleted_at"`
+	DeletionReason string     `json:"deletion_reason,omitempty"`
+}
+
 // CreateUserRequest represents create user request
 type CreateUserRequest struct {
 	Email     string                `json:"email" validate:"required,email"`
@@ -91,25 +183,38 @@ type UpdateUserRequest struct {
 
 // CreateProductRequest represents create product request
 type CreateProductRequest struct {
-	Name        string  `json:"name" validate:"required,min=1,max=255"`
-	Description string  `json:"description"`
-	Price       float64 `json:"price" validate:"required,gt=0"`
-	SKU         string  `json:"sku" validate:"required,min=1,max=100"`
-	Category    string  `json:"category" validate:"required"`
+	Name        string             `json:"name" validate:"required,min=1,max=255"`
+	Description string             `json:"description"`
+	Price       MoneyAmountRequest `json:"price" validate:"required"`
+	SKU         string             `json:"sku" validate:"required,min=1,max=100"`
+	Category    string             `json:"category" validate:"required"`
+	Stock       int                `json:"stock" validate:"min=0" example:"100"`
+}
+
+// MoneyAmountRequest represents a monetary amount in minor units; the currency defaults to USD
+type MoneyAmountRequest struct {
+	Amount   int64  `json:"amount" validate:"required,gt=0" example:"1999"`
+	Currency string `json:"currency,omitempty" validate:"omitempty,iso4217" example:"USD"`
 }
 
 // UpdateProductRequest represents update product request
 type UpdateProductRequest struct {
-	Name        *string  `json:"name,omitempty" validate:"omitempty,min=1,max=255"`
-	Description *string  `json:"description,omitempty"`
-	Price       *float64 `json:"price,omitempty" validate:"omitempty,gt=0"`
-	Category    *string  `json:"category,omitempty"`
-	IsActive    *bool    `json:"is_active,omitempty"`
+	Name        *string             `json:"name,omitempty" validate:"omitempty,min=1,max=255"`
+	Description *string             `json:"description,omitempty"`
+	Price       *MoneyAmountRequest `json:"price,omitempty" validate:"omitempty"`
+	SKU         *string             `json:"sku,omitempty" validate:"omitempty,min=1,max=100"`
+	Category    *string             `json:"category,omitempty"`
+	IsActive    *bool               `json:"is_active,omitempty"`
+}
+
+// RestockProductRequest represents restock product request
+type RestockProductRequest struct {
+	Quantity int `json:"quantity" validate:"required,gt=0" example:"50"`
 }
 
 // CreateOrderRequest represents create order request
 type CreateOrderRequest struct {
-	Items []CreateOrderItemRequest `json:"items" validate:"required,min=1"`
+	Items []CreateOrderItemRequest `json:"items" validate:"required,min=1,dive"`
 }
 
 // CreateOrderItemRequest represents create order item request
@@ -120,7 +225,42 @@ type CreateOrderItemRequest struct {
 
 // UpdateOrderStatusRequest represents update order status request
 type UpdateOrderStatusRequest struct {
-	Status string `json:"status" validate:"required,oneof=pending confirmed shipped delivered cancelled"`
+	Status string `json:"status" validate:"required,oneof=confirmed shipped delivered cancelled"`
+	Reason string `json:"reason,omitempty" validate:"max=500"`
+}
+
+// CancelOrderRequest represents cancel order request
+type CancelOrderRequest struct {
+	Reason string `json:"reason,omitempty" validate:"max=500"`
+}
+
+// LoginRequest represents login request
+type LoginRequest struct {
+	Username string `json:"username" validate:"required"`
+	Password string `json:"password" validate:"required"`
+}
+
+// RefreshTokenRequest represents refresh token request; the token may instead be sent as a cookie
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token,omitempty"`
+}
+
+// LogoutRequest represents logout request; the token may instead be sent as a cookie
+type LogoutRequest struct {
+	RefreshToken string `json:"refresh_token,omitempty"`
+}
+
+// CreateAPIKeyRequest represents create API key request
+type CreateAPIKeyRequest struct {
+	Name      string     `json:"name" validate:"required,max=100"`
+	OwnerID   uuid.UUID  `json:"owner_id" validate:"required"`
+	Scopes    []string   `json:"scopes"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+// SoftDeleteRequest represents soft delete request
+type SoftDeleteRequest struct {
+	Reason string `json:"reason,omitempty" validate:"max=500"`
 }
 
 // PaginationRequest represents pagination parameters
@@ -133,7 +273,6 @@ type PaginationRequest struct {
 type APIResponse[T any] struct {
 	Success bool   `json:"success"`
 	Data    T      `json:"data,omitempty"`
-	Error   string `json:"error,omitempty"`
 	Message string `json:"message,omitempty"`
 }
 
@@ -156,7 +295,6 @@ type PaginationInfo struct {
 type UserAPIResponse struct {
 	Success bool     `json:"success"`
 	Data    *UserDTO `json:"data,omitempty"`
-	Error   string   `json:"error,omitempty"`
 	Message string   `json:"message,omitempty"`
 }
 
@@ -164,30 +302,66 @@ type UserAPIResponse struct {
 type ProductAPIResponse struct {
 	Success bool        `json:"success"`
 	Data    *ProductDTO `json:"data,omitempty"`
-	Error   string      `json:"error,omitempty"`
 	Message string      `json:"message,omitempty"`
 }
 
+// StockLevelAPIResponse represents API response for stock level operations
+type StockLevelAPIResponse struct {
+	Success bool           `json:"success"`
+	Data    *StockLevelDTO `json:"data,omitempty"`
+	Message string         `json:"message,omitempty"`
+}
+
 // OrderAPIResponse represents API response for order operations
 type OrderAPIResponse struct {
 	Success bool      `json:"success"`
 	Data    *OrderDTO `json:"data,omitempty"`
-	Error   string    `json:"error,omitempty"`
 	Message string    `json:"message,omitempty"`
 }
 
-// ErrorAPIResponse represents API response for errors
-type ErrorAPIResponse struct {
+// TokenAPIResponse represents API response for login and refresh operations
+type TokenAPIResponse struct {
+	Success bool      `json:"success"`
+	Data    *TokenDTO `json:"data,omitempty"`
+	Message string    `json:"message,omitempty"`
+}
+
+// UserInfoAPIResponse represents API response for user info operations
+type UserInfoAPIResponse struct {
+	Success bool         `json:"success"`
+	Data    *UserInfoDTO `json:"data,omitempty"`
+	Message string       `json:"message,omitempty"`
+}
+
+// IssuedAPIKeyAPIResponse represents API response for API key issuance
+type IssuedAPIKeyAPIResponse struct {
+	Success bool             `json:"success"`
+	Data    *IssuedAPIKeyDTO `json:"data,omitempty"`
+	Message string           `json:"message,omitempty"`
+}
+
+// MessageAPIResponse represents API response for operations that only report success
+type MessageAPIResponse struct {
 	Success bool   `json:"success"`
-	Error   string `json:"error,omitempty"`
 	Message string `json:"message,omitempty"`
 }
 
+// ProblemDetails represents an RFC 7807 error response, served as application/problem+json.
+// Code identifies the error for clients and never changes; Detail is for humans.
+type ProblemDetails struct {
+	Type     string            `json:"type" example:"about:blank"`
+	Title    string            `json:"title" example:"Not Found"`
+	Status   int               `json:"status" example:"404"`
+	Detail   string            `json:"detail,omitempty" example:"user not found"`
+	Instance string            `json:"instance,omitempty" example:"/api/v1/users/0f6f3f0e-8a4c-4b8e-9d39-2d0c1b6f5a77"`
+	Code     string            `json:"code" example:"user_not_found"`
+	Errors   validation.Errors `json:"errors,omitempty"` // Fields that failed validation
+}
+
 // UsersListResponse represents API response for user list operations
 type UsersListResponse struct {
 	Success bool      `json:"success"`
 	Data    []UserDTO `json:"data,omitempty"`
-	Error   string    `json:"error,omitempty"`
 	Message string    `json:"message,omitempty"`
 }
 
@@ -195,7 +369,31 @@ type UsersListResponse struct {
 type ProductsListResponse struct {
 	Success    bool           `json:"success"`
 	Data       []ProductDTO   `json:"data,omitempty"`
-	Error      string         `json:"error,omitempty"`
 	Message    string         `json:"message,omitempty"`
 	Pagination PaginationInfo `json:"pagination"`
 }
+
+// OrdersListResponse represents paginated API response for order list operations
+type OrdersListResponse struct {
+	Success    bool           `json:"success"`
+	Data       []OrderDTO     `json:"data,omitempty"`
+	Message    string         `json:"message,omitempty"`
+	Pagination PaginationInfo `json:"pagination"`
+}
+
+// APIKeysListResponse represents paginated API response for API key list operations
+type APIKeysListResponse struct {
+	Success    bool           `json:"success"`
+	Data       []APIKeyDTO    `json:"data,omitempty"`
+	Message    string         `json:"message,omitempty"`
+	Pagination PaginationInfo `json:"pagination"`
+}
+
+// DeletedResourcesListResponse represents paginated API response for deleted entity list
+// operations; each resource has the DTO of its entity type
+type DeletedResourcesListResponse struct {
+	Success    bool                              `json:"success"`
+	Data       []DeletedResourceDTO[interface{}] `json:"data,omitempty"`
+	Message    string                            `json:"message,omitempty"`
+	Pagination PaginationInfo                    `json:"pagination"`
+}
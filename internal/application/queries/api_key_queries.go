@@ -0,0 +1,45 @@
+package queries
+
+import (
+	"context"
+	"goclean/internal/application/authz"
+	"goclean/internal/application/bus"
+	"goclean/internal/domain/entities"
+	"goclean/internal/domain/services"
+
+	"github.com/google/uuid"
+)
+
+// ListAPIKeysQuery represents a query to list API keys, optionally of one owner
+type ListAPIKeysQuery struct {
+	OwnerID *uuid.UUID `json:"owner_id,omitempty"`
+	Offset  int        `json:"offset" validate:"min=0"`
+	Limit   int        `json:"limit" validate:"min=1,max=100"`
+}
+
+// APIKeyQueryHandler handles API key queries
+type APIKeyQueryHandler struct {
+	apiKeyService *services.APIKeyDomainService
+	authorizer    authz.Authorizer
+}
+
+// NewAPIKeyQueryHandler creates a new API key query handler
+func NewAPIKeyQueryHandler(apiKeyService *services.APIKeyDomainService, authorizer authz.Authorizer) *APIKeyQueryHandler {
+	return &APIKeyQueryHandler{
+		apiKeyService: apiKeyService,
+		authorizer:    authorizer,
+	}
+}
+
+// Register registers the handler's queries with the bus
+func (h *APIKeyQueryHandler) Register(b *bus.QueryBus) {
+	bus.RegisterQuery(b, h.HandleList)
+}
+
+// HandleList handles ListAPIKeysQuery
+func (h *APIKeyQueryHandler) HandleList(ctx context.Context, query ListAPIKeysQuery) ([]*entities.APIKey, error) {
+	if err := authz.Authorize(ctx, h.authorizer, authz.ActionAPIKeyManage, authz.Resource{Type: authz.ResourceAPIKey}); err != nil {
+		return nil, err
+	}
+	return h.apiKeyService.ListAPIKeys(ctx, query.OwnerID, query.Offset, query.Limit)
+}
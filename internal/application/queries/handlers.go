@@ -2,28 +2,47 @@ package queries
 
 import (
 	"context"
+	"goclean/internal/application/authz"
+	"goclean/internal/application/bus"
 	"goclean/internal/domain/repositories"
+	"goclean/internal/domain/services"
+
+	"github.com/google/uuid"
 )
 
 // UserQueryHandler handles user-related queries
 type UserQueryHandler struct {
 	userRepo    repositories.UserRepository
 	profileRepo repositories.ProfileRepository
+	authorizer  authz.Authorizer
 }
 
 // NewUserQueryHandler creates a new user query handler
-func NewUserQueryHandler(userRepo repositories.UserRepository, profileRepo repositories.ProfileRepository) *UserQueryHandler {
+func NewUserQueryHandler(userRepo repositories.UserRepository, profileRepo repositories.ProfileRepository, authorizer authz.Authorizer) *UserQueryHandler {
 	return &UserQueryHandler{
 		userRepo:    userRepo,
 		profileRepo: profileRepo,
+		authorizer:  authorizer,
 	}
 }
 
+// Register registers the handler's queries with the bus
+func (h *UserQueryHandler) Register(b *bus.QueryBus) {
+	bus.RegisterQuery(b, h.Handle)
+	bus.RegisterQuery(b, h.HandleByEmail)
+	bus.RegisterQuery(b, h.HandleByUsername)
+	bus.RegisterQuery(b, h.HandleList)
+}
+
 // Handle handles GetUserByIDQuery
 func (h *UserQueryHandler) Handle(ctx context.Context, query GetUserByIDQuery) (*UserResult, error) {
+	if err := authz.Authorize(ctx, h.authorizer, authz.ActionUserRead, authz.OwnedBy(authz.ResourceUser, query.ID, query.ID)); err != nil {
+		return nil, err
+	}
+
 	user, err := h.userRepo.GetByID(ctx, query.ID)
 	if err != nil {
-		return nil, err
+		return nil, repositories.NotFoundAs(err, services.ErrUserNotFound)
 	}
 
 	profile, _ := h.profileRepo.GetByUserID(ctx, user.ID)
@@ -38,6 +57,9 @@ func (h *UserQueryHandler) Handle(ctx context.Context, query GetUserByIDQuery) (
 func (h *UserQueryHandler) HandleByEmail(ctx context.Context, query GetUserByEmailQuery) (*UserResult, error) {
 	user, err := h.userRepo.GetByEmail(ctx, query.Email)
 	if err != nil {
+		return nil, repositories.NotFoundAs(err, services.ErrUserNotFound)
+	}
+	if err := authz.Authorize(ctx, h.authorizer, authz.ActionUserRead, authz.OwnedBy(authz.ResourceUser, user.ID, user.ID)); err != nil {
 		return nil, err
 	}
 
@@ -53,6 +75,9 @@ func (h *UserQueryHandler) HandleByEmail(ctx context.Context, query GetUserByEma
 func (h *UserQueryHandler) HandleByUsername(ctx context.Context, query GetUserByUsernameQuery) (*UserResult, error) {
 	user, err := h.userRepo.GetByUsername(ctx, query.Username)
 	if err != nil {
+		return nil, repositories.NotFoundAs(err, services.ErrUserNotFound)
+	}
+	if err := authz.Authorize(ctx, h.authorizer, authz.ActionUserRead, authz.OwnedBy(authz.ResourceUser, user.ID, user.ID)); err != nil {
 		return nil, err
 	}
 
@@ -66,6 +91,10 @@ func (h *UserQueryHandler) HandleByUsername(ctx context.Context, query GetUserBy
 
 // HandleList handles ListUsersQuery
 func (h *UserQueryHandler) HandleList(ctx context.Context, query ListUsersQuery) (*UsersResult, error) {
+	if err := authz.Authorize(ctx, h.authorizer, authz.ActionUserList, authz.Resource{Type: authz.ResourceUser}); err != nil {
+		return nil, err
+	}
+
 	users, err := h.userRepo.List(ctx, query.Offset, query.Limit)
 	if err != nil {
 		return nil, err
@@ -98,11 +127,20 @@ func NewProductQueryHandler(productRepo repositories.ProductRepository) *Product
 	}
 }
 
+// Register registers the handler's queries with the bus
+func (h *ProductQueryHandler) Register(b *bus.QueryBus) {
+	bus.RegisterQuery(b, h.Handle)
+	bus.RegisterQuery(b, h.HandleBySKU)
+	bus.RegisterQuery(b, h.HandleList)
+	bus.RegisterQuery(b, h.HandleByCategory)
+	bus.RegisterQuery(b, h.HandleSearch)
+}
+
 // Handle handles GetProductByIDQuery
 func (h *ProductQueryHandler) Handle(ctx context.Context, query GetProductByIDQuery) (*ProductResult, error) {
 	product, err := h.productRepo.GetByID(ctx, query.ID)
 	if err != nil {
-		return nil, err
+		return nil, repositories.NotFoundAs(err, services.ErrProductNotFound)
 	}
 
 	return &ProductResult{Product: product}, nil
@@ -112,7 +150,7 @@ func (h *ProductQueryHandler) Handle(ctx context.Context, query GetProductByIDQu
 func (h *ProductQueryHandler) HandleBySKU(ctx context.Context, query GetProductBySKUQuery) (*ProductResult, error) {
 	product, err := h.productRepo.GetBySKU(ctx, query.SKU)
 	if err != nil {
-		return nil, err
+		return nil, repositories.NotFoundAs(err, services.ErrProductNotFound)
 	}
 
 	return &ProductResult{Product: product}, nil
@@ -159,28 +197,62 @@ func (h *ProductQueryHandler) HandleSearch(ctx context.Context, query SearchProd
 
 // OrderQueryHandler handles order-related queries
 type OrderQueryHandler struct {
-	orderRepo repositories.OrderRepository
+	orderRepo  repositories.OrderRepository
+	authorizer authz.Authorizer
 }
 
 // NewOrderQueryHandler creates a new order query handler
-func NewOrderQueryHandler(orderRepo repositories.OrderRepository) *OrderQueryHandler {
+func NewOrderQueryHandler(orderRepo repositories.OrderRepository, authorizer authz.Authorizer) *OrderQueryHandler {
 	return &OrderQueryHandler{
-		orderRepo: orderRepo,
+		orderRepo:  orderRepo,
+		authorizer: authorizer,
 	}
 }
 
+// Register registers the handler's queries with the bus
+func (h *OrderQueryHandler) Register(b *bus.QueryBus) {
+	bus.RegisterQuery(b, h.Handle)
+	bus.RegisterQuery(b, h.HandleStatusHistory)
+	bus.RegisterQuery(b, h.HandleByUserID)
+	bus.RegisterQuery(b, h.HandleList)
+}
+
 // Handle handles GetOrderByIDQuery
 func (h *OrderQueryHandler) Handle(ctx context.Context, query GetOrderByIDQuery) (*OrderResult, error) {
 	order, err := h.orderRepo.GetByID(ctx, query.ID)
 	if err != nil {
+		return nil, repositories.NotFoundAs(err, services.ErrOrderNotFound)
+	}
+	if err := authz.Authorize(ctx, h.authorizer, authz.ActionOrderRead, authz.OwnedBy(authz.ResourceOrder, order.ID, order.UserID)); err != nil {
 		return nil, err
 	}
 
 	return &OrderResult{Order: order}, nil
 }
 
+// HandleStatusHistory handles GetOrderStatusHistoryQuery
+func (h *OrderQueryHandler) HandleStatusHistory(ctx context.Context, query GetOrderStatusHistoryQuery) (*OrderStatusHistoryResult, error) {
+	order, err := h.orderRepo.GetByID(ctx, query.OrderID)
+	if err != nil {
+		return nil, repositories.NotFoundAs(err, services.ErrOrderNotFound)
+	}
+	if err := authz.Authorize(ctx, h.authorizer, authz.ActionOrderRead, authz.OwnedBy(authz.ResourceOrder, order.ID, order.UserID)); err != nil {
+		return nil, err
+	}
+
+	return &OrderStatusHistoryResult{
+		OrderID: order.ID,
+		Status:  order.Status,
+		History: order.StatusHistory,
+	}, nil
+}
+
 // HandleByUserID handles GetOrdersByUserIDQuery
 func (h *OrderQueryHandler) HandleByUserID(ctx context.Context, query GetOrdersByUserIDQuery) (*OrdersResult, error) {
+	if err := authz.Authorize(ctx, h.authorizer, authz.ActionOrderList, authz.OwnedBy(authz.ResourceOrder, uuid.Nil, query.UserID)); err != nil {
+		return nil, err
+	}
+
 	orders, err := h.orderRepo.GetByUserID(ctx, query.UserID, query.Offset, query.Limit)
 	if err != nil {
 		return nil, err
@@ -191,3 +263,20 @@ func (h *OrderQueryHandler) HandleByUserID(ctx context.Context, query GetOrdersB
 		Total:  len(orders), // In a real implementation, you'd get total count separately
 	}, nil
 }
+
+// HandleList handles ListOrdersQuery
+func (h *OrderQueryHandler) HandleList(ctx context.Context, query ListOrdersQuery) (*OrdersResult, error) {
+	if err := authz.Authorize(ctx, h.authorizer, authz.ActionOrderList, authz.Resource{Type: authz.ResourceOrder}); err != nil {
+		return nil, err
+	}
+
+	orders, err := h.orderRepo.List(ctx, query.Offset, query.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return &OrdersResult{
+		Orders: orders,
+		Total:  len(orders), // In a real implementation, you'd get total count separately
+	}, nil
+}
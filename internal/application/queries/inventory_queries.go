@@ -0,0 +1,43 @@
+package queries
+
+import (
+	"context"
+	"goclean/internal/application/authz"
+	"goclean/internal/application/bus"
+	"goclean/internal/domain/entities"
+	"goclean/internal/domain/services"
+
+	"github.com/google/uuid"
+)
+
+// GetProductStockQuery represents a query to get the stock level of a product
+type GetProductStockQuery struct {
+	ProductID uuid.UUID `json:"product_id" validate:"required"`
+}
+
+// InventoryQueryHandler handles inventory queries
+type InventoryQueryHandler struct {
+	inventoryService *services.InventoryDomainService
+	authorizer       authz.Authorizer
+}
+
+// NewInventoryQueryHandler creates a new inventory query handler
+func NewInventoryQueryHandler(inventoryService *services.InventoryDomainService, authorizer authz.Authorizer) *InventoryQueryHandler {
+	return &InventoryQueryHandler{
+		inventoryService: inventoryService,
+		authorizer:       authorizer,
+	}
+}
+
+// Register registers the handler's queries with the bus
+func (h *InventoryQueryHandler) Register(b *bus.QueryBus) {
+	bus.RegisterQuery(b, h.HandleGetStock)
+}
+
+// HandleGetStock handles GetProductStockQuery
+func (h *InventoryQueryHandler) HandleGetStock(ctx context.Context, query GetProductStockQuery) (*entities.InventoryItem, error) {
+	if err := authz.Authorize(ctx, h.authorizer, authz.ActionProductReadStock, authz.Resource{Type: authz.ResourceProduct, ID: query.ProductID}); err != nil {
+		return nil, err
+	}
+	return h.inventoryService.GetStock(ctx, query.ProductID)
+}
@@ -41,17 +41,32 @@ type GetProductByIDQuery struct {
 	ID uuid.UUID `json:"id" validate:"required"`
 }
 
+// AllowAnonymous lets visitors browse the catalogue
+func (q GetProductByIDQuery) AllowAnonymous() bool {
+	return true
+}
+
 // GetProductBySKUQuery represents a query to get product by SKU
 type GetProductBySKUQuery struct {
 	SKU string `json:"sku" validate:"required"`
 }
 
+// AllowAnonymous lets visitors browse the catalogue
+func (q GetProductBySKUQuery) AllowAnonymous() bool {
+	return true
+}
+
 // ListProductsQuery represents a query to list products
 type ListProductsQuery struct {
 	Offset int `json:"offset" validate:"min=0"`
 	Limit  int `json:"limit" validate:"min=1,max=100"`
 }
 
+// AllowAnonymous lets visitors browse the catalogue
+func (q ListProductsQuery) AllowAnonymous() bool {
+	return true
+}
+
 // ListProductsByCategoryQuery represents a query to list products by category
 type ListProductsByCategoryQuery struct {
 	Category string `json:"category" validate:"required"`
@@ -59,6 +74,11 @@ type ListProductsByCategoryQuery struct {
 	Limit    int    `json:"limit" validate:"min=1,max=100"`
 }
 
+// AllowAnonymous lets visitors browse the catalogue
+func (q ListProductsByCategoryQuery) AllowAnonymous() bool {
+	return true
+}
+
 // SearchProductsQuery represents a query to search products
 type SearchProductsQuery struct {
 	Query  string `json:"query" validate:"required"`
@@ -66,6 +86,11 @@ type SearchProductsQuery struct {
 	Limit  int    `json:"limit" validate:"min=1,max=100"`
 }
 
+// AllowAnonymous lets visitors browse the catalogue
+func (q SearchProductsQuery) AllowAnonymous() bool {
+	return true
+}
+
 // GetOrderByIDQuery represents a query to get order by ID
 type GetOrderByIDQuery struct {
 	ID uuid.UUID `json:"id" validate:"required"`
@@ -78,6 +103,11 @@ type GetOrdersByUserIDQuery struct {
 	Limit  int       `json:"limit" validate:"min=1,max=100"`
 }
 
+// GetOrderStatusHistoryQuery represents a query to get the status history of an order
+type GetOrderStatusHistoryQuery struct {
+	OrderID uuid.UUID `json:"order_id" validate:"required"`
+}
+
 // ListOrdersQuery represents a query to list orders
 type ListOrdersQuery struct {
 	Offset int `json:"offset" validate:"min=0"`
@@ -114,6 +144,13 @@ type OrderResult struct {
 	Order *entities.Order `json:"order"`
 }
 
+// OrderStatusHistoryResult represents order status history query result
+type OrderStatusHistoryResult struct {
+	OrderID uuid.UUID                     `json:"order_id"`
+	Status  entities.OrderStatus          `json:"status"`
+	History []entities.OrderStatusHistory `json:"history"`
+}
+
 // OrdersResult represents orders list query result
 type OrdersResult struct {
 	Orders []*entities.Order `json:"orders"`
@@ -2,41 +2,44 @@ package queries
 
 import (
 	"context"
+	"goclean/internal/application/authz"
+	"goclean/internal/application/bus"
 	"goclean/internal/domain/entities"
 	"goclean/internal/domain/services"
-
-	"github.com/google/uuid"
 )
 
-// GetDeletedUsersQuery represents a query to get deleted users
-type GetDeletedUsersQuery struct {
+// ListDeletedQuery represents a query to list soft deleted entities of type T
+type ListDeletedQuery[T entities.SoftDeletable] struct {
 	Offset int `json:"offset" validate:"min=0"`
 	Limit  int `json:"limit" validate:"min=1,max=100"`
 }
 
-// GetUserWithDeletedQuery represents a query to get a user including deleted ones
-type GetUserWithDeletedQuery struct {
-	UserID uuid.UUID `json:"user_id" validate:"required"`
-}
-
-// DeletedUsersQueryHandler handles queries for deleted users
-type DeletedUsersQueryHandler struct {
-	userAggregateService *services.UserAggregateService
+// DeletedQueryHandler handles queries for soft deleted entities of one type
+type DeletedQueryHandler[T entities.SoftDeletable] struct {
+	service      *services.SoftDeleteService[T]
+	authorizer   authz.Authorizer
+	resourceType string
 }
 
-// NewDeletedUsersQueryHandler creates a new deleted users query handler
-func NewDeletedUsersQueryHandler(userAggregateService *services.UserAggregateService) *DeletedUsersQueryHandler {
-	return &DeletedUsersQueryHandler{
-		userAggregateService: userAggregateService,
+// NewDeletedQueryHandler creates a deleted entities query handler
+func NewDeletedQueryHandler[T entities.SoftDeletable](service *services.SoftDeleteService[T], authorizer authz.Authorizer, resourceType string) *DeletedQueryHandler[T] {
+	return &DeletedQueryHandler[T]{
+		service:      service,
+		authorizer:   authorizer,
+		resourceType: resourceType,
 	}
 }
 
-// GetDeletedUsers handles the get deleted users query
-func (h *DeletedUsersQueryHandler) GetDeletedUsers(ctx context.Context, query GetDeletedUsersQuery) ([]*entities.User, error) {
-	return h.userAggregateService.ListDeletedUsers(ctx, query.Offset, query.Limit)
+// Register registers the handler's queries with the bus
+func (h *DeletedQueryHandler[T]) Register(b *bus.QueryBus) {
+	bus.RegisterQuery(b, h.ListDeleted)
 }
 
-// GetUserWithDeleted handles the get user with deleted query
-func (h *DeletedUsersQueryHandler) GetUserWithDeleted(ctx context.Context, query GetUserWithDeletedQuery) (*entities.User, error) {
-	return h.userAggregateService.GetUserWithDeleted(ctx, query.UserID)
+// ListDeleted handles ListDeletedQuery
+func (h *DeletedQueryHandler[T]) ListDeleted(ctx context.Context, query ListDeletedQuery[T]) ([]T, error) {
+	action := authz.Lifecycle(h.resourceType).ReadDeleted
+	if err := authz.Authorize(ctx, h.authorizer, action, authz.Resource{Type: h.resourceType}); err != nil {
+		return nil, err
+	}
+	return h.service.ListDeleted(ctx, query.Offset, query.Limit)
 }
@@ -0,0 +1,141 @@
+package validation
+
+import (
+	"errors"
+	"fmt"
+	"goclean/internal/domain"
+	"reflect"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// FieldError describes a field that failed a validate tag rule
+type FieldError struct {
+	Field   string `json:"field" example:"email"` // Path of the field as sent by the client, e.g. "items[0].quantity"
+	Rule    string `json:"rule" example:"email"`  // Failed rule, e.g. "required" or "max"
+	Param   string `json:"param,omitempty"`       // Rule parameter, e.g. "100" for max=100
+	Message string `json:"message" example:"must be a valid email address"`
+}
+
+// Errors lists every field that failed validation
+type Errors []FieldError
+
+// Error implements error
+func (e Errors) Error() string {
+	messages := make([]string, len(e))
+	for i, fieldError := range e {
+		messages[i] = fieldError.Field + " " + fieldError.Message
+	}
+	return "validation failed: " + strings.Join(messages, "; ")
+}
+
+// Is makes errors.Is(err, domain.ErrValidation) match field errors
+func (e Errors) Is(target error) bool {
+	return target == domain.ErrValidation
+}
+
+// Validator checks structs against their validate tags. It satisfies both echo.Validator
+// and bus.Validator, so request bodies and dispatched messages follow the same rules.
+type Validator struct {
+	validate *validator.Validate
+}
+
+// New creates a new validator reporting fields by their json or query name
+func New() *Validator {
+	validate := validator.New(validator.WithRequiredStructEnabled())
+	validate.RegisterTagNameFunc(fieldName)
+	return &Validator{validate: validate}
+}
+
+// Validate validates a struct or pointer to struct; other values have no tags and always pass.
+// Failures are returned as Errors.
+func (v *Validator) Validate(payload any) error {
+	value := reflect.ValueOf(payload)
+	for value.Kind() == reflect.Pointer {
+		if value.IsNil() {
+			return nil
+		}
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.Struct {
+		return nil
+	}
+
+	err := v.validate.Struct(payload)
+	var validationErrors validator.ValidationErrors
+	if !errors.As(err, &validationErrors) {
+		return err
+	}
+
+	fieldErrors := make(Errors, len(validationErrors))
+	for i, fieldError := range validationErrors {
+		fieldErrors[i] = FieldError{
+			Field:   fieldPath(fieldError.Namespace()),
+			Rule:    fieldError.Tag(),
+			Param:   fieldError.Param(),
+			Message: message(fieldError),
+		}
+	}
+	return fieldErrors
+}
+
+// fieldName names struct fields after their json tag, falling back to the query tag
+func fieldName(field reflect.StructField) string {
+	for _, tag := range []string{"json", "query"} {
+		name, _, _ := strings.Cut(field.Tag.Get(tag), ",")
+		if name == "-" {
+			return ""
+		}
+		if name != "" {
+			return name
+		}
+	}
+	return field.Name
+}
+
+// fieldPath drops the root struct name from a namespace such as "CreateOrderRequest.items[0].quantity"
+func fieldPath(namespace string) string {
+	if _, path, ok := strings.Cut(namespace, "."); ok {
+		return path
+	}
+	return namespace
+}
+
+// message describes the failed rule for humans
+func message(fieldError validator.FieldError) string {
+	switch fieldError.Tag() {
+	case "required":
+		return "is required"
+	case "email":
+		return "must be a valid email address"
+	case "min":
+		if isCollection(fieldError.Kind()) {
+			return fmt.Sprintf("must contain at least %s items", fieldError.Param())
+		}
+		if fieldError.Kind() == reflect.String {
+			return fmt.Sprintf("must be at least %s characters long", fieldError.Param())
+		}
+		return fmt.Sprintf("must be at least %s", fieldError.Param())
+	case "max":
+		if isCollection(fieldError.Kind()) {
+			return fmt.Sprintf("must contain at most %s items", fieldError.Param())
+		}
+		if fieldError.Kind() == reflect.String {
+			return fmt.Sprintf("must be at most %s characters long", fieldError.Param())
+		}
+		return fmt.Sprintf("must be at most %s", fieldError.Param())
+	case "gt":
+		return fmt.Sprintf("must be greater than %s", fieldError.Param())
+	case "oneof":
+		return fmt.Sprintf("must be one of: %s", fieldError.Param())
+	case "iso4217":
+		return "must be an ISO 4217 currency code"
+	default:
+		return fmt.Sprintf("failed the %s rule", fieldError.Tag())
+	}
+}
+
+func isCollection(kind reflect.Kind) bool {
+	return kind == reflect.Slice || kind == reflect.Array || kind == reflect.Map
+}
@@ -0,0 +1,130 @@
+package entities
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"goclean/internal/domain"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// apiKeyPrefix marks API keys so they are recognisable in logs and secret scanners
+const apiKeyPrefix = "gck"
+
+var (
+	ErrInvalidAPIKey      = errors.New("invalid API key")
+	ErrAPIKeyNameRequired = domain.NewValidation("api_key_name_required", "API key name is required")
+	ErrAPIKeyExpiryInPast = domain.NewValidation("api_key_expiry_in_past", "API key expiry must be in the future")
+)
+
+// APIKey represents a long-lived credential for service-to-service access. Only a
+// hash of the secret is stored; the plaintext key is returned once when issued.
+type APIKey struct {
+	BaseEntity
+	Name       string     `json:"name" gorm:"not null"`
+	KeyID      string     `json:"key_id" gorm:"uniqueIndex;not null"` // Public lookup part of the key
+	SecretHash string     `json:"-" gorm:"not null"`
+	OwnerID    uuid.UUID  `json:"owner_id" gorm:"type:uuid;not null;index"`
+	Scopes     []string   `json:"scopes" gorm:"serializer:json"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+	CreatedBy  uuid.UUID  `json:"created_by" gorm:"type:uuid;not null"`
+}
+
+// NewAPIKey creates a new API key and returns it with the plaintext key.
+// The plaintext key has the form gck_<key id>_<secret>.
+func NewAPIKey(name string, ownerID uuid.UUID, scopes []string, expiresAt *time.Time, createdBy uuid.UUID) (*APIKey, string, error) {
+	if strings.TrimSpace(name) == "" {
+		return nil, "", ErrAPIKeyNameRequired
+	}
+	if expiresAt != nil && !expiresAt.After(time.Now()) {
+		return nil, "", ErrAPIKeyExpiryInPast
+	}
+
+	keyID, err := randomToken(6)
+	if err != nil {
+		return nil, "", err
+	}
+	secret, err := randomToken(32)
+	if err != nil {
+		return nil, "", err
+	}
+
+	key := &APIKey{
+		BaseEntity: BaseEntity{
+			ID:        uuid.New(),
+			CreatedAt: time.Now(),
+			UpdatedAt: time.Now(),
+		},
+		Name:       name,
+		KeyID:      keyID,
+		SecretHash: hashAPIKeySecret(secret),
+		OwnerID:    ownerID,
+		Scopes:     scopes,
+		ExpiresAt:  expiresAt,
+		CreatedBy:  createdBy,
+	}
+
+	return key, fmt.Sprintf("%s_%s_%s", apiKeyPrefix, keyID, secret), nil
+}
+
+// ParseAPIKey splits a plaintext key into its key ID and secret
+func ParseAPIKey(plaintext string) (keyID, secret string, err error) {
+	parts := strings.SplitN(plaintext, "_", 3)
+	if len(parts) != 3 || parts[0] != apiKeyPrefix || parts[1] == "" || parts[2] == "" {
+		return "", "", ErrInvalidAPIKey
+	}
+	return parts[1], parts[2], nil
+}
+
+// VerifySecret checks the secret against the stored hash in constant time
+func (k *APIKey) VerifySecret(secret string) bool {
+	return subtle.ConstantTimeCompare([]byte(k.SecretHash), []byte(hashAPIKeySecret(secret))) == 1
+}
+
+// IsActive checks that the key is neither revoked nor expired
+func (k *APIKey) IsActive(now time.Time) bool {
+	if k.RevokedAt != nil {
+		return false
+	}
+	return k.ExpiresAt == nil || now.Before(*k.ExpiresAt)
+}
+
+// Revoke revokes the key; revoking twice keeps the original revocation time
+func (k *APIKey) Revoke() {
+	if k.RevokedAt != nil {
+		return
+	}
+	now := time.Now()
+	k.RevokedAt = &now
+	k.UpdatedAt = now
+}
+
+// TableName returns the table name for GORM
+func (k *APIKey) TableName() string {
+	return "api_keys"
+}
+
+// hashAPIKeySecret hashes a secret. Secrets are 256-bit random values, so a fast hash suffices.
+func hashAPIKeySecret(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+// randomToken returns n random bytes encoded as unpadded base64url without underscores,
+// so the token can be used as a component of an underscore-separated key
+func randomToken(n int) (string, error) {
+	buf := make([]byte, n)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate random token: %w", err)
+	}
+	return strings.ReplaceAll(base64.RawURLEncoding.EncodeToString(buf), "_", "-"), nil
+}
@@ -1,9 +1,12 @@
 package entities
 
 import (
+	"fmt"
+	"goclean/internal/domain"
 	"time"
 
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
 // AggregateRoot represents the base aggregate root with domain events
@@ -32,40 +35,96 @@ func (ar *AggregateRoot) ClearDomainEvents() {
 	ar.domainEvents = nil
 }
 
-// BaseEntity represents the base entity with common fields
+// BaseEntity represents the base entity with common fields. DeletedAt is a gorm.DeletedAt,
+// so GORM leaves soft deleted rows out of queries unless they are Unscoped.
 type BaseEntity struct {
-	ID        uuid.UUID  `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
-	CreatedAt time.Time  `json:"created_at" gorm:"autoCreateTime"`
-	UpdatedAt time.Time  `json:"updated_at" gorm:"autoUpdateTime"`
-	DeletedAt *time.Time `json:"deleted_at,omitempty" gorm:"index"` // Soft delete field
+	ID             uuid.UUID      `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	CreatedAt      time.Time      `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt      time.Time      `json:"updated_at" gorm:"autoUpdateTime"`
+	DeletedAt      gorm.DeletedAt `json:"deleted_at" gorm:"index"` // Soft delete field
+	DeletionReason string         `json:"deletion_reason,omitempty" gorm:"size:500"`
+}
+
+var (
+	// ErrAlreadyDeleted is returned when deleting an entity that is already soft deleted
+	ErrAlreadyDeleted = domain.NewInvalidStateTransition("already_deleted", "already deleted")
+	// ErrNotDeleted is returned when restoring an entity that is not soft deleted
+	ErrNotDeleted = domain.NewInvalidStateTransition("not_deleted", "not deleted")
+	// ErrUserErased is returned when changing or erasing a user whose personal data has been erased
+	ErrUserErased = domain.NewInvalidStateTransition("user_erased", "user has been erased")
+)
+
+// SoftDeletable is an entity with a soft delete lifecycle. Delete and Restore raise
+// domain events, so they are the only way aggregates should enter or leave it. Purge
+// raises the event of removing a deleted entity for good; the repository then hard
+// deletes it.
+type SoftDeletable interface {
+	EntityID() uuid.UUID
+	IsDeleted() bool
+	DeletionInfo() (deletedAt *time.Time, reason string)
+	Delete(reason string) error
+	Restore() error
+	Purge() error
+}
+
+// EntityID returns the entity's ID
+func (be *BaseEntity) EntityID() uuid.UUID {
+	return be.ID
 }
 
 // IsDeleted checks if the entity is soft deleted
 func (be *BaseEntity) IsDeleted() bool {
-	return be.DeletedAt != nil
+	return be.DeletedAt.Valid
 }
 
-// SoftDelete marks the entity as deleted
-func (be *BaseEntity) SoftDelete() {
-	now := time.Now()
-	be.DeletedAt = &now
+// DeletionInfo returns when and why the entity was soft deleted
+func (be *BaseEntity) DeletionInfo() (*time.Time, string) {
+	if !be.DeletedAt.Valid {
+		return nil, ""
+	}
+	deletedAt := be.DeletedAt.Time
+	return &deletedAt, be.DeletionReason
+}
+
+// markDeleted soft deletes the entity, recording why
+func (be *BaseEntity) markDeleted(reason string) error {
+	if be.IsDeleted() {
+		return ErrAlreadyDeleted
+	}
+	be.DeletedAt = gorm.DeletedAt{Time: time.Now(), Valid: true}
+	be.DeletionReason = reason
+	return nil
 }
 
-// Restore restores a soft deleted entity
-func (be *BaseEntity) Restore() {
-	be.DeletedAt = nil
+// markRestored restores a soft deleted entity
+func (be *BaseEntity) markRestored() error {
+	if !be.IsDeleted() {
+		return ErrNotDeleted
+	}
+	be.DeletedAt = gorm.DeletedAt{}
+	be.DeletionReason = ""
+	return nil
+}
+
+// checkPurgeable checks that the entity is soft deleted, since only deleted entities are purged
+func (be *BaseEntity) checkPurgeable() error {
+	if !be.IsDeleted() {
+		return ErrNotDeleted
+	}
+	return nil
 }
 
 // User represents the aggregate root for user domain
 type User struct {
-	BaseEntity             // Embedded base entity with soft delete
-	AggregateRoot          // Embedded aggregate root for domain events
-	Email         string   `json:"email" gorm:"uniqueIndex;not null"`
-	Username      string   `json:"username" gorm:"uniqueIndex;not null"`
-	FirstName     string   `json:"first_name" gorm:"not null"`
-	LastName      string   `json:"last_name" gorm:"not null"`
-	IsActive      bool     `json:"is_active" gorm:"default:true"`
-	Profile       *Profile `json:"profile,omitempty" gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`
+	BaseEntity               // Embedded base entity with soft delete
+	AggregateRoot            // Embedded aggregate root for domain events
+	Email         string     `json:"email" gorm:"uniqueIndex;not null"`
+	Username      string     `json:"username" gorm:"uniqueIndex;not null"`
+	FirstName     string     `json:"first_name" gorm:"not null"`
+	LastName      string     `json:"last_name" gorm:"not null"`
+	IsActive      bool       `json:"is_active" gorm:"default:true"`
+	ErasedAt      *time.Time `json:"erased_at,omitempty"` // Set once personal data has been erased
+	Profile       *Profile   `json:"profile,omitempty" gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`
 }
 
 // UserCreatedEvent represents a user created domain event
@@ -89,6 +148,7 @@ func (e UserCreatedEvent) EventType() string {
 // UserDeletedEvent represents a user deleted domain event
 type UserDeletedEvent struct {
 	UserID     uuid.UUID `json:"user_id"`
+	Reason     string    `json:"reason,omitempty"`
 	OccurredAt time.Time `json:"occurred_at"`
 }
 
@@ -102,11 +162,85 @@ func (e UserDeletedEvent) EventType() string {
 	return "UserDeleted"
 }
 
+// UserRestoredEvent represents a user restored domain event
+type UserRestoredEvent struct {
+	UserID     uuid.UUID `json:"user_id"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+// OccurredOn returns when the event occurred
+func (e UserRestoredEvent) OccurredOn() time.Time {
+	return e.OccurredAt
+}
+
+// EventType returns the event type
+func (e UserRestoredEvent) EventType() string {
+	return "UserRestored"
+}
+
+// UserPurgedEvent represents a user purged domain event, raised when a soft
+// deleted user is removed for good
+type UserPurgedEvent struct {
+	UserID     uuid.UUID `json:"user_id"`
+	DeletedAt  time.Time `json:"deleted_at"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+// OccurredOn returns when the event occurred
+func (e UserPurgedEvent) OccurredOn() time.Time {
+	return e.OccurredAt
+}
+
+// EventType returns the event type
+func (e UserPurgedEvent) EventType() string {
+	return "UserPurged"
+}
+
+// UserDataExportedEvent represents a user data exported domain event, raised when
+// everything stored about a user has been exported for a subject access request
+type UserDataExportedEvent struct {
+	UserID     uuid.UUID `json:"user_id"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+// OccurredOn returns when the event occurred
+func (e UserDataExportedEvent) OccurredOn() time.Time {
+	return e.OccurredAt
+}
+
+// EventType returns the event type
+func (e UserDataExportedEvent) EventType() string {
+	return "UserDataExported"
+}
+
+// UserErasedEvent represents a user erased domain event, raised when a user's personal
+// data has been anonymised
+type UserErasedEvent struct {
+	UserID     uuid.UUID `json:"user_id"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+// OccurredOn returns when the event occurred
+func (e UserErasedEvent) OccurredOn() time.Time {
+	return e.OccurredAt
+}
+
+// EventType returns the event type
+func (e UserErasedEvent) EventType() string {
+	return "UserErased"
+}
+
 // NewUser creates a new user aggregate
 func NewUser(email, username, firstName, lastName string) *User {
+	return NewUserWithID(uuid.New(), email, username, firstName, lastName)
+}
+
+// NewUserWithID creates a new user aggregate with an identity assigned elsewhere,
+// such as the subject of an identity provider token
+func NewUserWithID(id uuid.UUID, email, username, firstName, lastName string) *User {
 	user := &User{
 		BaseEntity: BaseEntity{
-			ID:        uuid.New(),
+			ID:        id,
 			CreatedAt: time.Now(),
 			UpdatedAt: time.Now(),
 		},
@@ -128,16 +262,146 @@ func NewUser(email, username, firstName, lastName string) *User {
 	return user
 }
 
-// Delete soft deletes the user and raises domain event
-func (u *User) Delete() {
-	u.SoftDelete()
+// SyncIdentity updates the user's identity fields from the identity provider.
+// Empty values are ignored. It reports whether anything changed.
+func (u *User) SyncIdentity(email, username, firstName, lastName string) bool {
+	changed := false
+	for _, field := range []struct {
+		current *string
+		value   string
+	}{
+		{&u.Email, email},
+		{&u.Username, username},
+		{&u.FirstName, firstName},
+		{&u.LastName, lastName},
+	} {
+		if field.value != "" && *field.current != field.value {
+			*field.current = field.value
+			changed = true
+		}
+	}
+
+	if changed {
+		u.UpdatedAt = time.Now()
+	}
+	return changed
+}
+
+// UserChanges is a partial update of a user; nil fields are left unchanged
+type UserChanges struct {
+	Email     *string
+	Username  *string
+	FirstName *string
+	LastName  *string
+}
+
+// Update applies the changes to the user. It reports whether anything changed.
+func (u *User) Update(changes UserChanges) bool {
+	changed := false
+	for _, field := range []struct {
+		current *string
+		value   *string
+	}{
+		{&u.Email, changes.Email},
+		{&u.Username, changes.Username},
+		{&u.FirstName, changes.FirstName},
+		{&u.LastName, changes.LastName},
+	} {
+		if field.value != nil && *field.current != *field.value {
+			*field.current = *field.value
+			changed = true
+		}
+	}
+
+	if changed {
+		u.UpdatedAt = time.Now()
+	}
+	return changed
+}
+
+// Delete soft deletes and deactivates the user and raises domain event
+func (u *User) Delete(reason string) error {
+	if err := u.markDeleted(reason); err != nil {
+		return err
+	}
 	u.IsActive = false
 
 	// Add domain event
 	u.AddDomainEvent(UserDeletedEvent{
 		UserID:     u.ID,
+		Reason:     reason,
+		OccurredAt: time.Now(),
+	})
+	return nil
+}
+
+// Restore restores and reactivates a soft deleted user and raises domain event
+func (u *User) Restore() error {
+	if err := u.markRestored(); err != nil {
+		return err
+	}
+	u.IsActive = true
+
+	u.AddDomainEvent(UserRestoredEvent{
+		UserID:     u.ID,
+		OccurredAt: time.Now(),
+	})
+	return nil
+}
+
+// Purge raises domain event for removing the soft deleted user for good; the
+// repository hard deletes it afterwards
+func (u *User) Purge() error {
+	if err := u.checkPurgeable(); err != nil {
+		return err
+	}
+
+	u.AddDomainEvent(UserPurgedEvent{
+		UserID:     u.ID,
+		DeletedAt:  u.DeletedAt.Time,
 		OccurredAt: time.Now(),
 	})
+	return nil
+}
+
+// IsErased checks if the user's personal data has been erased
+func (u *User) IsErased() bool {
+	return u.ErasedAt != nil
+}
+
+// RecordDataExport raises domain event for an export of the user's data
+func (u *User) RecordDataExport() {
+	u.AddDomainEvent(UserDataExportedEvent{
+		UserID:     u.ID,
+		OccurredAt: time.Now(),
+	})
+}
+
+// Erase anonymises the personal data of the user and its profile, deactivates the user
+// and raises domain event. Email and username are replaced by unique placeholders so
+// the user's orders stay attached to a valid record.
+func (u *User) Erase() error {
+	if u.IsErased() {
+		return ErrUserErased
+	}
+
+	now := time.Now()
+	u.Email = "erased-" + u.ID.String() + "@erased.invalid"
+	u.Username = "erased-" + u.ID.String()
+	u.FirstName = ""
+	u.LastName = ""
+	u.IsActive = false
+	u.ErasedAt = &now
+	u.UpdatedAt = now
+	if u.Profile != nil {
+		u.Profile.erase()
+	}
+
+	u.AddDomainEvent(UserErasedEvent{
+		UserID:     u.ID,
+		OccurredAt: now,
+	})
+	return nil
 }
 
 // TableName returns the table name for GORM
@@ -147,11 +411,12 @@ func (u *User) TableName() string {
 
 // Profile represents user profile information (child entity of User aggregate)
 type Profile struct {
-	BaseEntity             // Embedded base entity with soft delete
-	UserID      uuid.UUID  `json:"user_id" gorm:"type:uuid;not null;index"`
-	Bio         string     `json:"bio"`
-	Avatar      string     `json:"avatar"`
-	DateOfBirth *time.Time `json:"date_of_birth"`
+	BaseEntity               // Embedded base entity with soft delete
+	AggregateRoot            // Embedded for the domain events of its soft delete lifecycle
+	UserID        uuid.UUID  `json:"user_id" gorm:"type:uuid;not null;index"`
+	Bio           string     `json:"bio"`
+	Avatar        string     `json:"avatar"`
+	DateOfBirth   *time.Time `json:"date_of_birth"`
 }
 
 // NewProfile creates a new profile
@@ -169,6 +434,113 @@ func NewProfile(userID uuid.UUID, bio, avatar string, dateOfBirth *time.Time) *P
 	}
 }
 
+// ProfileDeletedEvent represents a profile deleted domain event
+type ProfileDeletedEvent struct {
+	ProfileID  uuid.UUID `json:"profile_id"`
+	UserID     uuid.UUID `json:"user_id"`
+	Reason     string    `json:"reason,omitempty"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+// OccurredOn returns when the event occurred
+func (e ProfileDeletedEvent) OccurredOn() time.Time {
+	return e.OccurredAt
+}
+
+// EventType returns the event type
+func (e ProfileDeletedEvent) EventType() string {
+	return "ProfileDeleted"
+}
+
+// ProfileRestoredEvent represents a profile restored domain event
+type ProfileRestoredEvent struct {
+	ProfileID  uuid.UUID `json:"profile_id"`
+	UserID     uuid.UUID `json:"user_id"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+// OccurredOn returns when the event occurred
+func (e ProfileRestoredEvent) OccurredOn() time.Time {
+	return e.OccurredAt
+}
+
+// EventType returns the event type
+func (e ProfileRestoredEvent) EventType() string {
+	return "ProfileRestored"
+}
+
+// ProfilePurgedEvent represents a profile purged domain event, raised when a soft
+// deleted profile is removed for good
+type ProfilePurgedEvent struct {
+	ProfileID  uuid.UUID `json:"profile_id"`
+	UserID     uuid.UUID `json:"user_id"`
+	DeletedAt  time.Time `json:"deleted_at"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+// OccurredOn returns when the event occurred
+func (e ProfilePurgedEvent) OccurredOn() time.Time {
+	return e.OccurredAt
+}
+
+// EventType returns the event type
+func (e ProfilePurgedEvent) EventType() string {
+	return "ProfilePurged"
+}
+
+// Delete soft deletes the profile and raises domain event
+func (p *Profile) Delete(reason string) error {
+	if err := p.markDeleted(reason); err != nil {
+		return err
+	}
+
+	p.AddDomainEvent(ProfileDeletedEvent{
+		ProfileID:  p.ID,
+		UserID:     p.UserID,
+		Reason:     reason,
+		OccurredAt: time.Now(),
+	})
+	return nil
+}
+
+// Restore restores a soft deleted profile and raises domain event
+func (p *Profile) Restore() error {
+	if err := p.markRestored(); err != nil {
+		return err
+	}
+
+	p.AddDomainEvent(ProfileRestoredEvent{
+		ProfileID:  p.ID,
+		UserID:     p.UserID,
+		OccurredAt: time.Now(),
+	})
+	return nil
+}
+
+// Purge raises domain event for removing the soft deleted profile for good; the
+// repository hard deletes it afterwards
+func (p *Profile) Purge() error {
+	if err := p.checkPurgeable(); err != nil {
+		return err
+	}
+
+	p.AddDomainEvent(ProfilePurgedEvent{
+		ProfileID:  p.ID,
+		UserID:     p.UserID,
+		DeletedAt:  p.DeletedAt.Time,
+		OccurredAt: time.Now(),
+	})
+	return nil
+}
+
+// erase clears the personal data of the profile
+func (p *Profile) erase() {
+	p.Bio = ""
+	p.Avatar = ""
+	p.DateOfBirth = nil
+	p.UpdatedAt = time.Now()
+}
+
 // TableName returns the table name for GORM
 func (p *Profile) TableName() string {
 	return "profiles"
@@ -180,7 +552,7 @@ type Product struct {
 	AggregateRoot           // Embedded aggregate root for domain events
 	Name          string    `json:"name" gorm:"not null;index"`
 	Description   string    `json:"description"`
-	Price         float64   `json:"price" gorm:"not null"`
+	Price         Money     `json:"price" gorm:"embedded;embeddedPrefix:price_"`
 	SKU           string    `json:"sku" gorm:"uniqueIndex;not null"`
 	Category      string    `json:"category" gorm:"index"`
 	IsActive      bool      `json:"is_active" gorm:"default:true"`
@@ -209,6 +581,7 @@ func (e ProductCreatedEvent) EventType() string {
 // ProductDeletedEvent represents a product deleted domain event
 type ProductDeletedEvent struct {
 	ProductID  uuid.UUID `json:"product_id"`
+	Reason     string    `json:"reason,omitempty"`
 	OccurredAt time.Time `json:"occurred_at"`
 }
 
@@ -222,8 +595,42 @@ func (e ProductDeletedEvent) EventType() string {
 	return "ProductDeleted"
 }
 
+// ProductRestoredEvent represents a product restored domain event
+type ProductRestoredEvent struct {
+	ProductID  uuid.UUID `json:"product_id"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+// OccurredOn returns when the event occurred
+func (e ProductRestoredEvent) OccurredOn() time.Time {
+	return e.OccurredAt
+}
+
+// EventType returns the event type
+func (e ProductRestoredEvent) EventType() string {
+	return "ProductRestored"
+}
+
+// ProductPurgedEvent represents a product purged domain event, raised when a soft
+// deleted product is removed for good
+type ProductPurgedEvent struct {
+	ProductID  uuid.UUID `json:"product_id"`
+	DeletedAt  time.Time `json:"deleted_at"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+// OccurredOn returns when the event occurred
+func (e ProductPurgedEvent) OccurredOn() time.Time {
+	return e.OccurredAt
+}
+
+// EventType returns the event type
+func (e ProductPurgedEvent) EventType() string {
+	return "ProductPurged"
+}
+
 // NewProduct creates a new product aggregate
-func NewProduct(name, description, sku, category string, price float64, createdBy uuid.UUID) *Product {
+func NewProduct(name, description, sku, category string, price Money, createdBy uuid.UUID) *Product {
 	product := &Product{
 		BaseEntity: BaseEntity{
 			ID:        uuid.New(),
@@ -251,16 +658,91 @@ func NewProduct(name, description, sku, category string, price float64, createdB
 	return product
 }
 
-// Delete soft deletes the product and raises domain event
-func (p *Product) Delete() {
-	p.SoftDelete()
+// ProductChanges is a partial update of a product; nil fields are left unchanged
+type ProductChanges struct {
+	Name        *string
+	Description *string
+	Price       *Money
+	SKU         *string
+	Category    *string
+	IsActive    *bool
+}
+
+// Update applies the changes to the product. It reports whether anything changed.
+func (p *Product) Update(changes ProductChanges) bool {
+	changed := false
+	for _, field := range []struct {
+		current *string
+		value   *string
+	}{
+		{&p.Name, changes.Name},
+		{&p.Description, changes.Description},
+		{&p.SKU, changes.SKU},
+		{&p.Category, changes.Category},
+	} {
+		if field.value != nil && *field.current != *field.value {
+			*field.current = *field.value
+			changed = true
+		}
+	}
+	if changes.Price != nil && p.Price != *changes.Price {
+		p.Price = *changes.Price
+		changed = true
+	}
+	if changes.IsActive != nil && p.IsActive != *changes.IsActive {
+		p.IsActive = *changes.IsActive
+		changed = true
+	}
+
+	if changed {
+		p.UpdatedAt = time.Now()
+	}
+	return changed
+}
+
+// Delete soft deletes the product, taking it off sale, and raises domain event
+func (p *Product) Delete(reason string) error {
+	if err := p.markDeleted(reason); err != nil {
+		return err
+	}
 	p.IsActive = false
 
 	// Add domain event
 	p.AddDomainEvent(ProductDeletedEvent{
 		ProductID:  p.ID,
+		Reason:     reason,
+		OccurredAt: time.Now(),
+	})
+	return nil
+}
+
+// Restore restores a soft deleted product, putting it back on sale, and raises domain event
+func (p *Product) Restore() error {
+	if err := p.markRestored(); err != nil {
+		return err
+	}
+	p.IsActive = true
+
+	p.AddDomainEvent(ProductRestoredEvent{
+		ProductID:  p.ID,
+		OccurredAt: time.Now(),
+	})
+	return nil
+}
+
+// Purge raises domain event for removing the soft deleted product for good; the
+// repository hard deletes it afterwards
+func (p *Product) Purge() error {
+	if err := p.checkPurgeable(); err != nil {
+		return err
+	}
+
+	p.AddDomainEvent(ProductPurgedEvent{
+		ProductID:  p.ID,
+		DeletedAt:  p.DeletedAt.Time,
 		OccurredAt: time.Now(),
 	})
+	return nil
 }
 
 // TableName returns the table name for GORM
@@ -270,19 +752,20 @@ func (p *Product) TableName() string {
 
 // Order represents an order aggregate root
 type Order struct {
-	BaseEntity                // Embedded base entity with soft delete
-	AggregateRoot             // Embedded aggregate root for domain events
-	UserID        uuid.UUID   `json:"user_id" gorm:"type:uuid;not null;index"`
-	Status        OrderStatus `json:"status" gorm:"type:varchar(20);not null;default:'pending'"`
-	TotalPrice    float64     `json:"total_price" gorm:"not null"`
-	Items         []OrderItem `json:"items" gorm:"foreignKey:OrderID;constraint:OnDelete:CASCADE"`
+	BaseEntity                         // Embedded base entity with soft delete
+	AggregateRoot                      // Embedded aggregate root for domain events
+	UserID        uuid.UUID            `json:"user_id" gorm:"type:uuid;not null;index"`
+	Status        OrderStatus          `json:"status" gorm:"type:varchar(20);not null;default:'pending'"`
+	TotalPrice    Money                `json:"total_price" gorm:"embedded;embeddedPrefix:total_price_"`
+	Items         []OrderItem          `json:"items" gorm:"foreignKey:OrderID;constraint:OnDelete:CASCADE"`
+	StatusHistory []OrderStatusHistory `json:"status_history,omitempty" gorm:"foreignKey:OrderID;constraint:OnDelete:CASCADE"`
 }
 
 // OrderCreatedEvent represents an order created domain event
 type OrderCreatedEvent struct {
 	OrderID    uuid.UUID `json:"order_id"`
 	UserID     uuid.UUID `json:"user_id"`
-	TotalPrice float64   `json:"total_price"`
+	TotalPrice Money     `json:"total_price"`
 	ItemCount  int       `json:"item_count"`
 	OccurredAt time.Time `json:"occurred_at"`
 }
@@ -297,12 +780,65 @@ func (e OrderCreatedEvent) EventType() string {
 	return "OrderCreated"
 }
 
-// OrderCancelledEvent represents an order cancelled domain event
-type OrderCancelledEvent struct {
+// OrderConfirmedEvent represents an order confirmed domain event
+type OrderConfirmedEvent struct {
+	OrderID     uuid.UUID `json:"order_id"`
+	ConfirmedBy uuid.UUID `json:"confirmed_by"`
+	OccurredAt  time.Time `json:"occurred_at"`
+}
+
+// OccurredOn returns when the event occurred
+func (e OrderConfirmedEvent) OccurredOn() time.Time {
+	return e.OccurredAt
+}
+
+// EventType returns the event type
+func (e OrderConfirmedEvent) EventType() string {
+	return "OrderConfirmed"
+}
+
+// OrderShippedEvent represents an order shipped domain event
+type OrderShippedEvent struct {
 	OrderID    uuid.UUID `json:"order_id"`
+	ShippedBy  uuid.UUID `json:"shipped_by"`
 	OccurredAt time.Time `json:"occurred_at"`
 }
 
+// OccurredOn returns when the event occurred
+func (e OrderShippedEvent) OccurredOn() time.Time {
+	return e.OccurredAt
+}
+
+// EventType returns the event type
+func (e OrderShippedEvent) EventType() string {
+	return "OrderShipped"
+}
+
+// OrderDeliveredEvent represents an order delivered domain event
+type OrderDeliveredEvent struct {
+	OrderID     uuid.UUID `json:"order_id"`
+	DeliveredBy uuid.UUID `json:"delivered_by"`
+	OccurredAt  time.Time `json:"occurred_at"`
+}
+
+// OccurredOn returns when the event occurred
+func (e OrderDeliveredEvent) OccurredOn() time.Time {
+	return e.OccurredAt
+}
+
+// EventType returns the event type
+func (e OrderDeliveredEvent) EventType() string {
+	return "OrderDelivered"
+}
+
+// OrderCancelledEvent represents an order cancelled domain event
+type OrderCancelledEvent struct {
+	OrderID     uuid.UUID `json:"order_id"`
+	CancelledBy uuid.UUID `json:"cancelled_by"`
+	Reason      string    `json:"reason,omitempty"`
+	OccurredAt  time.Time `json:"occurred_at"`
+}
+
 // OccurredOn returns when the event occurred
 func (e OrderCancelledEvent) OccurredOn() time.Time {
 	return e.OccurredAt
@@ -313,23 +849,72 @@ func (e OrderCancelledEvent) EventType() string {
 	return "OrderCancelled"
 }
 
-// NewOrder creates a new order aggregate
-func NewOrder(userID uuid.UUID, items []OrderItem) *Order {
-	var totalPrice float64
-	for _, item := range items {
-		totalPrice += item.Price * float64(item.Quantity)
-	}
+// OrderDeletedEvent represents an order deleted domain event
+type OrderDeletedEvent struct {
+	OrderID    uuid.UUID `json:"order_id"`
+	Reason     string    `json:"reason,omitempty"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+// OccurredOn returns when the event occurred
+func (e OrderDeletedEvent) OccurredOn() time.Time {
+	return e.OccurredAt
+}
+
+// EventType returns the event type
+func (e OrderDeletedEvent) EventType() string {
+	return "OrderDeleted"
+}
+
+// OrderRestoredEvent represents an order restored domain event
+type OrderRestoredEvent struct {
+	OrderID    uuid.UUID `json:"order_id"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+// OccurredOn returns when the event occurred
+func (e OrderRestoredEvent) OccurredOn() time.Time {
+	return e.OccurredAt
+}
+
+// EventType returns the event type
+func (e OrderRestoredEvent) EventType() string {
+	return "OrderRestored"
+}
 
+// OrderPurgedEvent represents an order purged domain event, raised when a soft
+// deleted order is removed for good
+type OrderPurgedEvent struct {
+	OrderID    uuid.UUID `json:"order_id"`
+	DeletedAt  time.Time `json:"deleted_at"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+// OccurredOn returns when the event occurred
+func (e OrderPurgedEvent) OccurredOn() time.Time {
+	return e.OccurredAt
+}
+
+// EventType returns the event type
+func (e OrderPurgedEvent) EventType() string {
+	return "OrderPurged"
+}
+
+// NewOrder creates a new order aggregate. All items must be priced in the same currency.
+func NewOrder(userID uuid.UUID, items []OrderItem) (*Order, error) {
 	order := &Order{
 		BaseEntity: BaseEntity{
 			ID:        uuid.New(),
 			CreatedAt: time.Now(),
 			UpdatedAt: time.Now(),
 		},
-		UserID:     userID,
-		Status:     OrderStatusPending,
-		TotalPrice: totalPrice,
-		Items:      items,
+		UserID: userID,
+		Status: OrderStatusPending,
+		Items:  items,
+	}
+
+	if err := order.RecalculateTotal(); err != nil {
+		return nil, err
 	}
 
 	// Set order ID for all items
@@ -342,6 +927,9 @@ func NewOrder(userID uuid.UUID, items []OrderItem) *Order {
 		}
 	}
 
+	// Record the initial status
+	order.recordStatusChange("", OrderStatusPending, userID, "Order placed")
+
 	// Add domain event
 	order.AddDomainEvent(OrderCreatedEvent{
 		OrderID:    order.ID,
@@ -351,19 +939,176 @@ func NewOrder(userID uuid.UUID, items []OrderItem) *Order {
 		OccurredAt: time.Now(),
 	})
 
-	return order
+	return order, nil
 }
 
-// Cancel cancels the order and raises domain event
-func (o *Order) Cancel() {
-	o.Status = OrderStatusCancelled
-	o.UpdatedAt = time.Now()
+// RecalculateTotal sums the item subtotals into the order total.
+// Items priced in different currencies are rejected.
+func (o *Order) RecalculateTotal() error {
+	if len(o.Items) == 0 {
+		o.TotalPrice = Money{}
+		return nil
+	}
+
+	total := ZeroMoney(o.Items[0].Price.Currency)
+	for _, item := range o.Items {
+		subtotal, err := item.Subtotal()
+		if err != nil {
+			return err
+		}
+		if total, err = total.Add(subtotal); err != nil {
+			return err
+		}
+	}
+
+	o.TotalPrice = total
+	return nil
+}
+
+// Confirm confirms a pending order and raises domain event
+func (o *Order) Confirm(confirmedBy uuid.UUID, reason string) error {
+	if err := o.transitionTo(OrderStatusConfirmed, confirmedBy, reason); err != nil {
+		return err
+	}
+
+	// Add domain event
+	o.AddDomainEvent(OrderConfirmedEvent{
+		OrderID:     o.ID,
+		ConfirmedBy: confirmedBy,
+		OccurredAt:  time.Now(),
+	})
+
+	return nil
+}
+
+// Ship marks a confirmed order as shipped and raises domain event
+func (o *Order) Ship(shippedBy uuid.UUID, reason string) error {
+	if err := o.transitionTo(OrderStatusShipped, shippedBy, reason); err != nil {
+		return err
+	}
+
+	// Add domain event
+	o.AddDomainEvent(OrderShippedEvent{
+		OrderID:    o.ID,
+		ShippedBy:  shippedBy,
+		OccurredAt: time.Now(),
+	})
+
+	return nil
+}
+
+// Deliver marks a shipped order as delivered and raises domain event
+func (o *Order) Deliver(deliveredBy uuid.UUID, reason string) error {
+	if err := o.transitionTo(OrderStatusDelivered, deliveredBy, reason); err != nil {
+		return err
+	}
+
+	// Add domain event
+	o.AddDomainEvent(OrderDeliveredEvent{
+		OrderID:     o.ID,
+		DeliveredBy: deliveredBy,
+		OccurredAt:  time.Now(),
+	})
+
+	return nil
+}
+
+// Cancel cancels an order that has not been shipped yet and raises domain event
+func (o *Order) Cancel(cancelledBy uuid.UUID, reason string) error {
+	if err := o.transitionTo(OrderStatusCancelled, cancelledBy, reason); err != nil {
+		return err
+	}
 
 	// Add domain event
 	o.AddDomainEvent(OrderCancelledEvent{
+		OrderID:     o.ID,
+		CancelledBy: cancelledBy,
+		Reason:      reason,
+		OccurredAt:  time.Now(),
+	})
+
+	return nil
+}
+
+// ChangeStatus moves the order to the given status through the matching aggregate method
+func (o *Order) ChangeStatus(status OrderStatus, changedBy uuid.UUID, reason string) error {
+	switch status {
+	case OrderStatusConfirmed:
+		return o.Confirm(changedBy, reason)
+	case OrderStatusShipped:
+		return o.Ship(changedBy, reason)
+	case OrderStatusDelivered:
+		return o.Deliver(changedBy, reason)
+	case OrderStatusCancelled:
+		return o.Cancel(changedBy, reason)
+	default:
+		return fmt.Errorf("%w: %s -> %s", ErrInvalidStatusTransition, o.Status, status)
+	}
+}
+
+// transitionTo validates and applies a status change, recording it in the history
+func (o *Order) transitionTo(status OrderStatus, changedBy uuid.UUID, reason string) error {
+	if !o.Status.CanTransitionTo(status) {
+		return fmt.Errorf("%w: %s -> %s", ErrInvalidStatusTransition, o.Status, status)
+	}
+
+	from := o.Status
+	o.Status = status
+	o.UpdatedAt = time.Now()
+	o.recordStatusChange(from, status, changedBy, reason)
+
+	return nil
+}
+
+// recordStatusChange appends an entry to the order status history
+func (o *Order) recordStatusChange(from, to OrderStatus, changedBy uuid.UUID, reason string) {
+	o.StatusHistory = append(o.StatusHistory, *NewOrderStatusHistory(o.ID, from, to, changedBy, reason))
+}
+
+// Delete soft deletes the order and raises domain event. Only delivered or cancelled
+// orders can be deleted, so no stock stays reserved for a hidden order.
+func (o *Order) Delete(reason string) error {
+	if !o.Status.IsFinal() {
+		return fmt.Errorf("%w: %s", ErrOrderNotFinal, o.Status)
+	}
+	if err := o.markDeleted(reason); err != nil {
+		return err
+	}
+
+	o.AddDomainEvent(OrderDeletedEvent{
 		OrderID:    o.ID,
+		Reason:     reason,
 		OccurredAt: time.Now(),
 	})
+	return nil
+}
+
+// Restore restores a soft deleted order and raises domain event
+func (o *Order) Restore() error {
+	if err := o.markRestored(); err != nil {
+		return err
+	}
+
+	o.AddDomainEvent(OrderRestoredEvent{
+		OrderID:    o.ID,
+		OccurredAt: time.Now(),
+	})
+	return nil
+}
+
+// Purge raises domain event for removing the soft deleted order for good; the
+// repository hard deletes it afterwards
+func (o *Order) Purge() error {
+	if err := o.checkPurgeable(); err != nil {
+		return err
+	}
+
+	o.AddDomainEvent(OrderPurgedEvent{
+		OrderID:    o.ID,
+		DeletedAt:  o.DeletedAt.Time,
+		OccurredAt: time.Now(),
+	})
+	return nil
 }
 
 // TableName returns the table name for GORM
@@ -377,11 +1122,11 @@ type OrderItem struct {
 	OrderID    uuid.UUID `json:"order_id" gorm:"type:uuid;not null;index"`
 	ProductID  uuid.UUID `json:"product_id" gorm:"type:uuid;not null"`
 	Quantity   int       `json:"quantity" gorm:"not null"`
-	Price      float64   `json:"price" gorm:"not null"`
+	Price      Money     `json:"price" gorm:"embedded;embeddedPrefix:price_"`
 }
 
 // NewOrderItem creates a new order item
-func NewOrderItem(orderID, productID uuid.UUID, quantity int, price float64) *OrderItem {
+func NewOrderItem(orderID, productID uuid.UUID, quantity int, price Money) *OrderItem {
 	return &OrderItem{
 		BaseEntity: BaseEntity{
 			ID:        uuid.New(),
@@ -395,11 +1140,56 @@ func NewOrderItem(orderID, productID uuid.UUID, quantity int, price float64) *Or
 	}
 }
 
+// Subtotal returns the item price multiplied by its quantity
+func (oi *OrderItem) Subtotal() (Money, error) {
+	return oi.Price.Multiply(int64(oi.Quantity))
+}
+
 // TableName returns the table name for GORM
 func (oi *OrderItem) TableName() string {
 	return "order_items"
 }
 
+// OrderStatusHistory records a single order status change (child entity of Order aggregate)
+type OrderStatusHistory struct {
+	BaseEntity             // Embedded base entity with soft delete
+	OrderID    uuid.UUID   `json:"order_id" gorm:"type:uuid;not null;index"`
+	FromStatus OrderStatus `json:"from_status" gorm:"type:varchar(20)"`
+	ToStatus   OrderStatus `json:"to_status" gorm:"type:varchar(20);not null"`
+	ChangedBy  uuid.UUID   `json:"changed_by" gorm:"type:uuid;not null"`
+	Reason     string      `json:"reason"`
+	ChangedAt  time.Time   `json:"changed_at" gorm:"not null;index"`
+}
+
+// NewOrderStatusHistory creates a new order status history entry
+func NewOrderStatusHistory(orderID uuid.UUID, from, to OrderStatus, changedBy uuid.UUID, reason string) *OrderStatusHistory {
+	now := time.Now()
+	return &OrderStatusHistory{
+		BaseEntity: BaseEntity{
+			ID:        uuid.New(),
+			CreatedAt: now,
+			UpdatedAt: now,
+		},
+		OrderID:    orderID,
+		FromStatus: from,
+		ToStatus:   to,
+		ChangedBy:  changedBy,
+		Reason:     reason,
+		ChangedAt:  now,
+	}
+}
+
+// TableName returns the table name for GORM
+func (h *OrderStatusHistory) TableName() string {
+	return "order_status_history"
+}
+
+// ErrInvalidStatusTransition is returned when an order status change is not allowed
+var ErrInvalidStatusTransition = domain.NewInvalidStateTransition("invalid_order_status_transition", "invalid order status transition")
+
+// ErrOrderNotFinal is returned when deleting an order that is still in progress
+var ErrOrderNotFinal = domain.NewInvalidStateTransition("order_not_final", "only delivered or cancelled orders can be deleted")
+
 // OrderStatus represents order status value object
 type OrderStatus string
 
@@ -420,3 +1210,26 @@ func (os OrderStatus) IsValid() bool {
 		return false
 	}
 }
+
+// orderStatusTransitions lists the statuses each order status may move to.
+// Orders can only be cancelled before they are shipped.
+var orderStatusTransitions = map[OrderStatus][]OrderStatus{
+	OrderStatusPending:   {OrderStatusConfirmed, OrderStatusCancelled},
+	OrderStatusConfirmed: {OrderStatusShipped, OrderStatusCancelled},
+	OrderStatusShipped:   {OrderStatusDelivered},
+}
+
+// CanTransitionTo checks if the order status may change to the given status
+func (os OrderStatus) CanTransitionTo(next OrderStatus) bool {
+	for _, allowed := range orderStatusTransitions[os] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+// IsFinal checks if no further status changes are possible
+func (os OrderStatus) IsFinal() bool {
+	return len(orderStatusTransitions[os]) == 0
+}
@@ -0,0 +1,278 @@
+package entities
+
+import (
+	"fmt"
+	"goclean/internal/domain"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrInsufficientStock    = domain.NewInvalidStateTransition("insufficient_stock", "insufficient stock")
+	ErrInvalidStockQuantity = domain.NewValidation("invalid_stock_quantity", "stock quantity must be greater than zero")
+	ErrReservationNotActive = domain.NewInvalidStateTransition("reservation_not_active", "stock reservation is not active")
+)
+
+// InventoryItem represents the stock level of a single product (aggregate root)
+type InventoryItem struct {
+	BaseEntity              // Embedded base entity with soft delete
+	AggregateRoot           // Embedded aggregate root for domain events
+	ProductID     uuid.UUID `json:"product_id" gorm:"type:uuid;not null;uniqueIndex"`
+	OnHand        int       `json:"on_hand" gorm:"not null;default:0;check:on_hand >= 0"`
+	Reserved      int       `json:"reserved" gorm:"not null;default:0;check:reserved >= 0"`
+	Version       int       `json:"version" gorm:"not null;default:1"` // Optimistic concurrency token
+}
+
+// StockReservedEvent represents a stock reserved domain event
+type StockReservedEvent struct {
+	ProductID  uuid.UUID `json:"product_id"`
+	OrderID    uuid.UUID `json:"order_id"`
+	Quantity   int       `json:"quantity"`
+	Available  int       `json:"available"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+// OccurredOn returns when the event occurred
+func (e StockReservedEvent) OccurredOn() time.Time {
+	return e.OccurredAt
+}
+
+// EventType returns the event type
+func (e StockReservedEvent) EventType() string {
+	return "StockReserved"
+}
+
+// StockDepletedEvent represents a product running out of available stock
+type StockDepletedEvent struct {
+	ProductID  uuid.UUID `json:"product_id"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+// OccurredOn returns when the event occurred
+func (e StockDepletedEvent) OccurredOn() time.Time {
+	return e.OccurredAt
+}
+
+// EventType returns the event type
+func (e StockDepletedEvent) EventType() string {
+	return "StockDepleted"
+}
+
+// StockCommittedEvent represents reserved stock leaving the warehouse
+type StockCommittedEvent struct {
+	ProductID  uuid.UUID `json:"product_id"`
+	OrderID    uuid.UUID `json:"order_id"`
+	Quantity   int       `json:"quantity"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+// OccurredOn returns when the event occurred
+func (e StockCommittedEvent) OccurredOn() time.Time {
+	return e.OccurredAt
+}
+
+// EventType returns the event type
+func (e StockCommittedEvent) EventType() string {
+	return "StockCommitted"
+}
+
+// StockReleasedEvent represents reserved stock becoming available again
+type StockReleasedEvent struct {
+	ProductID  uuid.UUID `json:"product_id"`
+	OrderID    uuid.UUID `json:"order_id"`
+	Quantity   int       `json:"quantity"`
+	Available  int       `json:"available"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+// OccurredOn returns when the event occurred
+func (e StockReleasedEvent) OccurredOn() time.Time {
+	return e.OccurredAt
+}
+
+// EventType returns the event type
+func (e StockReleasedEvent) EventType() string {
+	return "StockReleased"
+}
+
+// StockRestockedEvent represents stock being added for a product
+type StockRestockedEvent struct {
+	ProductID  uuid.UUID `json:"product_id"`
+	Quantity   int       `json:"quantity"`
+	OnHand     int       `json:"on_hand"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+// OccurredOn returns when the event occurred
+func (e StockRestockedEvent) OccurredOn() time.Time {
+	return e.OccurredAt
+}
+
+// EventType returns the event type
+func (e StockRestockedEvent) EventType() string {
+	return "StockRestocked"
+}
+
+// NewInventoryItem creates a new inventory item for a product
+func NewInventoryItem(productID uuid.UUID, onHand int) *InventoryItem {
+	return &InventoryItem{
+		BaseEntity: BaseEntity{
+			ID:        uuid.New(),
+			CreatedAt: time.Now(),
+			UpdatedAt: time.Now(),
+		},
+		ProductID: productID,
+		OnHand:    onHand,
+		Version:   1,
+	}
+}
+
+// Available returns the stock that can still be reserved
+func (i *InventoryItem) Available() int {
+	return i.OnHand - i.Reserved
+}
+
+// Reserve sets stock aside for an order and raises domain events
+func (i *InventoryItem) Reserve(orderID uuid.UUID, quantity int) (*StockReservation, error) {
+	if quantity <= 0 {
+		return nil, ErrInvalidStockQuantity
+	}
+	if quantity > i.Available() {
+		return nil, fmt.Errorf("%w: product %s has %d available, %d requested",
+			ErrInsufficientStock, i.ProductID, i.Available(), quantity)
+	}
+
+	i.Reserved += quantity
+	i.UpdatedAt = time.Now()
+
+	// Add domain events
+	i.AddDomainEvent(StockReservedEvent{
+		ProductID:  i.ProductID,
+		OrderID:    orderID,
+		Quantity:   quantity,
+		Available:  i.Available(),
+		OccurredAt: time.Now(),
+	})
+	if i.Available() == 0 {
+		i.AddDomainEvent(StockDepletedEvent{
+			ProductID:  i.ProductID,
+			OccurredAt: time.Now(),
+		})
+	}
+
+	return NewStockReservation(orderID, i.ProductID, quantity), nil
+}
+
+// Commit removes reserved stock from the warehouse once the order ships
+func (i *InventoryItem) Commit(reservation *StockReservation) error {
+	if reservation.Status != ReservationStatusActive {
+		return ErrReservationNotActive
+	}
+
+	i.OnHand -= reservation.Quantity
+	i.Reserved -= reservation.Quantity
+	i.UpdatedAt = time.Now()
+	reservation.markAs(ReservationStatusCommitted)
+
+	// Add domain event
+	i.AddDomainEvent(StockCommittedEvent{
+		ProductID:  i.ProductID,
+		OrderID:    reservation.OrderID,
+		Quantity:   reservation.Quantity,
+		OccurredAt: time.Now(),
+	})
+
+	return nil
+}
+
+// Release returns reserved stock to the available pool
+func (i *InventoryItem) Release(reservation *StockReservation) error {
+	if reservation.Status != ReservationStatusActive {
+		return ErrReservationNotActive
+	}
+
+	i.Reserved -= reservation.Quantity
+	i.UpdatedAt = time.Now()
+	reservation.markAs(ReservationStatusReleased)
+
+	// Add domain event
+	i.AddDomainEvent(StockReleasedEvent{
+		ProductID:  i.ProductID,
+		OrderID:    reservation.OrderID,
+		Quantity:   reservation.Quantity,
+		Available:  i.Available(),
+		OccurredAt: time.Now(),
+	})
+
+	return nil
+}
+
+// Restock adds stock for the product and raises domain event
+func (i *InventoryItem) Restock(quantity int) error {
+	if quantity <= 0 {
+		return ErrInvalidStockQuantity
+	}
+
+	i.OnHand += quantity
+	i.UpdatedAt = time.Now()
+
+	// Add domain event
+	i.AddDomainEvent(StockRestockedEvent{
+		ProductID:  i.ProductID,
+		Quantity:   quantity,
+		OnHand:     i.OnHand,
+		OccurredAt: time.Now(),
+	})
+
+	return nil
+}
+
+// TableName returns the table name for GORM
+func (i *InventoryItem) TableName() string {
+	return "inventory_items"
+}
+
+// ReservationStatus represents stock reservation status value object
+type ReservationStatus string
+
+const (
+	ReservationStatusActive    ReservationStatus = "reserved"
+	ReservationStatusCommitted ReservationStatus = "committed"
+	ReservationStatusReleased  ReservationStatus = "released"
+)
+
+// StockReservation represents stock held for an order (child entity of InventoryItem aggregate)
+type StockReservation struct {
+	BaseEntity                   // Embedded base entity with soft delete
+	OrderID    uuid.UUID         `json:"order_id" gorm:"type:uuid;not null;index"`
+	ProductID  uuid.UUID         `json:"product_id" gorm:"type:uuid;not null;index"`
+	Quantity   int               `json:"quantity" gorm:"not null"`
+	Status     ReservationStatus `json:"status" gorm:"type:varchar(20);not null;default:'reserved'"`
+}
+
+// NewStockReservation creates a new active stock reservation
+func NewStockReservation(orderID, productID uuid.UUID, quantity int) *StockReservation {
+	return &StockReservation{
+		BaseEntity: BaseEntity{
+			ID:        uuid.New(),
+			CreatedAt: time.Now(),
+			UpdatedAt: time.Now(),
+		},
+		OrderID:   orderID,
+		ProductID: productID,
+		Quantity:  quantity,
+		Status:    ReservationStatusActive,
+	}
+}
+
+// markAs changes the reservation status
+func (r *StockReservation) markAs(status ReservationStatus) {
+	r.Status = status
+	r.UpdatedAt = time.Now()
+}
+
+// TableName returns the table name for GORM
+func (r *StockReservation) TableName() string {
+	return "stock_reservations"
+}
@@ -0,0 +1,134 @@
+package entities
+
+import (
+	"fmt"
+	"goclean/internal/domain"
+	"math"
+	"strings"
+)
+
+// DefaultCurrency is the currency used when none is specified
+const DefaultCurrency = "USD"
+
+var (
+	ErrCurrencyMismatch = domain.NewValidation("currency_mismatch", "currency mismatch")
+	ErrInvalidCurrency  = domain.NewValidation("invalid_currency", "invalid currency code")
+	ErrMoneyOverflow    = domain.NewValidation("money_overflow", "money amount overflow")
+)
+
+// currencyExponents lists ISO 4217 currencies whose minor unit is not 1/100
+var currencyExponents = map[string]int{
+	"BHD": 3, "CLP": 0, "IQD": 3, "ISK": 0, "JOD": 3, "JPY": 0, "KRW": 0,
+	"KWD": 3, "LYD": 3, "OMR": 3, "PYG": 0, "TND": 3, "UGX": 0, "VND": 0,
+}
+
+// Money represents a monetary value object stored as integer minor units of an ISO 4217 currency.
+// When embedded in an entity it maps to <prefix>amount and <prefix>currency columns.
+type Money struct {
+	Amount   int64  `json:"amount" gorm:"not null;default:0"`
+	Currency string `json:"currency" gorm:"type:char(3);not null;default:'USD'"`
+}
+
+// NewMoney creates a new money value from minor units and a currency code
+func NewMoney(amount int64, currency string) (Money, error) {
+	currency = strings.ToUpper(strings.TrimSpace(currency))
+	if !IsValidCurrency(currency) {
+		return Money{}, fmt.Errorf("%w: %q", ErrInvalidCurrency, currency)
+	}
+	return Money{Amount: amount, Currency: currency}, nil
+}
+
+// ZeroMoney returns a zero amount in the given currency
+func ZeroMoney(currency string) Money {
+	return Money{Currency: strings.ToUpper(currency)}
+}
+
+// IsValidCurrency checks if the code looks like an ISO 4217 currency code
+func IsValidCurrency(currency string) bool {
+	if len(currency) != 3 {
+		return false
+	}
+	for _, r := range currency {
+		if r < 'A' || r > 'Z' {
+			return false
+		}
+	}
+	return true
+}
+
+// CurrencyExponent returns the number of minor unit digits of the currency
+func CurrencyExponent(currency string) int {
+	if exponent, ok := currencyExponents[currency]; ok {
+		return exponent
+	}
+	return 2
+}
+
+// Add returns the sum of two amounts in the same currency
+func (m Money) Add(other Money) (Money, error) {
+	if m.Currency != other.Currency {
+		return Money{}, fmt.Errorf("%w: %s and %s", ErrCurrencyMismatch, m.Currency, other.Currency)
+	}
+	sum := m.Amount + other.Amount
+	if (other.Amount > 0 && sum < m.Amount) || (other.Amount < 0 && sum > m.Amount) {
+		return Money{}, ErrMoneyOverflow
+	}
+	return Money{Amount: sum, Currency: m.Currency}, nil
+}
+
+// Sub returns the difference of two amounts in the same currency
+func (m Money) Sub(other Money) (Money, error) {
+	if other.Amount == math.MinInt64 {
+		return Money{}, ErrMoneyOverflow
+	}
+	return m.Add(Money{Amount: -other.Amount, Currency: other.Currency})
+}
+
+// Multiply returns the amount multiplied by a quantity
+func (m Money) Multiply(quantity int64) (Money, error) {
+	if quantity != 0 && m.Amount != 0 {
+		product := m.Amount * quantity
+		if product/quantity != m.Amount {
+			return Money{}, ErrMoneyOverflow
+		}
+		return Money{Amount: product, Currency: m.Currency}, nil
+	}
+	return Money{Currency: m.Currency}, nil
+}
+
+// IsZero checks if the amount is zero
+func (m Money) IsZero() bool {
+	return m.Amount == 0
+}
+
+// IsPositive checks if the amount is greater than zero
+func (m Money) IsPositive() bool {
+	return m.Amount > 0
+}
+
+// IsNegative checks if the amount is less than zero
+func (m Money) IsNegative() bool {
+	return m.Amount < 0
+}
+
+// Equals checks if two money values have the same amount and currency
+func (m Money) Equals(other Money) bool {
+	return m.Amount == other.Amount && m.Currency == other.Currency
+}
+
+// String formats the amount in major units followed by the currency code, e.g. "19.99 USD"
+func (m Money) String() string {
+	exponent := CurrencyExponent(m.Currency)
+	if exponent == 0 {
+		return fmt.Sprintf("%d %s", m.Amount, m.Currency)
+	}
+
+	sign := ""
+	amount := m.Amount
+	if amount < 0 {
+		sign = "-"
+		amount = -amount
+	}
+	scale := int64(math.Pow10(exponent))
+	return fmt.Sprintf("%s%d.%0*d %s", sign, amount/scale, exponent, amount%scale, m.Currency)
+}
@@ -0,0 +1,70 @@
+package domain
+
+import "strings"
+
+// Kind classifies domain errors so that adapters can map them to transport status codes
+// without knowing every individual error. Kinds are errors themselves, so
+// errors.Is(err, domain.ErrNotFound) matches every not-found error.
+type Kind string
+
+const (
+	// ErrNotFound means the requested aggregate does not exist
+	ErrNotFound Kind = "not_found"
+	// ErrConflict means the change clashes with existing data, e.g. a duplicate email
+	ErrConflict Kind = "conflict"
+	// ErrValidation means the input breaks a business rule
+	ErrValidation Kind = "validation"
+	// ErrForbidden means the caller may not perform the operation
+	ErrForbidden Kind = "forbidden"
+	// ErrInvalidStateTransition means the aggregate's current state does not allow the operation
+	ErrInvalidStateTransition Kind = "invalid_state_transition"
+)
+
+// Error implements error
+func (k Kind) Error() string {
+	return strings.ReplaceAll(string(k), "_", " ")
+}
+
+// Error is a domain error with a stable, machine readable code such as "user_not_found".
+// Codes are part of the API contract: clients switch on them, so never rename one.
+type Error struct {
+	Kind    Kind
+	Code    string
+	Message string
+}
+
+// Error implements error
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// Is reports whether target is the kind of this error
+func (e *Error) Is(target error) bool {
+	kind, ok := target.(Kind)
+	return ok && kind == e.Kind
+}
+
+// NewNotFound creates a not-found error
+func NewNotFound(code, message string) *Error {
+	return &Error{Kind: ErrNotFound, Code: code, Message: message}
+}
+
+// NewConflict creates a conflict error
+func NewConflict(code, message string) *Error {
+	return &Error{Kind: ErrConflict, Code: code, Message: message}
+}
+
+// NewValidation creates a validation error
+func NewValidation(code, message string) *Error {
+	return &Error{Kind: ErrValidation, Code: code, Message: message}
+}
+
+// NewForbidden creates a forbidden error
+func NewForbidden(code, message string) *Error {
+	return &Error{Kind: ErrForbidden, Code: code, Message: message}
+}
+
+// NewInvalidStateTransition creates an invalid state transition error
+func NewInvalidStateTransition(code, message string) *Error {
+	return &Error{Kind: ErrInvalidStateTransition, Code: code, Message: message}
+}
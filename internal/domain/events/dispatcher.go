@@ -42,6 +42,18 @@ func (d *DomainEventDispatcher) DispatchEvents(ctx context.Context, aggregateRoo
 		return nil
 	}
 
+	if err := d.Publish(ctx, events); err != nil {
+		return err
+	}
+
+	// Clear events after successful dispatch
+	aggregateRoot.ClearDomainEvents()
+	return nil
+}
+
+// Publish handles events locally and forwards them to the external publisher.
+// This lets the dispatcher act as the DomainEventPublisher of the outbox relay.
+func (d *DomainEventDispatcher) Publish(ctx context.Context, events []entities.DomainEvent) error {
 	// Handle events locally first
 	for _, event := range events {
 		for _, handler := range d.handlers {
@@ -60,7 +72,5 @@ func (d *DomainEventDispatcher) DispatchEvents(ctx context.Context, aggregateRoo
 		}
 	}
 
-	// Clear events after successful dispatch
-	aggregateRoot.ClearDomainEvents()
 	return nil
 }
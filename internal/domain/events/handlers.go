@@ -123,3 +123,39 @@ func (h *ProductCreatedEventHandler) CanHandle(event entities.DomainEvent) bool
 	_, ok := event.(entities.ProductCreatedEvent)
 	return ok
 }
+
+// StockDepletedEventHandler handles stock depleted events
+type StockDepletedEventHandler struct {
+	logger *logger.Logger
+}
+
+// NewStockDepletedEventHandler creates a new stock depleted event handler
+func NewStockDepletedEventHandler(logger *logger.Logger) *StockDepletedEventHandler {
+	return &StockDepletedEventHandler{
+		logger: logger,
+	}
+}
+
+// Handle handles the stock depleted event
+func (h *StockDepletedEventHandler) Handle(ctx context.Context, event entities.DomainEvent) error {
+	stockDepletedEvent, ok := event.(entities.StockDepletedEvent)
+	if !ok {
+		return nil
+	}
+
+	h.logger.Warn("Product is out of stock",
+		"product_id", stockDepletedEvent.ProductID,
+	)
+
+	// Here you can add additional logic like:
+	// - Notify purchasing to reorder
+	// - Hide the product from listings
+
+	return nil
+}
+
+// CanHandle checks if this handler can handle the event
+func (h *StockDepletedEventHandler) CanHandle(event entities.DomainEvent) bool {
+	_, ok := event.(entities.StockDepletedEvent)
+	return ok
+}
@@ -0,0 +1,75 @@
+package events
+
+import (
+	"encoding/json"
+	"fmt"
+	"goclean/internal/domain/entities"
+)
+
+// EventDecoder rebuilds a domain event from its serialized payload
+type EventDecoder func(payload []byte) (entities.DomainEvent, error)
+
+// EventRegistry maps event types to decoders so stored events can be restored
+type EventRegistry struct {
+	decoders map[string]EventDecoder
+}
+
+// NewEventRegistry creates an empty event registry
+func NewEventRegistry() *EventRegistry {
+	return &EventRegistry{
+		decoders: make(map[string]EventDecoder),
+	}
+}
+
+// RegisterEvent registers the concrete event type T under its EventType name
+func RegisterEvent[T entities.DomainEvent](r *EventRegistry) {
+	var zero T
+	r.decoders[zero.EventType()] = func(payload []byte) (entities.DomainEvent, error) {
+		var event T
+		if err := json.Unmarshal(payload, &event); err != nil {
+			return nil, err
+		}
+		return event, nil
+	}
+}
+
+// Decode restores a domain event of the given type from its JSON payload
+func (r *EventRegistry) Decode(eventType string, payload []byte) (entities.DomainEvent, error) {
+	decoder, ok := r.decoders[eventType]
+	if !ok {
+		return nil, fmt.Errorf("unknown event type: %s", eventType)
+	}
+	return decoder(payload)
+}
+
+// NewDefaultEventRegistry creates a registry with all domain events registered
+func NewDefaultEventRegistry() *EventRegistry {
+	r := NewEventRegistry()
+	RegisterEvent[entities.UserCreatedEvent](r)
+	RegisterEvent[entities.UserDeletedEvent](r)
+	RegisterEvent[entities.UserRestoredEvent](r)
+	RegisterEvent[entities.UserPurgedEvent](r)
+	RegisterEvent[entities.UserDataExportedEvent](r)
+	RegisterEvent[entities.UserErasedEvent](r)
+	RegisterEvent[entities.ProfileDeletedEvent](r)
+	RegisterEvent[entities.ProfileRestoredEvent](r)
+	RegisterEvent[entities.ProfilePurgedEvent](r)
+	RegisterEvent[entities.ProductCreatedEvent](r)
+	RegisterEvent[entities.ProductDeletedEvent](r)
+	RegisterEvent[entities.ProductRestoredEvent](r)
+	RegisterEvent[entities.ProductPurgedEvent](r)
+	RegisterEvent[entities.OrderCreatedEvent](r)
+	RegisterEvent[entities.OrderConfirmedEvent](r)
+	RegisterEvent[entities.OrderShippedEvent](r)
+	RegisterEvent[entities.OrderDeliveredEvent](r)
+	RegisterEvent[entities.OrderCancelledEvent](r)
+	RegisterEvent[entities.OrderDeletedEvent](r)
+	RegisterEvent[entities.OrderRestoredEvent](r)
+	RegisterEvent[entities.OrderPurgedEvent](r)
+	RegisterEvent[entities.StockReservedEvent](r)
+	RegisterEvent[entities.StockDepletedEvent](r)
+	RegisterEvent[entities.StockCommittedEvent](r)
+	RegisterEvent[entities.StockReleasedEvent](r)
+	RegisterEvent[entities.StockRestockedEvent](r)
+	return r
+}
@@ -2,11 +2,42 @@ package repositories
 
 import (
 	"context"
+	"errors"
+	"goclean/internal/domain"
 	"goclean/internal/domain/entities"
+	"time"
 
 	"github.com/google/uuid"
 )
 
+var (
+	// ErrNotFound is returned when no record matches the lookup
+	ErrNotFound = domain.NewNotFound("record_not_found", "record not found")
+	// ErrDuplicate is returned when a write violates a unique constraint
+	ErrDuplicate = domain.NewConflict("duplicate_record", "record already exists")
+	// ErrConcurrentModification is returned when an update loses an optimistic concurrency check
+	ErrConcurrentModification = domain.NewConflict("concurrent_modification", "record was modified concurrently")
+)
+
+// NotFoundAs replaces ErrNotFound with the aggregate's own not-found error, such as
+// services.ErrUserNotFound. Other failures, e.g. a lost connection, are returned unchanged.
+func NotFoundAs(err error, aggregateErr error) error {
+	if errors.Is(err, ErrNotFound) {
+		return aggregateErr
+	}
+	return err
+}
+
+// SoftDeleteRepository is the data access of a soft delete lifecycle. Every aggregate
+// repository implements it for its entity type.
+type SoftDeleteRepository[T entities.SoftDeletable] interface {
+	GetByIDIncludeDeleted(ctx context.Context, id uuid.UUID) (T, error)
+	Update(ctx context.Context, entity T) error
+	Delete(ctx context.Context, id uuid.UUID) error // Hard delete
+	ListDeleted(ctx context.Context, offset, limit int) ([]T, error)
+	ListDeletedBefore(ctx context.Context, before time.Time, offset, limit int) ([]T, error) // Oldest deletions first
+}
+
 // UserRepository defines the interface for user data access
 type UserRepository interface {
 	Create(ctx context.Context, user *entities.User) error
@@ -21,6 +52,7 @@ type UserRepository interface {
 	List(ctx context.Context, offset, limit int) ([]*entities.User, error)
 	ListIncludeDeleted(ctx context.Context, offset, limit int) ([]*entities.User, error)
 	ListDeleted(ctx context.Context, offset, limit int) ([]*entities.User, error)
+	ListDeletedBefore(ctx context.Context, before time.Time, offset, limit int) ([]*entities.User, error)
 }
 
 // ProductRepository defines the interface for product data access
@@ -36,6 +68,7 @@ type ProductRepository interface {
 	List(ctx context.Context, offset, limit int) ([]*entities.Product, error)
 	ListIncludeDeleted(ctx context.Context, offset, limit int) ([]*entities.Product, error)
 	ListDeleted(ctx context.Context, offset, limit int) ([]*entities.Product, error)
+	ListDeletedBefore(ctx context.Context, before time.Time, offset, limit int) ([]*entities.Product, error)
 	ListByCategory(ctx context.Context, category string, offset, limit int) ([]*entities.Product, error)
 	Search(ctx context.Context, query string, offset, limit int) ([]*entities.Product, error)
 }
@@ -46,6 +79,8 @@ type OrderRepository interface {
 	GetByID(ctx context.Context, id uuid.UUID) (*entities.Order, error)
 	GetByIDIncludeDeleted(ctx context.Context, id uuid.UUID) (*entities.Order, error)
 	GetByUserID(ctx context.Context, userID uuid.UUID, offset, limit int) ([]*entities.Order, error)
+	GetByUserIDIncludeDeleted(ctx context.Context, userID uuid.UUID, offset, limit int) ([]*entities.Order, error)
+	ListStatusChangesBy(ctx context.Context, userID uuid.UUID, offset, limit int) ([]*entities.OrderStatusHistory, error) // Status changes the user made, oldest first
 	Update(ctx context.Context, order *entities.Order) error
 	Delete(ctx context.Context, id uuid.UUID) error     // Hard delete
 	SoftDelete(ctx context.Context, id uuid.UUID) error // Soft delete
@@ -53,6 +88,7 @@ type OrderRepository interface {
 	List(ctx context.Context, offset, limit int) ([]*entities.Order, error)
 	ListIncludeDeleted(ctx context.Context, offset, limit int) ([]*entities.Order, error)
 	ListDeleted(ctx context.Context, offset, limit int) ([]*entities.Order, error)
+	ListDeletedBefore(ctx context.Context, before time.Time, offset, limit int) ([]*entities.Order, error)
 	UpdateStatus(ctx context.Context, id uuid.UUID, status entities.OrderStatus) error
 }
 
@@ -66,4 +102,32 @@ type ProfileRepository interface {
 	Delete(ctx context.Context, id uuid.UUID) error     // Hard delete
 	SoftDelete(ctx context.Context, id uuid.UUID) error // Soft delete
 	Restore(ctx context.Context, id uuid.UUID) error    // Restore soft deleted
+	ListDeleted(ctx context.Context, offset, limit int) ([]*entities.Profile, error)
+	ListDeletedBefore(ctx context.Context, before time.Time, offset, limit int) ([]*entities.Profile, error)
+}
+
+// InventoryRepository defines the interface for inventory data access
+type InventoryRepository interface {
+	Create(ctx context.Context, item *entities.InventoryItem) error
+	GetByProductID(ctx context.Context, productID uuid.UUID) (*entities.InventoryItem, error)
+	GetByProductIDForUpdate(ctx context.Context, productID uuid.UUID) (*entities.InventoryItem, error) // Locks the row until the transaction ends
+	Update(ctx context.Context, item *entities.InventoryItem) error                                    // Fails with ErrConcurrentModification on a stale version
+}
+
+// StockReservationRepository defines the interface for stock reservation data access
+type StockReservationRepository interface {
+	Create(ctx context.Context, reservation *entities.StockReservation) error
+	GetActiveByOrderID(ctx context.Context, orderID uuid.UUID) ([]*entities.StockReservation, error)
+	Update(ctx context.Context, reservation *entities.StockReservation) error
+}
+
+// APIKeyRepository defines the interface for API key data access
+type APIKeyRepository interface {
+	Create(ctx context.Context, key *entities.APIKey) error
+	GetByID(ctx context.Context, id uuid.UUID) (*entities.APIKey, error)
+	GetByKeyID(ctx context.Context, keyID string) (*entities.APIKey, error)
+	Update(ctx context.Context, key *entities.APIKey) error
+	UpdateLastUsed(ctx context.Context, id uuid.UUID, usedAt time.Time) error // Touches only last_used_at so it cannot undo a concurrent revoke
+	List(ctx context.Context, offset, limit int) ([]*entities.APIKey, error)
+	ListByOwnerID(ctx context.Context, ownerID uuid.UUID, offset, limit int) ([]*entities.APIKey, error)
 }
@@ -0,0 +1,11 @@
+package repositories
+
+import "context"
+
+// UnitOfWork runs repository calls inside a single database transaction.
+// The transaction is carried through the context passed to fn, so every
+// repository call made with that context joins it. The transaction commits
+// when fn returns nil and rolls back when it returns an error.
+type UnitOfWork interface {
+	Do(ctx context.Context, fn func(ctx context.Context) error) error
+}
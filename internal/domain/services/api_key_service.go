@@ -0,0 +1,137 @@
+package services
+
+import (
+	"context"
+	"goclean/internal/domain"
+	"goclean/internal/domain/entities"
+	"goclean/internal/domain/repositories"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ErrAPIKeyNotFound is returned when an API key does not exist
+var ErrAPIKeyNotFound = domain.NewNotFound("api_key_not_found", "API key not found")
+
+// apiKeyLastUsedResolution limits how often authentication writes last_used_at
+const apiKeyLastUsedResolution = time.Minute
+
+// APIKeyDomainService contains business logic for API keys
+type APIKeyDomainService struct {
+	apiKeyRepo repositories.APIKeyRepository
+	userRepo   repositories.UserRepository
+}
+
+// NewAPIKeyDomainService creates a new API key domain service
+func NewAPIKeyDomainService(apiKeyRepo repositories.APIKeyRepository, userRepo repositories.UserRepository) *APIKeyDomainService {
+	return &APIKeyDomainService{
+		apiKeyRepo: apiKeyRepo,
+		userRepo:   userRepo,
+	}
+}
+
+// IssueAPIKey creates a key acting as ownerID and returns it with the plaintext key,
+// which is not stored and cannot be retrieved again
+func (s *APIKeyDomainService) IssueAPIKey(
+	ctx context.Context,
+	name string,
+	ownerID uuid.UUID,
+	scopes []string,
+	expiresAt *time.Time,
+	createdBy uuid.UUID,
+) (*entities.APIKey, string, error) {
+	if _, err := s.userRepo.GetByID(ctx, ownerID); err != nil {
+		return nil, "", repositories.NotFoundAs(err, ErrUserNotFound)
+	}
+
+	key, plaintext, err := entities.NewAPIKey(name, ownerID, scopes, expiresAt, createdBy)
+	if err != nil {
+		return nil, "", err
+	}
+	if err := s.apiKeyRepo.Create(ctx, key); err != nil {
+		return nil, "", err
+	}
+	return key, plaintext, nil
+}
+
+// RevokeAPIKey revokes a key; revoking an already revoked key succeeds
+func (s *APIKeyDomainService) RevokeAPIKey(ctx context.Context, id uuid.UUID) error {
+	key, err := s.apiKeyRepo.GetByID(ctx, id)
+	if err != nil {
+		return repositories.NotFoundAs(err, ErrAPIKeyNotFound)
+	}
+	if key.RevokedAt != nil {
+		return nil
+	}
+
+	key.Revoke()
+	return s.apiKeyRepo.Update(ctx, key)
+}
+
+// RevokeOwnerAPIKeys revokes every active key acting as ownerID
+func (s *APIKeyDomainService) RevokeOwnerAPIKeys(ctx context.Context, ownerID uuid.UUID) error {
+	return revokeOwnerAPIKeys(ctx, s.apiKeyRepo, ownerID)
+}
+
+// revokeOwnerAPIKeys revokes the active keys of an owner; already revoked keys are kept as they are
+func revokeOwnerAPIKeys(ctx context.Context, apiKeyRepo repositories.APIKeyRepository, ownerID uuid.UUID) error {
+	keys, err := collectPages(func(offset, limit int) ([]*entities.APIKey, error) {
+		return apiKeyRepo.ListByOwnerID(ctx, ownerID, offset, limit)
+	})
+	if err != nil {
+		return err
+	}
+	for _, key := range keys {
+		if key.RevokedAt != nil {
+			continue
+		}
+		key.Revoke()
+		if err := apiKeyRepo.Update(ctx, key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ListAPIKeys lists keys, optionally restricted to one owner
+func (s *APIKeyDomainService) ListAPIKeys(ctx context.Context, ownerID *uuid.UUID, offset, limit int) ([]*entities.APIKey, error) {
+	if ownerID != nil {
+		return s.apiKeyRepo.ListByOwnerID(ctx, *ownerID, offset, limit)
+	}
+	return s.apiKeyRepo.List(ctx, offset, limit)
+}
+
+// Authenticate resolves a plaintext key to an active API key of an active owner. Every failure
+// returns entities.ErrInvalidAPIKey so callers cannot tell unknown, revoked and expired keys
+// or deleted, deactivated and erased owners apart.
+func (s *APIKeyDomainService) Authenticate(ctx context.Context, plaintext string) (*entities.APIKey, error) {
+	keyID, secret, err := entities.ParseAPIKey(plaintext)
+	if err != nil {
+		return nil, entities.ErrInvalidAPIKey
+	}
+
+	key, err := s.apiKeyRepo.GetByKeyID(ctx, keyID)
+	if err != nil {
+		return nil, entities.ErrInvalidAPIKey
+	}
+
+	now := time.Now()
+	if !key.VerifySecret(secret) || !key.IsActive(now) {
+		return nil, entities.ErrInvalidAPIKey
+	}
+
+	// Deleted and purged owners are not found
+	owner, err := s.userRepo.GetByID(ctx, key.OwnerID)
+	if err != nil || !owner.IsActive || owner.IsErased() {
+		return nil, entities.ErrInvalidAPIKey
+	}
+
+	// Recording usage is best effort and throttled so busy keys do not write on every request
+	if key.LastUsedAt == nil || now.Sub(*key.LastUsedAt) >= apiKeyLastUsedResolution {
+		if err := s.apiKeyRepo.UpdateLastUsed(ctx, key.ID, now); err == nil {
+			key.LastUsedAt = &now
+		}
+	}
+
+	return key, nil
+}
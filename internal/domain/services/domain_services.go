@@ -3,6 +3,7 @@ package services
 import (
 	"context"
 	"errors"
+	"goclean/internal/domain"
 	"goclean/internal/domain/entities"
 	"goclean/internal/domain/repositories"
 
@@ -10,153 +11,432 @@ import (
 )
 
 var (
-	ErrUserNotFound       = errors.New("user not found")
-	ErrUserAlreadyExists  = errors.New("user already exists")
-	ErrProductNotFound    = errors.New("product not found")
-	ErrOrderNotFound      = errors.New("order not found")
-	ErrInvalidOrderStatus = errors.New("invalid order status")
+	ErrUserNotFound       = domain.NewNotFound("user_not_found", "user not found")
+	ErrUserAlreadyExists  = domain.NewConflict("user_already_exists", "user already exists")
+	ErrUserDeleted        = domain.NewNotFound("user_deleted", "user has been deleted")
+	ErrEmailTaken         = domain.NewConflict("email_taken", "email already exists")
+	ErrUsernameTaken      = domain.NewConflict("username_taken", "username already exists")
+	ErrProductNotFound    = domain.NewNotFound("product_not_found", "product not found")
+	ErrProductSKUExists   = domain.NewConflict("product_sku_exists", "product with this SKU already exists")
+	ErrOrderNotFound      = domain.NewNotFound("order_not_found", "order not found")
+	ErrInvalidOrderStatus = domain.NewValidation("invalid_order_status", "invalid order status")
+
+	ErrInvalidProductPrice = domain.NewValidation("invalid_product_price", "product price must be greater than zero")
+	ErrProductNameRequired = domain.NewValidation("product_name_required", "product name is required")
+	ErrProductSKURequired  = domain.NewValidation("product_sku_required", "product SKU is required")
+	ErrNegativeStock       = domain.NewValidation("negative_stock", "initial stock cannot be negative")
+	ErrOrderWithoutItems   = domain.NewValidation("order_without_items", "order must have at least one item")
+	ErrInvalidQuantity     = domain.NewValidation("invalid_quantity", "item quantity must be greater than zero")
 )
 
 // UserDomainService contains business logic for users
 type UserDomainService struct {
+	uow         repositories.UnitOfWork
 	userRepo    repositories.UserRepository
 	profileRepo repositories.ProfileRepository
 }
 
 // NewUserDomainService creates a new user domain service
-func NewUserDomainService(userRepo repositories.UserRepository, profileRepo repositories.ProfileRepository) *UserDomainService {
+func NewUserDomainService(uow repositories.UnitOfWork, userRepo repositories.UserRepository, profileRepo repositories.ProfileRepository) *UserDomainService {
 	return &UserDomainService{
+		uow:         uow,
 		userRepo:    userRepo,
 		profileRepo: profileRepo,
 	}
 }
 
-// CreateUserWithProfile creates a user and their profile
+// CreateUserWithProfile creates a user and their profile in a single transaction
 func (s *UserDomainService) CreateUserWithProfile(ctx context.Context, user *entities.User, profile *entities.Profile) error {
-	// Check if user already exists
-	existingUser, _ := s.userRepo.GetByEmail(ctx, user.Email)
-	if existingUser != nil {
-		return ErrUserAlreadyExists
+	return s.uow.Do(ctx, func(ctx context.Context) error {
+		// Check if user already exists
+		existingUser, _ := s.userRepo.GetByEmail(ctx, user.Email)
+		if existingUser != nil {
+			return ErrUserAlreadyExists
+		}
+
+		// Create user
+		if err := s.userRepo.Create(ctx, user); err != nil {
+			return err
+		}
+
+		// Create profile if provided; a failure rolls back the user as well
+		if profile != nil {
+			profile.UserID = user.ID
+			if err := s.profileRepo.Create(ctx, profile); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// ProvisionUser creates or updates the local user for an identity provider subject.
+// A new user raises UserCreatedEvent; an existing one has its identity fields synced.
+// Deleted users are not recreated.
+func (s *UserDomainService) ProvisionUser(ctx context.Context, id uuid.UUID, email, username, firstName, lastName string) (*entities.User, error) {
+	var provisioned *entities.User
+	err := s.uow.Do(ctx, func(ctx context.Context) error {
+		existingUser, err := s.userRepo.GetByIDIncludeDeleted(ctx, id)
+		if err != nil && !errors.Is(err, repositories.ErrNotFound) {
+			return err
+		}
+		if existingUser != nil {
+			// Syncing an erased user would bring back the personal data taken from the token claims
+			if err := accountClosedError(existingUser); err != nil {
+				return err
+			}
+			provisioned = existingUser
+			if !existingUser.SyncIdentity(email, username, firstName, lastName) {
+				return nil
+			}
+			return s.userRepo.Update(ctx, existingUser)
+		}
+
+		// Email and username belong to a different local user, e.g. one registered through the API
+		if conflicting, _ := s.userRepo.GetByEmail(ctx, email); conflicting != nil {
+			return ErrUserAlreadyExists
+		}
+		if conflicting, _ := s.userRepo.GetByUsername(ctx, username); conflicting != nil {
+			return ErrUserAlreadyExists
+		}
+
+		user := entities.NewUserWithID(id, email, username, firstName, lastName)
+		if err := s.userRepo.Create(ctx, user); err != nil {
+			return err
+		}
+		provisioned = user
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
+	return provisioned, nil
+}
 
-	// Create user
-	if err := s.userRepo.Create(ctx, user); err != nil {
-		return err
+// CheckAccountOpen returns ErrUserDeleted or entities.ErrUserErased if the user's account
+// has been closed, and ErrUserNotFound if the user does not exist
+func (s *UserDomainService) CheckAccountOpen(ctx context.Context, id uuid.UUID) error {
+	user, err := s.userRepo.GetByIDIncludeDeleted(ctx, id)
+	if err != nil {
+		return repositories.NotFoundAs(err, ErrUserNotFound)
 	}
+	return accountClosedError(user)
+}
 
-	// Create profile if provided
-	if profile != nil {
-		profile.UserID = user.ID
-		if err := s.profileRepo.Create(ctx, profile); err != nil {
-			// Rollback user creation if profile creation fails
-			s.userRepo.Delete(ctx, user.ID)
+// accountClosedError returns the error for a deleted or erased user, or nil for an open account
+func accountClosedError(user *entities.User) error {
+	switch {
+	case user.IsDeleted():
+		return ErrUserDeleted
+	case user.IsErased():
+		return entities.ErrUserErased
+	default:
+		return nil
+	}
+}
+
+// UpdateUser applies a partial update to a user. Email and username must stay unique.
+func (s *UserDomainService) UpdateUser(ctx context.Context, userID uuid.UUID, changes entities.UserChanges) (*entities.User, error) {
+	var user *entities.User
+	err := s.uow.Do(ctx, func(ctx context.Context) error {
+		var err error
+		user, err = s.getActiveUser(ctx, userID)
+		if err != nil {
 			return err
 		}
+		if user.IsErased() {
+			return entities.ErrUserErased
+		}
+
+		if changes.Email != nil && *changes.Email != user.Email {
+			if conflicting, _ := s.userRepo.GetByEmail(ctx, *changes.Email); conflicting != nil {
+				return ErrEmailTaken
+			}
+		}
+		if changes.Username != nil && *changes.Username != user.Username {
+			if conflicting, _ := s.userRepo.GetByUsername(ctx, *changes.Username); conflicting != nil {
+				return ErrUsernameTaken
+			}
+		}
+
+		if !user.Update(changes) {
+			return nil
+		}
+		return s.userRepo.Update(ctx, user)
+	})
+	if err != nil {
+		return nil, err
 	}
+	return user, nil
+}
 
-	return nil
+// getActiveUser loads a user that has not been deleted
+func (s *UserDomainService) getActiveUser(ctx context.Context, userID uuid.UUID) (*entities.User, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, repositories.NotFoundAs(err, ErrUserNotFound)
+	}
+	if user.IsDeleted() {
+		return nil, ErrUserDeleted
+	}
+	return user, nil
 }
 
 // ProductDomainService contains business logic for products
 type ProductDomainService struct {
-	productRepo repositories.ProductRepository
+	uow           repositories.UnitOfWork
+	productRepo   repositories.ProductRepository
+	inventoryRepo repositories.InventoryRepository
 }
 
 // NewProductDomainService creates a new product domain service
-func NewProductDomainService(productRepo repositories.ProductRepository) *ProductDomainService {
+func NewProductDomainService(uow repositories.UnitOfWork, productRepo repositories.ProductRepository, inventoryRepo repositories.InventoryRepository) *ProductDomainService {
 	return &ProductDomainService{
-		productRepo: productRepo,
+		uow:           uow,
+		productRepo:   productRepo,
+		inventoryRepo: inventoryRepo,
 	}
 }
 
 // ValidateProduct validates product business rules
 func (s *ProductDomainService) ValidateProduct(product *entities.Product) error {
-	if product.Price <= 0 {
-		return errors.New("product price must be greater than zero")
+	if !entities.IsValidCurrency(product.Price.Currency) {
+		return entities.ErrInvalidCurrency
+	}
+	if !product.Price.IsPositive() {
+		return ErrInvalidProductPrice
 	}
 	if product.Name == "" {
-		return errors.New("product name is required")
+		return ErrProductNameRequired
 	}
 	if product.SKU == "" {
-		return errors.New("product SKU is required")
+		return ErrProductSKURequired
 	}
 	return nil
 }
 
-// CreateProduct creates a product after validation
-func (s *ProductDomainService) CreateProduct(ctx context.Context, product *entities.Product) error {
+// CreateProduct creates a product and its inventory record after validation
+func (s *ProductDomainService) CreateProduct(ctx context.Context, product *entities.Product, initialStock int) error {
 	if err := s.ValidateProduct(product); err != nil {
 		return err
 	}
+	if initialStock < 0 {
+		return ErrNegativeStock
+	}
+
+	return s.uow.Do(ctx, func(ctx context.Context) error {
+		// Check if SKU already exists
+		existingProduct, _ := s.productRepo.GetBySKU(ctx, product.SKU)
+		if existingProduct != nil {
+			return ErrProductSKUExists
+		}
+
+		if err := s.productRepo.Create(ctx, product); err != nil {
+			return err
+		}
+
+		return s.inventoryRepo.Create(ctx, entities.NewInventoryItem(product.ID, initialStock))
+	})
+}
+
+// UpdateProduct applies a partial update to a product. The SKU must stay unique.
+func (s *ProductDomainService) UpdateProduct(ctx context.Context, productID uuid.UUID, changes entities.ProductChanges) (*entities.Product, error) {
+	var product *entities.Product
+	err := s.uow.Do(ctx, func(ctx context.Context) error {
+		var err error
+		product, err = s.GetProduct(ctx, productID)
+		if err != nil {
+			return err
+		}
+
+		if changes.SKU != nil && *changes.SKU != product.SKU {
+			if conflicting, _ := s.productRepo.GetBySKU(ctx, *changes.SKU); conflicting != nil {
+				return ErrProductSKUExists
+			}
+		}
 
-	// Check if SKU already exists
-	existingProduct, _ := s.productRepo.GetBySKU(ctx, product.SKU)
-	if existingProduct != nil {
-		return errors.New("product with this SKU already exists")
+		if !product.Update(changes) {
+			return nil
+		}
+		if err := s.ValidateProduct(product); err != nil {
+			return err
+		}
+		return s.productRepo.Update(ctx, product)
+	})
+	if err != nil {
+		return nil, err
 	}
+	return product, nil
+}
 
-	return s.productRepo.Create(ctx, product)
+// GetProduct retrieves a product that has not been deleted
+func (s *ProductDomainService) GetProduct(ctx context.Context, productID uuid.UUID) (*entities.Product, error) {
+	product, err := s.productRepo.GetByID(ctx, productID)
+	if err != nil {
+		return nil, repositories.NotFoundAs(err, ErrProductNotFound)
+	}
+	if product.IsDeleted() {
+		return nil, ErrProductNotFound
+	}
+	return product, nil
 }
 
 // OrderDomainService contains business logic for orders
 type OrderDomainService struct {
-	orderRepo   repositories.OrderRepository
-	productRepo repositories.ProductRepository
+	uow              repositories.UnitOfWork
+	orderRepo        repositories.OrderRepository
+	productRepo      repositories.ProductRepository
+	userRepo         repositories.UserRepository
+	inventoryService *InventoryDomainService
 }
 
 // NewOrderDomainService creates a new order domain service
-func NewOrderDomainService(orderRepo repositories.OrderRepository, productRepo repositories.ProductRepository) *OrderDomainService {
+func NewOrderDomainService(
+	uow repositories.UnitOfWork,
+	orderRepo repositories.OrderRepository,
+	productRepo repositories.ProductRepository,
+	userRepo repositories.UserRepository,
+	inventoryService *InventoryDomainService,
+) *OrderDomainService {
 	return &OrderDomainService{
-		orderRepo:   orderRepo,
-		productRepo: productRepo,
+		uow:              uow,
+		orderRepo:        orderRepo,
+		productRepo:      productRepo,
+		userRepo:         userRepo,
+		inventoryService: inventoryService,
 	}
 }
 
-// CreateOrder creates an order with business validation
-func (s *OrderDomainService) CreateOrder(ctx context.Context, order *entities.Order) error {
-	if len(order.Items) == 0 {
-		return errors.New("order must have at least one item")
+// CreateOrder prices the items from the catalogue and places a new order with business validation
+func (s *OrderDomainService) CreateOrder(ctx context.Context, userID uuid.UUID, items []entities.OrderItem) (*entities.Order, error) {
+	if len(items) == 0 {
+		return nil, ErrOrderWithoutItems
 	}
 
-	totalPrice := 0.0
-	for _, item := range order.Items {
-		// Validate product exists
-		product, err := s.productRepo.GetByID(ctx, item.ProductID)
+	var order *entities.Order
+	err := s.uow.Do(ctx, func(ctx context.Context) error {
+		// Deleted users are not found; erased users keep their row but may not order
+		user, err := s.userRepo.GetByID(ctx, userID)
+		if err != nil {
+			return repositories.NotFoundAs(err, ErrUserNotFound)
+		}
+		if user.IsErased() {
+			return entities.ErrUserErased
+		}
+
+		for i := range items {
+			item := &items[i]
+
+			// Validate product exists
+			product, err := s.productRepo.GetByID(ctx, item.ProductID)
+			if err != nil {
+				return repositories.NotFoundAs(err, ErrProductNotFound)
+			}
+
+			// Validate quantity
+			if item.Quantity <= 0 {
+				return ErrInvalidQuantity
+			}
+
+			// Set item price from product price
+			item.Price = product.Price
+		}
+
+		// The aggregate sums the totals and rejects mixed currencies
+		order, err = entities.NewOrder(userID, items)
 		if err != nil {
-			return ErrProductNotFound
+			return err
 		}
 
-		// Validate quantity
-		if item.Quantity <= 0 {
-			return errors.New("item quantity must be greater than zero")
+		if err := s.orderRepo.Create(ctx, order); err != nil {
+			return err
 		}
 
-		// Set item price from product price
-		item.Price = product.Price
-		totalPrice += item.Price * float64(item.Quantity)
+		// Hold stock for the order; insufficient stock rolls back the order
+		return s.inventoryService.ReserveForOrder(ctx, order)
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	order.TotalPrice = totalPrice
-	order.Status = entities.OrderStatusPending
-
-	return s.orderRepo.Create(ctx, order)
+	return order, nil
 }
 
-// UpdateOrderStatus updates order status with business validation
-func (s *OrderDomainService) UpdateOrderStatus(ctx context.Context, orderID uuid.UUID, status entities.OrderStatus) error {
+// UpdateOrderStatus moves an order to a new status following the order state machine
+func (s *OrderDomainService) UpdateOrderStatus(ctx context.Context, orderID uuid.UUID, status entities.OrderStatus, changedBy uuid.UUID, reason string) (*entities.Order, error) {
 	if !status.IsValid() {
-		return ErrInvalidOrderStatus
+		return nil, ErrInvalidOrderStatus
 	}
 
+	var order *entities.Order
+	err := s.uow.Do(ctx, func(ctx context.Context) error {
+		var err error
+		order, err = s.orderRepo.GetByID(ctx, orderID)
+		if err != nil {
+			return repositories.NotFoundAs(err, ErrOrderNotFound)
+		}
+
+		// Business rules for status transitions are enforced by the aggregate
+		if err := order.ChangeStatus(status, changedBy, reason); err != nil {
+			return err
+		}
+
+		if err := s.orderRepo.Update(ctx, order); err != nil {
+			return err
+		}
+
+		return s.settleStock(ctx, order)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return order, nil
+}
+
+// GetOrder retrieves an order
+func (s *OrderDomainService) GetOrder(ctx context.Context, orderID uuid.UUID) (*entities.Order, error) {
 	order, err := s.orderRepo.GetByID(ctx, orderID)
 	if err != nil {
-		return ErrOrderNotFound
+		return nil, repositories.NotFoundAs(err, ErrOrderNotFound)
 	}
+	return order, nil
+}
 
-	// Business rules for status transitions
-	if order.Status == entities.OrderStatusDelivered || order.Status == entities.OrderStatusCancelled {
-		return errors.New("cannot change status of delivered or cancelled orders")
+// CancelOrder cancels an order that has not been shipped yet
+func (s *OrderDomainService) CancelOrder(ctx context.Context, orderID uuid.UUID, cancelledBy uuid.UUID, reason string) (*entities.Order, error) {
+	var order *entities.Order
+	err := s.uow.Do(ctx, func(ctx context.Context) error {
+		var err error
+		order, err = s.orderRepo.GetByID(ctx, orderID)
+		if err != nil {
+			return repositories.NotFoundAs(err, ErrOrderNotFound)
+		}
+
+		if err := order.Cancel(cancelledBy, reason); err != nil {
+			return err
+		}
+
+		if err := s.orderRepo.Update(ctx, order); err != nil {
+			return err
+		}
+
+		return s.settleStock(ctx, order)
+	})
+	if err != nil {
+		return nil, err
 	}
+	return order, nil
+}
 
-	return s.orderRepo.UpdateStatus(ctx, orderID, status)
+// settleStock commits the order's reserved stock when it ships and releases it when it is cancelled
+func (s *OrderDomainService) settleStock(ctx context.Context, order *entities.Order) error {
+	switch order.Status {
+	case entities.OrderStatusShipped:
+		return s.inventoryService.CommitForOrder(ctx, order.ID)
+	case entities.OrderStatusCancelled:
+		return s.inventoryService.ReleaseForOrder(ctx, order.ID)
+	default:
+		return nil
+	}
 }
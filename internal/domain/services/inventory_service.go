@@ -0,0 +1,147 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"goclean/internal/domain"
+	"goclean/internal/domain/entities"
+	"goclean/internal/domain/repositories"
+	"sort"
+
+	"github.com/google/uuid"
+)
+
+// ErrInventoryNotFound is returned when a product has no inventory record
+var ErrInventoryNotFound = domain.NewNotFound("inventory_not_found", "inventory not found")
+
+// InventoryDomainService contains business logic for product stock and reservations
+type InventoryDomainService struct {
+	uow             repositories.UnitOfWork
+	inventoryRepo   repositories.InventoryRepository
+	reservationRepo repositories.StockReservationRepository
+}
+
+// NewInventoryDomainService creates a new inventory domain service
+func NewInventoryDomainService(
+	uow repositories.UnitOfWork,
+	inventoryRepo repositories.InventoryRepository,
+	reservationRepo repositories.StockReservationRepository,
+) *InventoryDomainService {
+	return &InventoryDomainService{
+		uow:             uow,
+		inventoryRepo:   inventoryRepo,
+		reservationRepo: reservationRepo,
+	}
+}
+
+// ReserveForOrder reserves stock for every item of an order or fails without reserving anything.
+// Inventory rows are locked in product ID order so concurrent orders cannot deadlock or oversell.
+func (s *InventoryDomainService) ReserveForOrder(ctx context.Context, order *entities.Order) error {
+	quantities := make(map[uuid.UUID]int)
+	for _, item := range order.Items {
+		quantities[item.ProductID] += item.Quantity
+	}
+
+	productIDs := make([]uuid.UUID, 0, len(quantities))
+	for productID := range quantities {
+		productIDs = append(productIDs, productID)
+	}
+	sort.Slice(productIDs, func(i, j int) bool {
+		return productIDs[i].String() < productIDs[j].String()
+	})
+
+	return s.uow.Do(ctx, func(ctx context.Context) error {
+		for _, productID := range productIDs {
+			item, err := s.inventoryRepo.GetByProductIDForUpdate(ctx, productID)
+			if errors.Is(err, repositories.ErrNotFound) {
+				return fmt.Errorf("%w: product %s", entities.ErrInsufficientStock, productID)
+			}
+			if err != nil {
+				return err
+			}
+
+			reservation, err := item.Reserve(order.ID, quantities[productID])
+			if err != nil {
+				return err
+			}
+
+			if err := s.inventoryRepo.Update(ctx, item); err != nil {
+				return err
+			}
+			if err := s.reservationRepo.Create(ctx, reservation); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// CommitForOrder removes the order's reserved stock from the warehouse
+func (s *InventoryDomainService) CommitForOrder(ctx context.Context, orderID uuid.UUID) error {
+	return s.settleReservations(ctx, orderID, (*entities.InventoryItem).Commit)
+}
+
+// ReleaseForOrder returns the order's reserved stock to the available pool
+func (s *InventoryDomainService) ReleaseForOrder(ctx context.Context, orderID uuid.UUID) error {
+	return s.settleReservations(ctx, orderID, (*entities.InventoryItem).Release)
+}
+
+// Restock adds stock for a product and returns its updated inventory record
+func (s *InventoryDomainService) Restock(ctx context.Context, productID uuid.UUID, quantity int) (*entities.InventoryItem, error) {
+	var item *entities.InventoryItem
+	err := s.uow.Do(ctx, func(ctx context.Context) error {
+		var err error
+		item, err = s.inventoryRepo.GetByProductIDForUpdate(ctx, productID)
+		if err != nil {
+			return repositories.NotFoundAs(err, ErrInventoryNotFound)
+		}
+
+		if err := item.Restock(quantity); err != nil {
+			return err
+		}
+		return s.inventoryRepo.Update(ctx, item)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
+// GetStock returns the inventory record of a product
+func (s *InventoryDomainService) GetStock(ctx context.Context, productID uuid.UUID) (*entities.InventoryItem, error) {
+	item, err := s.inventoryRepo.GetByProductID(ctx, productID)
+	if err != nil {
+		return nil, repositories.NotFoundAs(err, ErrInventoryNotFound)
+	}
+	return item, nil
+}
+
+// settleReservations applies fn to every active reservation of an order
+func (s *InventoryDomainService) settleReservations(ctx context.Context, orderID uuid.UUID, fn func(*entities.InventoryItem, *entities.StockReservation) error) error {
+	return s.uow.Do(ctx, func(ctx context.Context) error {
+		reservations, err := s.reservationRepo.GetActiveByOrderID(ctx, orderID)
+		if err != nil {
+			return err
+		}
+
+		for _, reservation := range reservations {
+			item, err := s.inventoryRepo.GetByProductIDForUpdate(ctx, reservation.ProductID)
+			if err != nil {
+				return repositories.NotFoundAs(err, ErrInventoryNotFound)
+			}
+
+			if err := fn(item, reservation); err != nil {
+				return err
+			}
+
+			if err := s.inventoryRepo.Update(ctx, item); err != nil {
+				return err
+			}
+			if err := s.reservationRepo.Update(ctx, reservation); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
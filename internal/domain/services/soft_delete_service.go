@@ -0,0 +1,145 @@
+package services
+
+import (
+	"context"
+	"goclean/internal/domain"
+	"goclean/internal/domain/entities"
+	"goclean/internal/domain/repositories"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// purgeBatchSize is how many deleted entities Purge loads at a time
+const purgeBatchSize = 100
+
+// ErrProfileNotFound is returned when a profile does not exist
+var ErrProfileNotFound = domain.NewNotFound("profile_not_found", "profile not found")
+
+// SoftDeleteService manages the soft delete lifecycle of one entity type: deleting with
+// a reason, restoring, listing and finally purging deleted entities. Changes go through
+// the entity's Delete, Restore and Purge methods, so their domain events are stored
+// with them.
+type SoftDeleteService[T entities.SoftDeletable] struct {
+	uow         repositories.UnitOfWork
+	repo        repositories.SoftDeleteRepository[T]
+	errNotFound error
+}
+
+// NewSoftDeleteService creates a soft delete service; errNotFound is returned for unknown IDs
+func NewSoftDeleteService[T entities.SoftDeletable](uow repositories.UnitOfWork, repo repositories.SoftDeleteRepository[T], errNotFound error) *SoftDeleteService[T] {
+	return &SoftDeleteService[T]{
+		uow:         uow,
+		repo:        repo,
+		errNotFound: errNotFound,
+	}
+}
+
+// NewUserSoftDeleteService creates the soft delete service for users
+func NewUserSoftDeleteService(uow repositories.UnitOfWork, userRepo repositories.UserRepository) *SoftDeleteService[*entities.User] {
+	return NewSoftDeleteService[*entities.User](uow, userRepo, ErrUserNotFound)
+}
+
+// NewProfileSoftDeleteService creates the soft delete service for profiles
+func NewProfileSoftDeleteService(uow repositories.UnitOfWork, profileRepo repositories.ProfileRepository) *SoftDeleteService[*entities.Profile] {
+	return NewSoftDeleteService[*entities.Profile](uow, profileRepo, ErrProfileNotFound)
+}
+
+// NewProductSoftDeleteService creates the soft delete service for products
+func NewProductSoftDeleteService(uow repositories.UnitOfWork, productRepo repositories.ProductRepository) *SoftDeleteService[*entities.Product] {
+	return NewSoftDeleteService[*entities.Product](uow, productRepo, ErrProductNotFound)
+}
+
+// NewOrderSoftDeleteService creates the soft delete service for orders
+func NewOrderSoftDeleteService(uow repositories.UnitOfWork, orderRepo repositories.OrderRepository) *SoftDeleteService[*entities.Order] {
+	return NewSoftDeleteService[*entities.Order](uow, orderRepo, ErrOrderNotFound)
+}
+
+// Delete soft deletes an entity, recording why
+func (s *SoftDeleteService[T]) Delete(ctx context.Context, id uuid.UUID, reason string) error {
+	return s.uow.Do(ctx, func(ctx context.Context) error {
+		entity, err := s.Get(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if err := entity.Delete(reason); err != nil {
+			return err
+		}
+		return s.repo.Update(ctx, entity)
+	})
+}
+
+// Restore restores a soft deleted entity
+func (s *SoftDeleteService[T]) Restore(ctx context.Context, id uuid.UUID) error {
+	return s.uow.Do(ctx, func(ctx context.Context) error {
+		entity, err := s.Get(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if err := entity.Restore(); err != nil {
+			return err
+		}
+		return s.repo.Update(ctx, entity)
+	})
+}
+
+// Get retrieves an entity whether or not it is deleted
+func (s *SoftDeleteService[T]) Get(ctx context.Context, id uuid.UUID) (T, error) {
+	entity, err := s.repo.GetByIDIncludeDeleted(ctx, id)
+	if err != nil {
+		var zero T
+		return zero, repositories.NotFoundAs(err, s.errNotFound)
+	}
+	return entity, nil
+}
+
+// ListDeleted lists soft deleted entities
+func (s *SoftDeleteService[T]) ListDeleted(ctx context.Context, offset, limit int) ([]T, error) {
+	return s.repo.ListDeleted(ctx, offset, limit)
+}
+
+// Purge hard deletes the entities soft deleted before deletedBefore and returns them. Each
+// entity raises its Purged event, which is stored before the row is removed. With dryRun
+// nothing changes and the entities that would be purged are returned.
+func (s *SoftDeleteService[T]) Purge(ctx context.Context, deletedBefore time.Time, dryRun bool) ([]T, error) {
+	var purged []T
+	offset := 0
+	for {
+		batch, err := s.repo.ListDeletedBefore(ctx, deletedBefore, offset, purgeBatchSize)
+		if err != nil {
+			return purged, err
+		}
+
+		for _, entity := range batch {
+			if !dryRun {
+				if err := s.purge(ctx, entity); err != nil {
+					return purged, err
+				}
+			}
+			purged = append(purged, entity)
+		}
+		if len(batch) < purgeBatchSize {
+			return purged, nil
+		}
+
+		// Purged rows are gone from the next batch; rows kept in a dry run are skipped
+		if dryRun {
+			offset += len(batch)
+		}
+	}
+}
+
+// purge stores the entity's Purged event and hard deletes it
+func (s *SoftDeleteService[T]) purge(ctx context.Context, entity T) error {
+	return s.uow.Do(ctx, func(ctx context.Context) error {
+		if err := entity.Purge(); err != nil {
+			return err
+		}
+		if err := s.repo.Update(ctx, entity); err != nil {
+			return err
+		}
+		return s.repo.Delete(ctx, entity.EntityID())
+	})
+}
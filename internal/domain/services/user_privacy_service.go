@@ -0,0 +1,149 @@
+package services
+
+import (
+	"context"
+	"goclean/internal/domain/entities"
+	"goclean/internal/domain/repositories"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// privacyPageSize is how many records UserPrivacyService loads at a time
+const privacyPageSize = 100
+
+// UserDataExport is everything stored about a user, gathered for a subject access request.
+// Soft deleted records are included, since they are still stored.
+type UserDataExport struct {
+	User         *entities.User
+	Profile      *entities.Profile
+	Orders       []*entities.Order
+	APIKeys      []*entities.APIKey
+	AuditEntries []*entities.OrderStatusHistory // Order status changes the user made
+	ExportedAt   time.Time
+}
+
+// UserPrivacyService answers subject access and erasure requests for users
+type UserPrivacyService struct {
+	uow         repositories.UnitOfWork
+	userRepo    repositories.UserRepository
+	profileRepo repositories.ProfileRepository
+	orderRepo   repositories.OrderRepository
+	apiKeyRepo  repositories.APIKeyRepository
+}
+
+// NewUserPrivacyService creates a new user privacy service
+func NewUserPrivacyService(
+	uow repositories.UnitOfWork,
+	userRepo repositories.UserRepository,
+	profileRepo repositories.ProfileRepository,
+	orderRepo repositories.OrderRepository,
+	apiKeyRepo repositories.APIKeyRepository,
+) *UserPrivacyService {
+	return &UserPrivacyService{
+		uow:         uow,
+		userRepo:    userRepo,
+		profileRepo: profileRepo,
+		orderRepo:   orderRepo,
+		apiKeyRepo:  apiKeyRepo,
+	}
+}
+
+// Export gathers everything stored about a user and raises UserDataExportedEvent
+func (s *UserPrivacyService) Export(ctx context.Context, userID uuid.UUID) (*UserDataExport, error) {
+	var export *UserDataExport
+	err := s.uow.Do(ctx, func(ctx context.Context) error {
+		user, err := s.getUser(ctx, userID)
+		if err != nil {
+			return err
+		}
+
+		orders, err := collectPages(func(offset, limit int) ([]*entities.Order, error) {
+			return s.orderRepo.GetByUserIDIncludeDeleted(ctx, userID, offset, limit)
+		})
+		if err != nil {
+			return err
+		}
+		apiKeys, err := collectPages(func(offset, limit int) ([]*entities.APIKey, error) {
+			return s.apiKeyRepo.ListByOwnerID(ctx, userID, offset, limit)
+		})
+		if err != nil {
+			return err
+		}
+		auditEntries, err := collectPages(func(offset, limit int) ([]*entities.OrderStatusHistory, error) {
+			return s.orderRepo.ListStatusChangesBy(ctx, userID, offset, limit)
+		})
+		if err != nil {
+			return err
+		}
+
+		user.RecordDataExport()
+		if err := s.userRepo.Update(ctx, user); err != nil {
+			return err
+		}
+
+		export = &UserDataExport{
+			User:         user,
+			Profile:      user.Profile,
+			Orders:       orders,
+			APIKeys:      apiKeys,
+			AuditEntries: auditEntries,
+			ExportedAt:   time.Now(),
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return export, nil
+}
+
+// Erase anonymises the personal data of a user and its profile, revokes the user's API
+// keys and raises UserErasedEvent. Orders are kept for accounting; they only reference
+// the user by ID.
+func (s *UserPrivacyService) Erase(ctx context.Context, userID uuid.UUID) error {
+	return s.uow.Do(ctx, func(ctx context.Context) error {
+		user, err := s.getUser(ctx, userID)
+		if err != nil {
+			return err
+		}
+
+		if err := user.Erase(); err != nil {
+			return err
+		}
+		if err := s.userRepo.Update(ctx, user); err != nil {
+			return err
+		}
+		if user.Profile != nil {
+			if err := s.profileRepo.Update(ctx, user.Profile); err != nil {
+				return err
+			}
+		}
+
+		return revokeOwnerAPIKeys(ctx, s.apiKeyRepo, userID)
+	})
+}
+
+// getUser loads a user whether or not it is deleted
+func (s *UserPrivacyService) getUser(ctx context.Context, userID uuid.UUID) (*entities.User, error) {
+	user, err := s.userRepo.GetByIDIncludeDeleted(ctx, userID)
+	if err != nil {
+		return nil, repositories.NotFoundAs(err, ErrUserNotFound)
+	}
+	return user, nil
+}
+
+// collectPages loads every page of a paginated lookup
+func collectPages[T any](fetch func(offset, limit int) ([]T, error)) ([]T, error) {
+	var all []T
+	for offset := 0; ; offset += privacyPageSize {
+		page, err := fetch(offset, privacyPageSize)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, page...)
+		if len(page) < privacyPageSize {
+			return all, nil
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package auth
+
+import (
+	"context"
+	"goclean/internal/domain/entities"
+	"goclean/internal/domain/services"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// APIKeyHeader is the request header carrying an API key
+const APIKeyHeader = "X-API-Key"
+
+// apiKeyTokenType marks claims that were derived from an API key instead of a token
+const apiKeyTokenType = "APIKey"
+
+// APIKeyAuthenticator resolves an API key to the principal it acts as
+type APIKeyAuthenticator interface {
+	AuthenticateAPIKey(ctx context.Context, key string) (*UserClaims, error)
+}
+
+// apiKeyAuthenticator implements APIKeyAuthenticator on the API key domain service
+type apiKeyAuthenticator struct {
+	service *services.APIKeyDomainService
+}
+
+// NewAPIKeyAuthenticator creates a new API key authenticator
+func NewAPIKeyAuthenticator(service *services.APIKeyDomainService) APIKeyAuthenticator {
+	return &apiKeyAuthenticator{service: service}
+}
+
+// AuthenticateAPIKey verifies the key and returns claims for its owner
+func (a *apiKeyAuthenticator) AuthenticateAPIKey(ctx context.Context, key string) (*UserClaims, error) {
+	apiKey, err := a.service.Authenticate(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	return APIKeyClaims(apiKey), nil
+}
+
+// APIKeyClaims maps an API key to claims for its owner. Scopes become realm roles,
+// so role checks treat a key like a token carrying exactly those roles.
+func APIKeyClaims(key *entities.APIKey) *UserClaims {
+	claims := &UserClaims{
+		UserID:          key.OwnerID.String(),
+		RealmAccess:     RoleClaims{Roles: append([]string(nil), key.Scopes...)},
+		AuthorizedParty: key.Name,
+		TokenType:       apiKeyTokenType,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:  key.OwnerID.String(),
+			ID:       key.ID.String(),
+			IssuedAt: jwt.NewNumericDate(key.CreatedAt),
+		},
+	}
+	if key.ExpiresAt != nil {
+		claims.ExpiresAt = jwt.NewNumericDate(*key.ExpiresAt)
+	}
+	return claims
+}
+
+// IsAPIKey checks if the claims were derived from an API key
+func (c *UserClaims) IsAPIKey() bool {
+	return c.TokenType == apiKeyTokenType
+}
@@ -0,0 +1,34 @@
+package auth
+
+import (
+	"context"
+	"goclean/internal/application/authz"
+)
+
+// userClaimsContextKey is the context key for the authenticated user's claims
+type userClaimsContextKey struct{}
+
+// WithUserClaims returns a copy of ctx carrying the authenticated user's claims and
+// the authorization principal derived from them
+func WithUserClaims(ctx context.Context, claims *UserClaims) context.Context {
+	ctx = authz.WithPrincipal(ctx, claims.Principal())
+	return context.WithValue(ctx, userClaimsContextKey{}, claims)
+}
+
+// UserClaimsFromContext returns the authenticated user's claims stored in ctx
+func UserClaimsFromContext(ctx context.Context) (*UserClaims, bool) {
+	claims, ok := ctx.Value(userClaimsContextKey{}).(*UserClaims)
+	return claims, ok && claims != nil
+}
+
+// Principal returns the authorization principal for the claims. Its roles are the
+// merged realm and client roles, or the scopes for API keys.
+func (c *UserClaims) Principal() *authz.Principal {
+	method := authz.AuthMethodToken
+	if c.IsAPIKey() {
+		method = authz.AuthMethodAPIKey
+	}
+	return authz.NewPrincipal(c.UserID, c.Roles(), map[string]string{
+		authz.AttrAuthMethod: method,
+	})
+}
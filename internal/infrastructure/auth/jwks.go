@@ -0,0 +1,224 @@
+package auth
+
+import (
+	"context"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"sync"
+	"time"
+)
+
+var (
+	ErrKeyNotFound       = errors.New("signing key not found")
+	ErrUnsupportedJWKKey = errors.New("unsupported JWK key")
+)
+
+// JWKSConfig holds JWKS key provider configuration
+type JWKSConfig struct {
+	URL                string
+	CacheTTL           time.Duration // How long fetched keys are trusted before a refetch
+	MinRefreshInterval time.Duration // Minimum time between refetches triggered by unknown key IDs
+	HTTPClient         *http.Client
+}
+
+// JWKSKeyProvider fetches and caches RSA signing keys from a JWKS endpoint.
+// Keys are selected by the token's kid header; an unknown kid triggers a
+// rate-limited refetch so rotated keys are picked up without a restart.
+type JWKSKeyProvider struct {
+	config JWKSConfig
+
+	mu          sync.RWMutex
+	keys        map[string]*rsa.PublicKey
+	fetchedAt   time.Time
+	lastAttempt time.Time
+
+	refreshMu sync.Mutex // Serializes refetches so concurrent misses share one request
+}
+
+// jwk represents a single JSON Web Key
+type jwk struct {
+	Kid string `json:"kid"`
+	Kty string `json:"kty"`
+	Alg string `json:"alg"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+}
+
+// jwks represents a JSON Web Key Set document
+type jwks struct {
+	Keys []jwk `json:"keys"`
+}
+
+// NewJWKSKeyProvider creates a new JWKS key provider
+func NewJWKSKeyProvider(config JWKSConfig) *JWKSKeyProvider {
+	if config.CacheTTL <= 0 {
+		config.CacheTTL = time.Hour
+	}
+	if config.MinRefreshInterval <= 0 {
+		config.MinRefreshInterval = 30 * time.Second
+	}
+	if config.HTTPClient == nil {
+		config.HTTPClient = &http.Client{Timeout: 10 * time.Second}
+	}
+
+	return &JWKSKeyProvider{
+		config: config,
+		keys:   make(map[string]*rsa.PublicKey),
+	}
+}
+
+// GetKey returns the RSA public key for the given key ID. An empty kid is
+// accepted only when the key set contains exactly one key.
+func (p *JWKSKeyProvider) GetKey(ctx context.Context, kid string) (*rsa.PublicKey, error) {
+	if key, fresh := p.lookup(kid); key != nil && fresh {
+		return key, nil
+	}
+
+	p.refreshMu.Lock()
+	defer p.refreshMu.Unlock()
+
+	// Another caller may have refreshed while we waited
+	key, fresh := p.lookup(kid)
+	if key != nil && fresh {
+		return key, nil
+	}
+
+	if p.canRefresh(fresh) {
+		if err := p.refresh(ctx); err != nil {
+			// Serve a stale key rather than failing while the endpoint is unavailable
+			if key != nil {
+				return key, nil
+			}
+			return nil, err
+		}
+		key, _ = p.lookup(kid)
+	}
+
+	if key == nil {
+		return nil, fmt.Errorf("%w: kid %q", ErrKeyNotFound, kid)
+	}
+	return key, nil
+}
+
+// lookup returns the cached key for kid and whether the cache is within its TTL
+func (p *JWKSKeyProvider) lookup(kid string) (*rsa.PublicKey, bool) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	fresh := !p.fetchedAt.IsZero() && time.Since(p.fetchedAt) < p.config.CacheTTL
+	if kid == "" {
+		if len(p.keys) == 1 {
+			for _, key := range p.keys {
+				return key, fresh
+			}
+		}
+		return nil, fresh
+	}
+	return p.keys[kid], fresh
+}
+
+// canRefresh reports whether a refetch is allowed. Expired caches always refresh;
+// misses against a fresh cache are limited to one refetch per MinRefreshInterval.
+func (p *JWKSKeyProvider) canRefresh(fresh bool) bool {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	if !fresh {
+		return true
+	}
+	return time.Since(p.lastAttempt) >= p.config.MinRefreshInterval
+}
+
+// refresh fetches the key set and replaces the cached keys
+func (p *JWKSKeyProvider) refresh(ctx context.Context) error {
+	p.mu.Lock()
+	p.lastAttempt = time.Now()
+	p.mu.Unlock()
+
+	keys, err := p.fetch(ctx)
+	if err != nil {
+		return err
+	}
+
+	p.mu.Lock()
+	p.keys = keys
+	p.fetchedAt = time.Now()
+	p.mu.Unlock()
+
+	return nil
+}
+
+// fetch downloads the JWKS document and converts its signing keys
+func (p *JWKSKeyProvider) fetch(ctx context.Context) (map[string]*rsa.PublicKey, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.config.URL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create JWKS request: %w", err)
+	}
+
+	resp, err := p.config.HTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get JWKS: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to get JWKS: unexpected status %d", resp.StatusCode)
+	}
+
+	var set jwks
+	if err := json.NewDecoder(resp.Body).Decode(&set); err != nil {
+		return nil, fmt.Errorf("failed to decode JWKS: %w", err)
+	}
+
+	keys := make(map[string]*rsa.PublicKey)
+	for _, key := range set.Keys {
+		// Skip encryption keys and non-RSA keys
+		if key.Kty != "RSA" || (key.Use != "" && key.Use != "sig") {
+			continue
+		}
+
+		publicKey, err := key.rsaPublicKey()
+		if err != nil {
+			return nil, fmt.Errorf("invalid JWK %q: %w", key.Kid, err)
+		}
+		keys[key.Kid] = publicKey
+	}
+
+	if len(keys) == 0 {
+		return nil, errors.New("no RSA signing keys found in JWKS")
+	}
+
+	return keys, nil
+}
+
+// rsaPublicKey converts the base64url-encoded modulus and exponent to an RSA public key
+func (k jwk) rsaPublicKey() (*rsa.PublicKey, error) {
+	if k.N == "" || k.E == "" {
+		return nil, fmt.Errorf("%w: missing modulus or exponent", ErrUnsupportedJWKKey)
+	}
+
+	n, err := base64.RawURLEncoding.DecodeString(k.N)
+	if err != nil {
+		return nil, fmt.Errorf("invalid modulus: %w", err)
+	}
+	e, err := base64.RawURLEncoding.DecodeString(k.E)
+	if err != nil {
+		return nil, fmt.Errorf("invalid exponent: %w", err)
+	}
+
+	exponent := new(big.Int).SetBytes(e)
+	if !exponent.IsInt64() || exponent.Int64() < 3 || exponent.Int64() > 1<<31-1 {
+		return nil, fmt.Errorf("%w: exponent out of range", ErrUnsupportedJWKKey)
+	}
+
+	return &rsa.PublicKey{
+		N: new(big.Int).SetBytes(n),
+		E: int(exponent.Int64()),
+	}, nil
+}
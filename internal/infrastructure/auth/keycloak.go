@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/http"
 	"strings"
 	"time"
 
@@ -11,63 +12,71 @@ import (
 	"github.com/golang-jwt/jwt/v5"
 )
 
+// rsaSigningMethods lists the algorithms accepted for JWKS-verified tokens
+var rsaSigningMethods = []string{"RS256", "RS384", "RS512"}
+
+// refreshTokenType is the typ claim Keycloak and the local provider put on refresh tokens
+const refreshTokenType = "Refresh"
+
+// ErrInvalidAudience is returned when a token was not issued for the configured client
+var ErrInvalidAudience = errors.New("token not issued for this client")
+
 // KeycloakConfig holds Keycloak configuration
 type KeycloakConfig struct {
-	BaseURL      string
-	Realm        string
-	ClientID     string
-	ClientSecret string
+	BaseURL                string
+	Realm                  string
+	ClientID               string
+	ClientSecret           string
+	JWKSCacheTTL           time.Duration
+	JWKSMinRefreshInterval time.Duration
 }
 
 // AuthService provides authentication functionality
 type AuthService struct {
 	client *gocloak.GoCloak
 	config KeycloakConfig
+	keys   *JWKSKeyProvider
 }
 
 // NewAuthService creates a new auth service
 func NewAuthService(config KeycloakConfig) *AuthService {
 	client := gocloak.NewClient(config.BaseURL)
 
+	keys := NewJWKSKeyProvider(JWKSConfig{
+		URL:                fmt.Sprintf("%s/realms/%s/protocol/openid-connect/certs", strings.TrimSuffix(config.BaseURL, "/"), config.Realm),
+		CacheTTL:           config.JWKSCacheTTL,
+		MinRefreshInterval: config.JWKSMinRefreshInterval,
+	})
+
 	return &AuthService{
 		client: client,
 		config: config,
+		keys:   keys,
 	}
 }
 
 // ValidateToken validates JWT token
 func (s *AuthService) ValidateToken(ctx context.Context, tokenString string) (*UserClaims, error) {
-	// Remove Bearer prefix if present
-	tokenString = strings.TrimPrefix(tokenString, "Bearer ")
-
-	// Get public key from Keycloak
-	publicKey, err := s.getPublicKey(ctx)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get public key: %w", err)
+	parser := tokenParser{
+		issuer:   s.issuer(),
+		clientID: s.config.ClientID,
+		methods:  rsaSigningMethods,
 	}
 
-	// Parse and validate token
-	token, err := jwt.ParseWithClaims(tokenString, &UserClaims{}, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodRSA); !ok {
-			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+	// Validate the token with the Keycloak key matching its kid header
+	return parser.parse(tokenString, func(token *jwt.Token) (interface{}, error) {
+		kid, _ := token.Header["kid"].(string)
+		publicKey, err := s.keys.GetKey(ctx, kid)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get public key: %w", err)
 		}
 		return publicKey, nil
 	})
+}
 
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse token: %w", err)
-	}
-
-	if !token.Valid {
-		return nil, errors.New("invalid token")
-	}
-
-	claims, ok := token.Claims.(*UserClaims)
-	if !ok {
-		return nil, errors.New("invalid token claims")
-	}
-
-	return claims, nil
+// issuer returns the expected token issuer for the configured realm
+func (s *AuthService) issuer() string {
+	return fmt.Sprintf("%s/realms/%s", strings.TrimSuffix(s.config.BaseURL, "/"), s.config.Realm)
 }
 
 // GetUserInfo retrieves user information from Keycloak
@@ -80,83 +89,154 @@ func (s *AuthService) GetUserInfo(ctx context.Context, accessToken string) (*goc
 }
 
 // Login authenticates user with Keycloak
-func (s *AuthService) Login(ctx context.Context, username, password string) (*gocloak.JWT, error) {
+func (s *AuthService) Login(ctx context.Context, username, password string) (*TokenSet, error) {
 	jwt, err := s.client.Login(ctx, s.config.ClientID, s.config.ClientSecret, s.config.Realm, username, password)
 	if err != nil {
-		return nil, fmt.Errorf("failed to login: %w", err)
+		return nil, fmt.Errorf("failed to login: %w", keycloakError(err))
 	}
-	return jwt, nil
+	return toTokenSet(jwt), nil
 }
 
 // RefreshToken refreshes JWT token
-func (s *AuthService) RefreshToken(ctx context.Context, refreshToken string) (*gocloak.JWT, error) {
+func (s *AuthService) RefreshToken(ctx context.Context, refreshToken string) (*TokenSet, error) {
 	jwt, err := s.client.RefreshToken(ctx, refreshToken, s.config.ClientID, s.config.ClientSecret, s.config.Realm)
 	if err != nil {
-		return nil, fmt.Errorf("failed to refresh token: %w", err)
+		return nil, fmt.Errorf("failed to refresh token: %w", keycloakError(err))
 	}
-	return jwt, nil
+	return toTokenSet(jwt), nil
 }
 
 // Logout logs out user
 func (s *AuthService) Logout(ctx context.Context, refreshToken string) error {
 	err := s.client.Logout(ctx, s.config.ClientID, s.config.ClientSecret, s.config.Realm, refreshToken)
 	if err != nil {
-		return fmt.Errorf("failed to logout: %w", err)
+		return fmt.Errorf("failed to logout: %w", keycloakError(err))
 	}
 	return nil
 }
 
-// getPublicKey retrieves public key from Keycloak JWKS endpoint
-func (s *AuthService) getPublicKey(ctx context.Context) (interface{}, error) {
-	// Get JWKS (JSON Web Key Set) from Keycloak
-	jwks, err := s.client.GetCerts(ctx, s.config.Realm)
+// LogoutUserSessions ends all Keycloak sessions of a user using the client's service account.
+// The client needs the realm-management manage-users role.
+func (s *AuthService) LogoutUserSessions(ctx context.Context, userID string) error {
+	token, err := s.client.LoginClient(ctx, s.config.ClientID, s.config.ClientSecret, s.config.Realm)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get JWKS: %w", err)
+		return fmt.Errorf("failed to authenticate client: %w", err)
 	}
 
-	if jwks.Keys == nil || len(*jwks.Keys) == 0 {
-		return nil, errors.New("no keys found in JWKS")
+	if err := s.client.LogoutAllSessions(ctx, token.AccessToken, s.config.Realm, userID); err != nil {
+		return fmt.Errorf("failed to logout user sessions: %w", err)
 	}
+	return nil
+}
 
-	// Use the first key (in production, you might want to find the correct key by kid)
-	keys := *jwks.Keys
-	key := keys[0]
+// keycloakError maps rejected grants to ErrInvalidCredentials
+func keycloakError(err error) error {
+	var apiErr *gocloak.APIError
+	if errors.As(err, &apiErr) && (apiErr.Code == http.StatusBadRequest || apiErr.Code == http.StatusUnauthorized) {
+		return fmt.Errorf("%w: %s", ErrInvalidCredentials, apiErr.Message)
+	}
+	return err
+}
 
-	// Convert the key to RSA public key
-	if key.Kty == nil || *key.Kty != "RSA" {
-		return nil, errors.New("unsupported key type, expected RSA")
+// toTokenSet converts a Keycloak token response
+func toTokenSet(jwt *gocloak.JWT) *TokenSet {
+	return &TokenSet{
+		AccessToken:      jwt.AccessToken,
+		RefreshToken:     jwt.RefreshToken,
+		IDToken:          jwt.IDToken,
+		TokenType:        jwt.TokenType,
+		ExpiresIn:        jwt.ExpiresIn,
+		RefreshExpiresIn: jwt.RefreshExpiresIn,
 	}
+}
 
-	// Parse the key - gocloak should provide the key in the proper format
-	// For simplicity, we'll use the key directly from the JWKS
-	// In a production environment, you might want to implement proper JWK to RSA conversion
-	return &key, nil
+// RoleClaims represents a Keycloak role list, used for realm_access and each resource_access entry
+type RoleClaims struct {
+	Roles []string `json:"roles"`
 }
 
 // UserClaims represents JWT claims
 type UserClaims struct {
-	UserID        string   `json:"sub"`
-	Email         string   `json:"email"`
-	Username      string   `json:"preferred_username"`
-	FirstName     string   `json:"given_name"`
-	LastName      string   `json:"family_name"`
-	Roles         []string `json:"realm_access.roles"`
-	EmailVerified bool     `json:"email_verified"`
-	SessionState  string   `json:"session_state"`
+	UserID          string                `json:"sub"`
+	Email           string                `json:"email"`
+	Username        string                `json:"preferred_username"`
+	FirstName       string                `json:"given_name"`
+	LastName        string                `json:"family_name"`
+	RealmAccess     RoleClaims            `json:"realm_access"`
+	ResourceAccess  map[string]RoleClaims `json:"resource_access"`
+	AuthorizedParty string                `json:"azp"`
+	EmailVerified   bool                  `json:"email_verified"`
+	SessionState    string                `json:"session_state"`
+	SessionID       string                `json:"sid,omitempty"`
+	TokenType       string                `json:"typ,omitempty"`
 	jwt.RegisteredClaims
+
+	clientID string // Client whose roles count towards HasRole, set by ValidateToken
 }
 
-// HasRole checks if user has specific role
+// HasRole checks if user has a realm role or a client role for the configured client
 func (c *UserClaims) HasRole(role string) bool {
-	for _, r := range c.Roles {
-		if r == role {
+	return c.HasRealmRole(role) || c.HasClientRole(c.clientID, role)
+}
+
+// HasRealmRole checks if user has a specific realm role
+func (c *UserClaims) HasRealmRole(role string) bool {
+	return containsRole(c.RealmAccess.Roles, role)
+}
+
+// HasClientRole checks if user has a specific role for the given client
+func (c *UserClaims) HasClientRole(clientID, role string) bool {
+	if clientID == "" {
+		return false
+	}
+	return containsRole(c.ResourceAccess[clientID].Roles, role)
+}
+
+// Roles returns the realm roles followed by the configured client's roles
+func (c *UserClaims) Roles() []string {
+	roles := append([]string{}, c.RealmAccess.Roles...)
+	if c.clientID != "" {
+		for _, role := range c.ResourceAccess[c.clientID].Roles {
+			if !containsRole(roles, role) {
+				roles = append(roles, role)
+			}
+		}
+	}
+	return roles
+}
+
+// IssuedFor checks if the token was issued to or for the given client. Keycloak
+// access tokens carry the requesting client in azp and often only "account" in aud.
+func (c *UserClaims) IssuedFor(clientID string) bool {
+	if clientID == "" || c.AuthorizedParty == clientID {
+		return true
+	}
+	for _, aud := range c.Audience {
+		if aud == clientID {
 			return true
 		}
 	}
 	return false
 }
 
+// Session returns the identity provider session the token belongs to
+func (c *UserClaims) Session() string {
+	if c.SessionID != "" {
+		return c.SessionID
+	}
+	return c.SessionState
+}
+
 // IsExpired checks if token is expired
 func (c *UserClaims) IsExpired() bool {
 	return c.ExpiresAt.Time.Before(time.Now())
 }
+
+func containsRole(roles []string, role string) bool {
+	for _, r := range roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,191 @@
+package auth
+
+import (
+	"context"
+	"crypto/rsa"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+// LocalConfig holds configuration for the self-contained development token issuer
+type LocalConfig struct {
+	Issuer          string
+	ClientID        string
+	SigningMethod   string // HS256 (default) or RS256
+	Secret          string // HMAC secret, at least 32 bytes
+	PrivateKeyFile  string // PEM-encoded RSA private key for RS256
+	AccessTokenTTL  time.Duration
+	RefreshTokenTTL time.Duration
+}
+
+// LocalProvider issues and validates tokens signed with a configured key. It needs
+// no identity provider and is meant for local development and integration tests.
+type LocalProvider struct {
+	config    LocalConfig
+	method    jwt.SigningMethod
+	signKey   interface{}
+	verifyKey interface{}
+}
+
+// NewLocalProvider creates a new local provider
+func NewLocalProvider(config LocalConfig) (*LocalProvider, error) {
+	if config.Issuer == "" {
+		config.Issuer = "goclean-local"
+	}
+	if config.AccessTokenTTL <= 0 {
+		config.AccessTokenTTL = 15 * time.Minute
+	}
+	if config.RefreshTokenTTL <= 0 {
+		config.RefreshTokenTTL = 24 * time.Hour
+	}
+
+	provider := &LocalProvider{config: config}
+
+	switch strings.ToUpper(config.SigningMethod) {
+	case "", "HS256":
+		if len(config.Secret) < 32 {
+			return nil, errors.New("local auth provider requires a secret of at least 32 bytes")
+		}
+		provider.method = jwt.SigningMethodHS256
+		provider.signKey = []byte(config.Secret)
+		provider.verifyKey = []byte(config.Secret)
+	case "RS256":
+		privateKey, err := loadRSAPrivateKey(config.PrivateKeyFile)
+		if err != nil {
+			return nil, err
+		}
+		provider.method = jwt.SigningMethodRS256
+		provider.signKey = privateKey
+		provider.verifyKey = &privateKey.PublicKey
+	default:
+		return nil, fmt.Errorf("unsupported local signing method %q", config.SigningMethod)
+	}
+
+	return provider, nil
+}
+
+// IssueToken mints an access and refresh token for the given claims. Subject, roles and
+// profile fields are taken from claims; issuer, client and lifetimes come from the config.
+func (p *LocalProvider) IssueToken(claims UserClaims) (*TokenSet, error) {
+	if claims.UserID == "" {
+		return nil, errors.New("token subject is required")
+	}
+
+	now := time.Now()
+	claims.AuthorizedParty = p.config.ClientID
+	claims.RegisteredClaims = jwt.RegisteredClaims{
+		Issuer:    p.config.Issuer,
+		IssuedAt:  jwt.NewNumericDate(now),
+		NotBefore: jwt.NewNumericDate(now),
+		ID:        uuid.NewString(),
+	}
+	if claims.SessionState == "" {
+		claims.SessionState = uuid.NewString()
+	}
+
+	access := claims
+	access.TokenType = "Bearer"
+	access.ExpiresAt = jwt.NewNumericDate(now.Add(p.config.AccessTokenTTL))
+	accessToken, err := p.sign(&access)
+	if err != nil {
+		return nil, err
+	}
+
+	refresh := claims
+	refresh.TokenType = refreshTokenType
+	refresh.ID = uuid.NewString()
+	refresh.ExpiresAt = jwt.NewNumericDate(now.Add(p.config.RefreshTokenTTL))
+	refreshToken, err := p.sign(&refresh)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TokenSet{
+		AccessToken:      accessToken,
+		RefreshToken:     refreshToken,
+		TokenType:        "Bearer",
+		ExpiresIn:        int(p.config.AccessTokenTTL.Seconds()),
+		RefreshExpiresIn: int(p.config.RefreshTokenTTL.Seconds()),
+	}, nil
+}
+
+// ValidateToken validates JWT token
+func (p *LocalProvider) ValidateToken(ctx context.Context, tokenString string) (*UserClaims, error) {
+	return p.parser().parse(tokenString, p.keyFunc)
+}
+
+// Login is not supported; use IssueToken to mint tokens for a user
+func (p *LocalProvider) Login(ctx context.Context, username, password string) (*TokenSet, error) {
+	return nil, fmt.Errorf("failed to login: %w", ErrUnsupportedOperation)
+}
+
+// RefreshToken issues a new token pair from a refresh token minted by this provider
+func (p *LocalProvider) RefreshToken(ctx context.Context, refreshToken string) (*TokenSet, error) {
+	token, err := jwt.ParseWithClaims(refreshToken, &UserClaims{}, p.keyFunc,
+		jwt.WithValidMethods([]string{p.method.Alg()}),
+		jwt.WithIssuer(p.config.Issuer),
+		jwt.WithExpirationRequired(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to refresh token: %w: %v", ErrInvalidCredentials, err)
+	}
+
+	claims, ok := token.Claims.(*UserClaims)
+	if !ok || claims.TokenType != refreshTokenType {
+		return nil, fmt.Errorf("failed to refresh token: %w: not a refresh token", ErrInvalidCredentials)
+	}
+
+	return p.IssueToken(*claims)
+}
+
+// Logout is a no-op because local tokens are stateless
+func (p *LocalProvider) Logout(ctx context.Context, refreshToken string) error {
+	return nil
+}
+
+// parser returns the access token checks for this provider
+func (p *LocalProvider) parser() tokenParser {
+	return tokenParser{
+		issuer:   p.config.Issuer,
+		clientID: p.config.ClientID,
+		methods:  []string{p.method.Alg()},
+	}
+}
+
+// keyFunc returns the verification key
+func (p *LocalProvider) keyFunc(*jwt.Token) (interface{}, error) {
+	return p.verifyKey, nil
+}
+
+// sign signs the claims with the configured key
+func (p *LocalProvider) sign(claims *UserClaims) (string, error) {
+	signed, err := jwt.NewWithClaims(p.method, claims).SignedString(p.signKey)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign token: %w", err)
+	}
+	return signed, nil
+}
+
+// loadRSAPrivateKey reads a PEM-encoded RSA private key
+func loadRSAPrivateKey(path string) (*rsa.PrivateKey, error) {
+	if path == "" {
+		return nil, errors.New("local auth provider requires a private key file for RS256")
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read private key: %w", err)
+	}
+
+	privateKey, err := jwt.ParseRSAPrivateKeyFromPEM(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse private key: %w", err)
+	}
+	return privateKey, nil
+}
@@ -0,0 +1,249 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// OIDCConfig holds configuration for a generic OpenID Connect provider
+type OIDCConfig struct {
+	IssuerURL              string
+	ClientID               string
+	ClientSecret           string
+	JWKSCacheTTL           time.Duration
+	JWKSMinRefreshInterval time.Duration
+	HTTPClient             *http.Client
+}
+
+// OIDCProvider authenticates against any OpenID Connect provider using its discovery document
+type OIDCProvider struct {
+	config OIDCConfig
+
+	mu        sync.Mutex
+	discovery *oidcDiscovery
+	keys      *JWKSKeyProvider
+}
+
+// oidcDiscovery represents the fields used from /.well-known/openid-configuration
+type oidcDiscovery struct {
+	Issuer             string `json:"issuer"`
+	JWKSURI            string `json:"jwks_uri"`
+	TokenEndpoint      string `json:"token_endpoint"`
+	RevocationEndpoint string `json:"revocation_endpoint"`
+	EndSessionEndpoint string `json:"end_session_endpoint"`
+}
+
+// oidcErrorResponse represents an OAuth 2.0 error response
+type oidcErrorResponse struct {
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+// NewOIDCProvider creates a new OIDC provider. Discovery happens lazily on first use
+// so the application can start while the identity provider is unavailable.
+func NewOIDCProvider(config OIDCConfig) *OIDCProvider {
+	if config.HTTPClient == nil {
+		config.HTTPClient = &http.Client{Timeout: 10 * time.Second}
+	}
+	return &OIDCProvider{config: config}
+}
+
+// ValidateToken validates JWT token
+func (p *OIDCProvider) ValidateToken(ctx context.Context, tokenString string) (*UserClaims, error) {
+	discovery, keys, err := p.discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	parser := tokenParser{
+		issuer:   discovery.Issuer,
+		clientID: p.config.ClientID,
+		methods:  rsaSigningMethods,
+	}
+
+	return parser.parse(tokenString, func(token *jwt.Token) (interface{}, error) {
+		kid, _ := token.Header["kid"].(string)
+		publicKey, err := keys.GetKey(ctx, kid)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get public key: %w", err)
+		}
+		return publicKey, nil
+	})
+}
+
+// Login authenticates user with the resource owner password grant
+func (p *OIDCProvider) Login(ctx context.Context, username, password string) (*TokenSet, error) {
+	tokens, err := p.tokenRequest(ctx, url.Values{
+		"grant_type": {"password"},
+		"username":   {username},
+		"password":   {password},
+		"scope":      {"openid"},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to login: %w", err)
+	}
+	return tokens, nil
+}
+
+// RefreshToken refreshes JWT token
+func (p *OIDCProvider) RefreshToken(ctx context.Context, refreshToken string) (*TokenSet, error) {
+	tokens, err := p.tokenRequest(ctx, url.Values{
+		"grant_type":    {"refresh_token"},
+		"refresh_token": {refreshToken},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to refresh token: %w", err)
+	}
+	return tokens, nil
+}
+
+// Logout revokes the refresh token, falling back to the end session endpoint
+func (p *OIDCProvider) Logout(ctx context.Context, refreshToken string) error {
+	discovery, _, err := p.discover(ctx)
+	if err != nil {
+		return err
+	}
+
+	var endpoint string
+	form := p.clientForm()
+	switch {
+	case discovery.RevocationEndpoint != "":
+		endpoint = discovery.RevocationEndpoint
+		form.Set("token", refreshToken)
+		form.Set("token_type_hint", "refresh_token")
+	case discovery.EndSessionEndpoint != "":
+		endpoint = discovery.EndSessionEndpoint
+		form.Set("refresh_token", refreshToken)
+	default:
+		return fmt.Errorf("failed to logout: %w", ErrUnsupportedOperation)
+	}
+
+	resp, err := p.postForm(ctx, endpoint, form)
+	if err != nil {
+		return fmt.Errorf("failed to logout: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return fmt.Errorf("failed to logout: %w", readOIDCError(resp))
+	}
+	return nil
+}
+
+// discover fetches and caches the discovery document. Failures are not cached.
+func (p *OIDCProvider) discover(ctx context.Context) (*oidcDiscovery, *JWKSKeyProvider, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.discovery != nil {
+		return p.discovery, p.keys, nil
+	}
+
+	discoveryURL := strings.TrimSuffix(p.config.IssuerURL, "/") + "/.well-known/openid-configuration"
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, discoveryURL, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create discovery request: %w", err)
+	}
+
+	resp, err := p.config.HTTPClient.Do(req)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get OIDC discovery document: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, nil, fmt.Errorf("failed to get OIDC discovery document: unexpected status %d", resp.StatusCode)
+	}
+
+	var discovery oidcDiscovery
+	if err := json.NewDecoder(resp.Body).Decode(&discovery); err != nil {
+		return nil, nil, fmt.Errorf("failed to decode OIDC discovery document: %w", err)
+	}
+	if discovery.Issuer == "" || discovery.JWKSURI == "" {
+		return nil, nil, errors.New("OIDC discovery document is missing issuer or jwks_uri")
+	}
+
+	p.discovery = &discovery
+	p.keys = NewJWKSKeyProvider(JWKSConfig{
+		URL:                discovery.JWKSURI,
+		CacheTTL:           p.config.JWKSCacheTTL,
+		MinRefreshInterval: p.config.JWKSMinRefreshInterval,
+		HTTPClient:         p.config.HTTPClient,
+	})
+
+	return p.discovery, p.keys, nil
+}
+
+// tokenRequest posts a grant to the token endpoint
+func (p *OIDCProvider) tokenRequest(ctx context.Context, grant url.Values) (*TokenSet, error) {
+	discovery, _, err := p.discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if discovery.TokenEndpoint == "" {
+		return nil, ErrUnsupportedOperation
+	}
+
+	form := p.clientForm()
+	for key, values := range grant {
+		form[key] = values
+	}
+
+	resp, err := p.postForm(ctx, discovery.TokenEndpoint, form)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, readOIDCError(resp)
+	}
+
+	var tokens TokenSet
+	if err := json.NewDecoder(resp.Body).Decode(&tokens); err != nil {
+		return nil, fmt.Errorf("failed to decode token response: %w", err)
+	}
+	return &tokens, nil
+}
+
+// clientForm returns the client authentication parameters
+func (p *OIDCProvider) clientForm() url.Values {
+	form := url.Values{"client_id": {p.config.ClientID}}
+	if p.config.ClientSecret != "" {
+		form.Set("client_secret", p.config.ClientSecret)
+	}
+	return form
+}
+
+// postForm posts a URL-encoded form
+func (p *OIDCProvider) postForm(ctx context.Context, endpoint string, form url.Values) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	return p.config.HTTPClient.Do(req)
+}
+
+// readOIDCError converts an OAuth 2.0 error response, mapping rejected grants to ErrInvalidCredentials
+func readOIDCError(resp *http.Response) error {
+	var body oidcErrorResponse
+	_ = json.NewDecoder(resp.Body).Decode(&body)
+
+	if body.Error == "invalid_grant" {
+		return fmt.Errorf("%w: %s", ErrInvalidCredentials, body.ErrorDescription)
+	}
+	if body.Error != "" {
+		return fmt.Errorf("%s: %s", body.Error, body.ErrorDescription)
+	}
+	return fmt.Errorf("unexpected status %d", resp.StatusCode)
+}
@@ -0,0 +1,114 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Supported authentication provider types
+const (
+	ProviderKeycloak = "keycloak"
+	ProviderOIDC     = "oidc"
+	ProviderLocal    = "local"
+)
+
+var (
+	ErrInvalidCredentials   = errors.New("invalid credentials")
+	ErrUnsupportedOperation = errors.New("operation not supported by auth provider")
+)
+
+// TokenValidator validates bearer tokens and returns their claims
+type TokenValidator interface {
+	ValidateToken(ctx context.Context, tokenString string) (*UserClaims, error)
+}
+
+// AuthProvider authenticates users against an identity provider
+type AuthProvider interface {
+	TokenValidator
+	Login(ctx context.Context, username, password string) (*TokenSet, error)
+	RefreshToken(ctx context.Context, refreshToken string) (*TokenSet, error)
+	Logout(ctx context.Context, refreshToken string) error
+}
+
+// TokenSet represents the tokens returned by a login or refresh
+type TokenSet struct {
+	AccessToken      string `json:"access_token"`
+	RefreshToken     string `json:"refresh_token,omitempty"`
+	IDToken          string `json:"id_token,omitempty"`
+	TokenType        string `json:"token_type"`
+	ExpiresIn        int    `json:"expires_in"`
+	RefreshExpiresIn int    `json:"refresh_expires_in,omitempty"`
+}
+
+// ProviderConfig selects and configures an authentication provider
+type ProviderConfig struct {
+	Type     string
+	Keycloak KeycloakConfig
+	OIDC     OIDCConfig
+	Local    LocalConfig
+}
+
+// NewProvider creates the authentication provider selected by config.Type
+func NewProvider(config ProviderConfig) (AuthProvider, error) {
+	switch strings.ToLower(config.Type) {
+	case "", ProviderKeycloak:
+		return NewAuthService(config.Keycloak), nil
+	case ProviderOIDC:
+		return NewOIDCProvider(config.OIDC), nil
+	case ProviderLocal:
+		return NewLocalProvider(config.Local)
+	default:
+		return nil, fmt.Errorf("unknown auth provider %q", config.Type)
+	}
+}
+
+// tokenParser holds the checks shared by all providers when validating an access token
+type tokenParser struct {
+	issuer   string
+	clientID string
+	methods  []string
+}
+
+// parse verifies the token signature, issuer, expiry and audience
+func (p tokenParser) parse(tokenString string, keyFunc jwt.Keyfunc) (*UserClaims, error) {
+	// Remove Bearer prefix if present
+	tokenString = strings.TrimPrefix(tokenString, "Bearer ")
+
+	options := []jwt.ParserOption{
+		jwt.WithValidMethods(p.methods),
+		jwt.WithExpirationRequired(),
+	}
+	if p.issuer != "" {
+		options = append(options, jwt.WithIssuer(p.issuer))
+	}
+
+	token, err := jwt.ParseWithClaims(tokenString, &UserClaims{}, keyFunc, options...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse token: %w", err)
+	}
+
+	if !token.Valid {
+		return nil, errors.New("invalid token")
+	}
+
+	claims, ok := token.Claims.(*UserClaims)
+	if !ok {
+		return nil, errors.New("invalid token claims")
+	}
+
+	// Refresh tokens must not be accepted as access tokens
+	if strings.EqualFold(claims.TokenType, refreshTokenType) {
+		return nil, errors.New("refresh token used as access token")
+	}
+
+	if !claims.IssuedFor(p.clientID) {
+		return nil, ErrInvalidAudience
+	}
+	claims.clientID = p.clientID
+
+	return claims, nil
+}
@@ -0,0 +1,182 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"goclean/internal/infrastructure/cache"
+	"goclean/pkg/logger"
+	"strconv"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+var (
+	ErrTokenRevoked          = errors.New("token has been revoked")
+	ErrRevocationUnavailable = errors.New("token revocation check unavailable")
+)
+
+// RevocationStore records revoked tokens, sessions and users
+type RevocationStore interface {
+	RevokeToken(ctx context.Context, jti string, ttl time.Duration) error
+	RevokeSession(ctx context.Context, sessionID string, ttl time.Duration) error
+	RevokeUser(ctx context.Context, userID string, revokedAt time.Time, ttl time.Duration) error
+	IsRevoked(ctx context.Context, claims *UserClaims) (bool, error)
+}
+
+// SessionTerminator is implemented by providers that can end all sessions of a user
+type SessionTerminator interface {
+	LogoutUserSessions(ctx context.Context, userID string) error
+}
+
+// redisRevocationStore implements RevocationStore on the Redis cache. Entries expire
+// with the tokens they revoke, so the store does not grow without bound.
+type redisRevocationStore struct {
+	cache *cache.CacheService
+}
+
+// NewRedisRevocationStore creates a new Redis-backed revocation store
+func NewRedisRevocationStore(cache *cache.CacheService) RevocationStore {
+	return &redisRevocationStore{cache: cache}
+}
+
+// RevokeToken revokes a single token by its jti
+func (s *redisRevocationStore) RevokeToken(ctx context.Context, jti string, ttl time.Duration) error {
+	if jti == "" || ttl <= 0 {
+		return nil
+	}
+	return s.cache.Set(ctx, revokedTokenKey(jti), "1", ttl)
+}
+
+// RevokeSession revokes every token of an identity provider session
+func (s *redisRevocationStore) RevokeSession(ctx context.Context, sessionID string, ttl time.Duration) error {
+	if sessionID == "" || ttl <= 0 {
+		return nil
+	}
+	return s.cache.Set(ctx, revokedSessionKey(sessionID), "1", ttl)
+}
+
+// RevokeUser revokes every token of a user issued at or before revokedAt
+func (s *redisRevocationStore) RevokeUser(ctx context.Context, userID string, revokedAt time.Time, ttl time.Duration) error {
+	return s.cache.Set(ctx, revokedUserKey(userID), strconv.FormatInt(revokedAt.Unix(), 10), ttl)
+}
+
+// IsRevoked checks the token, its session and its user in one round trip
+func (s *redisRevocationStore) IsRevoked(ctx context.Context, claims *UserClaims) (bool, error) {
+	values, err := s.cache.GetMany(ctx,
+		revokedTokenKey(claims.ID),
+		revokedSessionKey(claims.Session()),
+		revokedUserKey(claims.UserID),
+	)
+	if err != nil {
+		return false, err
+	}
+
+	if (claims.ID != "" && values[0] != "") || (claims.Session() != "" && values[1] != "") {
+		return true, nil
+	}
+
+	if values[2] != "" {
+		revokedAt, err := strconv.ParseInt(values[2], 10, 64)
+		if err != nil {
+			return false, fmt.Errorf("invalid user revocation entry: %w", err)
+		}
+		// Tokens without iat cannot be proven newer than the revocation
+		if claims.IssuedAt == nil || claims.IssuedAt.Unix() <= revokedAt {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func revokedTokenKey(jti string) string         { return "auth:revoked:token:" + jti }
+func revokedSessionKey(sessionID string) string { return "auth:revoked:session:" + sessionID }
+func revokedUserKey(userID string) string       { return "auth:revoked:user:" + userID }
+
+// RevocationConfig holds token revocation configuration
+type RevocationConfig struct {
+	FailOpen         bool          // Accept tokens when the store is unavailable instead of rejecting them
+	MaxTokenLifetime time.Duration // Upper bound on token lifetime, used when a revocation has no natural expiry
+}
+
+// RevocationService checks and records token revocations
+type RevocationService struct {
+	store    RevocationStore
+	provider AuthProvider
+	config   RevocationConfig
+	logger   *logger.Logger
+}
+
+// NewRevocationService creates a new revocation service
+func NewRevocationService(store RevocationStore, provider AuthProvider, config RevocationConfig, logger *logger.Logger) *RevocationService {
+	if config.MaxTokenLifetime <= 0 {
+		config.MaxTokenLifetime = 24 * time.Hour
+	}
+	return &RevocationService{
+		store:    store,
+		provider: provider,
+		config:   config,
+		logger:   logger,
+	}
+}
+
+// Check returns ErrTokenRevoked for revoked tokens. When the store is unavailable it
+// returns ErrRevocationUnavailable, or nil if the service is configured to fail open.
+func (s *RevocationService) Check(ctx context.Context, claims *UserClaims) error {
+	revoked, err := s.store.IsRevoked(ctx, claims)
+	if err != nil {
+		if s.config.FailOpen {
+			s.logger.Warn("Token revocation check failed, accepting token", "error", err)
+			return nil
+		}
+		s.logger.Error("Token revocation check failed, rejecting token", "error", err)
+		return fmt.Errorf("%w: %v", ErrRevocationUnavailable, err)
+	}
+	if revoked {
+		return ErrTokenRevoked
+	}
+	return nil
+}
+
+// RevokeToken revokes a validated access token until it expires
+func (s *RevocationService) RevokeToken(ctx context.Context, claims *UserClaims) error {
+	return s.store.RevokeToken(ctx, claims.ID, s.remainingLifetime(claims.ExpiresAt))
+}
+
+// RevokeSession revokes every token of the identity provider session of validated access
+// token claims. Tokens that carry no session only revoke themselves. The session stays
+// revoked for MaxTokenLifetime, since its refresh tokens may outlive the access token.
+func (s *RevocationService) RevokeSession(ctx context.Context, claims *UserClaims) error {
+	if claims.Session() == "" {
+		return s.RevokeToken(ctx, claims)
+	}
+	if err := s.store.RevokeSession(ctx, claims.Session(), s.config.MaxTokenLifetime); err != nil {
+		return fmt.Errorf("failed to revoke session: %w", err)
+	}
+	return nil
+}
+
+// RevokeUserSessions revokes every token issued to the user so far and ends their
+// identity provider sessions when the provider supports it
+func (s *RevocationService) RevokeUserSessions(ctx context.Context, userID string) error {
+	if err := s.store.RevokeUser(ctx, userID, time.Now(), s.config.MaxTokenLifetime); err != nil {
+		return fmt.Errorf("failed to revoke user tokens: %w", err)
+	}
+
+	if terminator, ok := s.provider.(SessionTerminator); ok {
+		if err := terminator.LogoutUserSessions(ctx, userID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// remainingLifetime returns how long a revocation must be kept for a token expiring at expiresAt
+func (s *RevocationService) remainingLifetime(expiresAt *jwt.NumericDate) time.Duration {
+	if expiresAt == nil {
+		return s.config.MaxTokenLifetime
+	}
+	return time.Until(expiresAt.Time)
+}
@@ -56,6 +56,22 @@ func (s *CacheService) Exists(ctx context.Context, key string) (bool, error) {
 	return result.Val() > 0, nil
 }
 
+// GetMany retrieves several values in one round trip; missing keys are returned as empty strings
+func (s *CacheService) GetMany(ctx context.Context, keys ...string) ([]string, error) {
+	values, err := s.client.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]string, len(values))
+	for i, value := range values {
+		if str, ok := value.(string); ok {
+			result[i] = str
+		}
+	}
+	return result, nil
+}
+
 // Close closes the Redis connection
 func (s *CacheService) Close() error {
 	return s.client.Close()
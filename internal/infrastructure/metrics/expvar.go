@@ -0,0 +1,30 @@
+package metrics
+
+import (
+	"expvar"
+	"goclean/internal/application/bus"
+	"time"
+)
+
+// ExpvarRecorder records command and query metrics as expvar counters, which are
+// served as JSON by expvar.Handler. For each message it keeps
+// "<kind>.<name>.count", "<kind>.<name>.errors" and "<kind>.<name>.duration_us".
+type ExpvarRecorder struct {
+	messages *expvar.Map
+}
+
+// NewExpvarRecorder creates a new recorder publishing its counters under name.
+// Publishing the same name twice panics, so create one recorder per process.
+func NewExpvarRecorder(name string) *ExpvarRecorder {
+	return &ExpvarRecorder{messages: expvar.NewMap(name)}
+}
+
+// ObserveMessage implements bus.MetricsRecorder
+func (r *ExpvarRecorder) ObserveMessage(msg bus.Message, duration time.Duration, err error) {
+	prefix := string(msg.Kind) + "." + msg.Name
+	r.messages.Add(prefix+".count", 1)
+	if err != nil {
+		r.messages.Add(prefix+".errors", 1)
+	}
+	r.messages.Add(prefix+".duration_us", duration.Microseconds())
+}
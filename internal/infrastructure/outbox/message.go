@@ -0,0 +1,76 @@
+package outbox
+
+import (
+	"encoding/json"
+	"fmt"
+	"goclean/internal/domain/entities"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Message represents a domain event stored in the transactional outbox
+type Message struct {
+	ID            uuid.UUID       `json:"id" gorm:"type:uuid;primary_key"`
+	AggregateType string          `json:"aggregate_type" gorm:"type:varchar(100);not null;index"`
+	AggregateID   uuid.UUID       `json:"aggregate_id" gorm:"type:uuid;not null;index"`
+	EventType     string          `json:"event_type" gorm:"type:varchar(100);not null"`
+	Payload       json.RawMessage `json:"payload" gorm:"type:jsonb;not null"`
+	OccurredAt    time.Time       `json:"occurred_at" gorm:"not null"`
+	CreatedAt     time.Time       `json:"created_at" gorm:"autoCreateTime"`
+	Attempts      int             `json:"attempts" gorm:"not null;default:0"`
+	LastError     string          `json:"last_error,omitempty"`
+	NextAttemptAt time.Time       `json:"next_attempt_at" gorm:"not null;index"`
+	DeliveredAt   *time.Time      `json:"delivered_at,omitempty" gorm:"index"`
+}
+
+// TableName returns the table name for GORM
+func (m *Message) TableName() string {
+	return "outbox_messages"
+}
+
+// Append stores domain events in the outbox using the given transaction
+func Append(tx *gorm.DB, aggregateType string, aggregateID uuid.UUID, events []entities.DomainEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	messages := make([]Message, len(events))
+	for i, event := range events {
+		payload, err := json.Marshal(event)
+		if err != nil {
+			return fmt.Errorf("failed to serialize %s event: %w", event.EventType(), err)
+		}
+
+		messages[i] = Message{
+			ID:            uuid.New(),
+			AggregateType: aggregateType,
+			AggregateID:   aggregateID,
+			EventType:     event.EventType(),
+			Payload:       payload,
+			OccurredAt:    event.OccurredOn(),
+			NextAttemptAt: now,
+		}
+	}
+
+	return tx.Create(&messages).Error
+}
+
+// SaveWithEvents runs fn and stores the aggregate's pending domain events in the
+// same transaction. Events are cleared from the aggregate once the transaction commits.
+func SaveWithEvents(db *gorm.DB, aggregateType string, aggregateID uuid.UUID, aggregateRoot *entities.AggregateRoot, fn func(tx *gorm.DB) error) error {
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := fn(tx); err != nil {
+			return err
+		}
+		return Append(tx, aggregateType, aggregateID, aggregateRoot.DomainEvents())
+	})
+	if err != nil {
+		return err
+	}
+
+	aggregateRoot.ClearDomainEvents()
+	return nil
+}
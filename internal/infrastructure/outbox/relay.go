@@ -0,0 +1,171 @@
+package outbox
+
+import (
+	"context"
+	"goclean/internal/domain/entities"
+	"goclean/internal/domain/events"
+	"goclean/pkg/logger"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// RelayConfig holds outbox relay configuration
+type RelayConfig struct {
+	PollInterval time.Duration
+	BatchSize    int
+	MaxAttempts  int
+	BaseBackoff  time.Duration
+	MaxBackoff   time.Duration
+}
+
+// Relay delivers outbox messages to a domain event publisher
+type Relay struct {
+	db        *gorm.DB
+	registry  *events.EventRegistry
+	publisher events.DomainEventPublisher
+	config    RelayConfig
+	logger    *logger.Logger
+}
+
+// NewRelay creates a new outbox relay
+func NewRelay(
+	db *gorm.DB,
+	registry *events.EventRegistry,
+	publisher events.DomainEventPublisher,
+	config RelayConfig,
+	logger *logger.Logger,
+) *Relay {
+	if config.PollInterval <= 0 {
+		config.PollInterval = 2 * time.Second
+	}
+	if config.BatchSize <= 0 {
+		config.BatchSize = 100
+	}
+	if config.BaseBackoff <= 0 {
+		config.BaseBackoff = time.Second
+	}
+	if config.MaxBackoff < config.BaseBackoff {
+		config.MaxBackoff = config.BaseBackoff
+	}
+
+	return &Relay{
+		db:        db,
+		registry:  registry,
+		publisher: publisher,
+		config:    config,
+		logger:    logger,
+	}
+}
+
+// Run polls the outbox until the context is cancelled
+func (r *Relay) Run(ctx context.Context) {
+	r.logger.Info("Outbox relay started", "poll_interval", r.config.PollInterval)
+
+	ticker := time.NewTicker(r.config.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		// Drain the outbox before waiting for the next tick
+		for {
+			delivered, err := r.ProcessBatch(ctx)
+			if err != nil {
+				r.logger.Error("Failed to process outbox batch", "error", err)
+				break
+			}
+			if delivered < r.config.BatchSize {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			r.logger.Info("Outbox relay stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// ProcessBatch claims a batch of due messages, publishes them and records the outcome.
+// It returns the number of messages that were processed.
+func (r *Relay) ProcessBatch(ctx context.Context) (int, error) {
+	processed := 0
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var messages []Message
+		query := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("delivered_at IS NULL AND next_attempt_at <= ?", time.Now())
+		if r.config.MaxAttempts > 0 {
+			query = query.Where("attempts < ?", r.config.MaxAttempts)
+		}
+
+		if err := query.Order("created_at").Limit(r.config.BatchSize).Find(&messages).Error; err != nil {
+			return err
+		}
+
+		for i := range messages {
+			if err := r.deliver(ctx, tx, &messages[i]); err != nil {
+				return err
+			}
+			processed++
+		}
+
+		return nil
+	})
+
+	return processed, err
+}
+
+// deliver publishes a single message and marks it delivered or schedules a retry
+func (r *Relay) deliver(ctx context.Context, tx *gorm.DB, message *Message) error {
+	publishErr := r.publish(ctx, message)
+	if publishErr == nil {
+		now := time.Now()
+		return tx.Model(message).Updates(map[string]interface{}{
+			"delivered_at": now,
+			"attempts":     message.Attempts + 1,
+			"last_error":   "",
+		}).Error
+	}
+
+	attempts := message.Attempts + 1
+	nextAttempt := time.Now().Add(r.backoff(attempts))
+
+	r.logger.Warn("Failed to publish outbox message",
+		"message_id", message.ID,
+		"event_type", message.EventType,
+		"attempts", attempts,
+		"next_attempt_at", nextAttempt,
+		"error", publishErr,
+	)
+
+	return tx.Model(message).Updates(map[string]interface{}{
+		"attempts":        attempts,
+		"last_error":      publishErr.Error(),
+		"next_attempt_at": nextAttempt,
+	}).Error
+}
+
+// publish decodes the stored event and hands it to the publisher
+func (r *Relay) publish(ctx context.Context, message *Message) error {
+	event, err := r.registry.Decode(message.EventType, message.Payload)
+	if err != nil {
+		return err
+	}
+
+	return r.publisher.Publish(ctx, []entities.DomainEvent{event})
+}
+
+// backoff returns the exponential retry delay for the given attempt number
+func (r *Relay) backoff(attempts int) time.Duration {
+	delay := r.config.BaseBackoff
+	for i := 1; i < attempts; i++ {
+		delay *= 2
+		if delay >= r.config.MaxBackoff {
+			return r.config.MaxBackoff
+		}
+	}
+	return delay
+}
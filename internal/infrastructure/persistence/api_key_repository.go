@@ -0,0 +1,73 @@
+package persistence
+
+import (
+	"context"
+	"goclean/internal/domain/entities"
+	"goclean/internal/domain/repositories"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// APIKeyGormRepository implements APIKeyRepository using GORM
+type APIKeyGormRepository struct {
+	db *gorm.DB
+}
+
+// NewAPIKeyGormRepository creates a new API key GORM repository
+func NewAPIKeyGormRepository(db *gorm.DB) repositories.APIKeyRepository {
+	return &APIKeyGormRepository{db: db}
+}
+
+// Create creates a new API key
+func (r *APIKeyGormRepository) Create(ctx context.Context, key *entities.APIKey) error {
+	return TranslateError(DB(ctx, r.db).Create(key).Error)
+}
+
+// GetByID retrieves an API key by ID
+func (r *APIKeyGormRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.APIKey, error) {
+	var key entities.APIKey
+	err := DB(ctx, r.db).Where("id = ?", id).First(&key).Error
+	if err != nil {
+		return nil, TranslateError(err)
+	}
+	return &key, nil
+}
+
+// GetByKeyID retrieves an API key by the public key ID embedded in the plaintext key
+func (r *APIKeyGormRepository) GetByKeyID(ctx context.Context, keyID string) (*entities.APIKey, error) {
+	var key entities.APIKey
+	err := DB(ctx, r.db).Where("key_id = ?", keyID).First(&key).Error
+	if err != nil {
+		return nil, TranslateError(err)
+	}
+	return &key, nil
+}
+
+// Update updates an API key
+func (r *APIKeyGormRepository) Update(ctx context.Context, key *entities.APIKey) error {
+	return TranslateError(DB(ctx, r.db).Save(key).Error)
+}
+
+// UpdateLastUsed records when an API key was last used
+func (r *APIKeyGormRepository) UpdateLastUsed(ctx context.Context, id uuid.UUID, usedAt time.Time) error {
+	return DB(ctx, r.db).Model(&entities.APIKey{}).
+		Where("id = ?", id).
+		UpdateColumn("last_used_at", usedAt).Error
+}
+
+// List lists API keys, newest first
+func (r *APIKeyGormRepository) List(ctx context.Context, offset, limit int) ([]*entities.APIKey, error) {
+	var keys []*entities.APIKey
+	err := DB(ctx, r.db).Order("created_at DESC").Offset(offset).Limit(limit).Find(&keys).Error
+	return keys, err
+}
+
+// ListByOwnerID lists the API keys of an owner, newest first
+func (r *APIKeyGormRepository) ListByOwnerID(ctx context.Context, ownerID uuid.UUID, offset, limit int) ([]*entities.APIKey, error) {
+	var keys []*entities.APIKey
+	err := DB(ctx, r.db).Where("owner_id = ?", ownerID).
+		Order("created_at DESC, id").Offset(offset).Limit(limit).Find(&keys).Error
+	return keys, err
+}
@@ -3,7 +3,10 @@ package persistence
 import (
 	"fmt"
 	"goclean/internal/domain/entities"
+	"goclean/internal/infrastructure/outbox"
+	"math"
 
+	"github.com/google/uuid"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
@@ -26,6 +29,8 @@ func NewDatabase(config DatabaseConfig) (*gorm.DB, error) {
 
 	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{
 		Logger: logger.Default.LogMode(logger.Info),
+		// Report unique violations as gorm.ErrDuplicatedKey, see TranslateError
+		TranslateError: true,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to database: %w", err)
@@ -36,11 +41,98 @@ func NewDatabase(config DatabaseConfig) (*gorm.DB, error) {
 
 // MigrateDatabase runs database migrations
 func MigrateDatabase(db *gorm.DB) error {
-	return db.AutoMigrate(
+	if err := migrateLegacyPriceColumns(db); err != nil {
+		return fmt.Errorf("failed to migrate price columns: %w", err)
+	}
+
+	err := db.AutoMigrate(
 		&entities.User{},
 		&entities.Profile{},
 		&entities.Product{},
 		&entities.Order{},
 		&entities.OrderItem{},
+		&entities.OrderStatusHistory{},
+		&entities.InventoryItem{},
+		&entities.StockReservation{},
+		&entities.APIKey{},
+		&outbox.Message{},
 	)
+	if err != nil {
+		return err
+	}
+
+	if err := backfillInventory(db); err != nil {
+		return fmt.Errorf("failed to backfill inventory: %w", err)
+	}
+	return nil
+}
+
+// backfillInventory creates an empty inventory record for every product from before stock
+// was tracked, so its stock level can be read and restocked. Deleted products are included
+// so they can still be restored and sold.
+func backfillInventory(db *gorm.DB) error {
+	var productIDs []uuid.UUID
+	err := db.Unscoped().Model(&entities.Product{}).
+		Where("NOT EXISTS (SELECT 1 FROM inventory_items WHERE inventory_items.product_id = products.id)").
+		Pluck("id", &productIDs).Error
+	if err != nil || len(productIDs) == 0 {
+		return err
+	}
+
+	items := make([]*entities.InventoryItem, len(productIDs))
+	for i, productID := range productIDs {
+		items[i] = entities.NewInventoryItem(productID, 0)
+	}
+	return db.CreateInBatches(items, 100).Error
+}
+
+// legacyPriceColumns lists float price columns replaced by Money columns
+var legacyPriceColumns = []struct {
+	model  interface{}
+	column string
+	prefix string
+}{
+	{&entities.Product{}, "price", "price_"},
+	{&entities.Order{}, "total_price", "total_price_"},
+	{&entities.OrderItem{}, "price", "price_"},
+}
+
+// migrateLegacyPriceColumns converts float price columns from earlier schema versions
+// into integer minor units in the default currency, then drops the float column.
+func migrateLegacyPriceColumns(db *gorm.DB) error {
+	migrator := db.Migrator()
+	scale := math.Pow10(entities.CurrencyExponent(entities.DefaultCurrency))
+
+	for _, legacy := range legacyPriceColumns {
+		if !migrator.HasTable(legacy.model) || !migrator.HasColumn(legacy.model, legacy.column) ||
+			migrator.HasColumn(legacy.model, legacy.prefix+"amount") {
+			continue
+		}
+
+		stmt := &gorm.Statement{DB: db}
+		if err := stmt.Parse(legacy.model); err != nil {
+			return err
+		}
+		table := stmt.Schema.Table
+
+		err := db.Transaction(func(tx *gorm.DB) error {
+			statements := []string{
+				fmt.Sprintf("ALTER TABLE %s ADD COLUMN %samount bigint NOT NULL DEFAULT 0", table, legacy.prefix),
+				fmt.Sprintf("ALTER TABLE %s ADD COLUMN %scurrency char(3) NOT NULL DEFAULT '%s'", table, legacy.prefix, entities.DefaultCurrency),
+				fmt.Sprintf("UPDATE %s SET %samount = ROUND(%s * %v)", table, legacy.prefix, legacy.column, scale),
+				fmt.Sprintf("ALTER TABLE %s DROP COLUMN %s", table, legacy.column),
+			}
+			for _, sql := range statements {
+				if err := tx.Exec(sql).Error; err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("table %s: %w", table, err)
+		}
+	}
+
+	return nil
 }
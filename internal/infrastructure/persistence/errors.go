@@ -0,0 +1,23 @@
+package persistence
+
+import (
+	"errors"
+	"goclean/internal/domain/repositories"
+
+	"gorm.io/gorm"
+)
+
+// TranslateError maps GORM errors to repository errors so callers never depend on GORM:
+// a missing record becomes repositories.ErrNotFound and a unique constraint violation
+// becomes repositories.ErrDuplicate. Other errors are returned unchanged.
+// Unique violations are only recognised with gorm.Config.TranslateError enabled.
+func TranslateError(err error) error {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return repositories.ErrNotFound
+	case errors.Is(err, gorm.ErrDuplicatedKey):
+		return repositories.ErrDuplicate
+	default:
+		return err
+	}
+}
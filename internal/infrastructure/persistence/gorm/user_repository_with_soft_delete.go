@@ -2,9 +2,11 @@ package gorm
 
 import (
 	"context"
-	"errors"
 	"goclean/internal/domain/entities"
 	"goclean/internal/domain/repositories"
+	"goclean/internal/infrastructure/outbox"
+	"goclean/internal/infrastructure/persistence"
+	"time"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
@@ -20,20 +22,19 @@ func NewUserRepository(db *gorm.DB) repositories.UserRepository {
 	return &userRepository{db: db}
 }
 
-// Create creates a new user
+// Create creates a new user and stores its domain events in the outbox
 func (r *userRepository) Create(ctx context.Context, user *entities.User) error {
-	return r.db.WithContext(ctx).Create(user).Error
+	return outbox.SaveWithEvents(persistence.DB(ctx, r.db), "User", user.ID, &user.AggregateRoot, func(tx *gorm.DB) error {
+		return persistence.TranslateError(tx.Create(user).Error)
+	})
 }
 
 // GetByID gets a user by ID (excludes soft deleted)
 func (r *userRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.User, error) {
 	var user entities.User
-	err := r.db.WithContext(ctx).Preload("Profile").First(&user, "id = ?", id).Error
+	err := persistence.DB(ctx, r.db).Preload("Profile").First(&user, "id = ?", id).Error
 	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("user not found")
-		}
-		return nil, err
+		return nil, persistence.TranslateError(err)
 	}
 	return &user, nil
 }
@@ -41,12 +42,9 @@ func (r *userRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.U
 // GetByIDIncludeDeleted gets a user by ID (includes soft deleted)
 func (r *userRepository) GetByIDIncludeDeleted(ctx context.Context, id uuid.UUID) (*entities.User, error) {
 	var user entities.User
-	err := r.db.WithContext(ctx).Unscoped().Preload("Profile").First(&user, "id = ?", id).Error
+	err := persistence.DB(ctx, r.db).Unscoped().Preload("Profile").First(&user, "id = ?", id).Error
 	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("user not found")
-		}
-		return nil, err
+		return nil, persistence.TranslateError(err)
 	}
 	return &user, nil
 }
@@ -54,12 +52,9 @@ func (r *userRepository) GetByIDIncludeDeleted(ctx context.Context, id uuid.UUID
 // GetByEmail gets a user by email (excludes soft deleted)
 func (r *userRepository) GetByEmail(ctx context.Context, email string) (*entities.User, error) {
 	var user entities.User
-	err := r.db.WithContext(ctx).Preload("Profile").First(&user, "email = ?", email).Error
+	err := persistence.DB(ctx, r.db).Preload("Profile").First(&user, "email = ?", email).Error
 	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("user not found")
-		}
-		return nil, err
+		return nil, persistence.TranslateError(err)
 	}
 	return &user, nil
 }
@@ -67,42 +62,45 @@ func (r *userRepository) GetByEmail(ctx context.Context, email string) (*entitie
 // GetByUsername gets a user by username (excludes soft deleted)
 func (r *userRepository) GetByUsername(ctx context.Context, username string) (*entities.User, error) {
 	var user entities.User
-	err := r.db.WithContext(ctx).Preload("Profile").First(&user, "username = ?", username).Error
+	err := persistence.DB(ctx, r.db).Preload("Profile").First(&user, "username = ?", username).Error
 	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("user not found")
-		}
-		return nil, err
+		return nil, persistence.TranslateError(err)
 	}
 	return &user, nil
 }
 
-// Update updates a user
+// Update updates a user and stores its domain events in the outbox
 func (r *userRepository) Update(ctx context.Context, user *entities.User) error {
-	return r.db.WithContext(ctx).Save(user).Error
+	return outbox.SaveWithEvents(persistence.DB(ctx, r.db), "User", user.ID, &user.AggregateRoot, func(tx *gorm.DB) error {
+		return persistence.SaveSoftDeletable(tx, &entities.User{}, &user.BaseEntity, func(tx *gorm.DB) error {
+			return persistence.TranslateError(tx.Save(user).Error)
+		}, persistence.UserDependents...)
+	})
 }
 
 // Delete permanently deletes a user (hard delete)
 func (r *userRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	return r.db.WithContext(ctx).Unscoped().Delete(&entities.User{}, "id = ?", id).Error
+	return persistence.DB(ctx, r.db).Unscoped().Delete(&entities.User{}, "id = ?", id).Error
 }
 
-// SoftDelete soft deletes a user
+// SoftDelete soft deletes a user and its profile
 func (r *userRepository) SoftDelete(ctx context.Context, id uuid.UUID) error {
-	return r.db.WithContext(ctx).Delete(&entities.User{}, "id = ?", id).Error
+	return persistence.DB(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		return persistence.SoftDeleteByID(tx, &entities.User{}, id, persistence.UserDependents...)
+	})
 }
 
-// Restore restores a soft deleted user
+// Restore restores a soft deleted user and the profile deleted along with it
 func (r *userRepository) Restore(ctx context.Context, id uuid.UUID) error {
-	return r.db.WithContext(ctx).Model(&entities.User{}).Unscoped().
-		Where("id = ? AND deleted_at IS NOT NULL", id).
-		Update("deleted_at", nil).Error
+	return persistence.DB(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		return persistence.RestoreByID(tx, &entities.User{}, id, persistence.UserDependents...)
+	})
 }
 
 // List lists users with pagination (excludes soft deleted)
 func (r *userRepository) List(ctx context.Context, offset, limit int) ([]*entities.User, error) {
 	var users []*entities.User
-	err := r.db.WithContext(ctx).Preload("Profile").
+	err := persistence.DB(ctx, r.db).Preload("Profile").
 		Offset(offset).Limit(limit).
 		Find(&users).Error
 	return users, err
@@ -111,7 +109,7 @@ func (r *userRepository) List(ctx context.Context, offset, limit int) ([]*entiti
 // ListIncludeDeleted lists users with pagination (includes soft deleted)
 func (r *userRepository) ListIncludeDeleted(ctx context.Context, offset, limit int) ([]*entities.User, error) {
 	var users []*entities.User
-	err := r.db.WithContext(ctx).Unscoped().Preload("Profile").
+	err := persistence.DB(ctx, r.db).Unscoped().Preload("Profile").
 		Offset(offset).Limit(limit).
 		Find(&users).Error
 	return users, err
@@ -120,9 +118,19 @@ func (r *userRepository) ListIncludeDeleted(ctx context.Context, offset, limit i
 // ListDeleted lists only soft deleted users with pagination
 func (r *userRepository) ListDeleted(ctx context.Context, offset, limit int) ([]*entities.User, error) {
 	var users []*entities.User
-	err := r.db.WithContext(ctx).Unscoped().Preload("Profile").
+	err := persistence.DB(ctx, r.db).Unscoped().Preload("Profile").
 		Where("deleted_at IS NOT NULL").
 		Offset(offset).Limit(limit).
 		Find(&users).Error
 	return users, err
 }
+
+// ListDeletedBefore retrieves users soft deleted before the given time, oldest deletions first
+func (r *userRepository) ListDeletedBefore(ctx context.Context, before time.Time, offset, limit int) ([]*entities.User, error) {
+	var users []*entities.User
+	err := persistence.DB(ctx, r.db).Unscoped().Preload("Profile").
+		Where("deleted_at IS NOT NULL AND deleted_at < ?", before).
+		Order("deleted_at, id").
+		Offset(offset).Limit(limit).Find(&users).Error
+	return users, err
+}
@@ -0,0 +1,105 @@
+package persistence
+
+import (
+	"context"
+	"goclean/internal/domain/entities"
+	"goclean/internal/domain/repositories"
+	"goclean/internal/infrastructure/outbox"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// InventoryGormRepository implements InventoryRepository using GORM
+type InventoryGormRepository struct {
+	db *gorm.DB
+}
+
+// NewInventoryGormRepository creates a new inventory GORM repository
+func NewInventoryGormRepository(db *gorm.DB) repositories.InventoryRepository {
+	return &InventoryGormRepository{db: db}
+}
+
+// Create creates a new inventory item and stores its domain events in the outbox
+func (r *InventoryGormRepository) Create(ctx context.Context, item *entities.InventoryItem) error {
+	return outbox.SaveWithEvents(DB(ctx, r.db), "Inventory", item.ID, &item.AggregateRoot, func(tx *gorm.DB) error {
+		return TranslateError(tx.Create(item).Error)
+	})
+}
+
+// GetByProductID retrieves the inventory item of a product
+func (r *InventoryGormRepository) GetByProductID(ctx context.Context, productID uuid.UUID) (*entities.InventoryItem, error) {
+	var item entities.InventoryItem
+	err := DB(ctx, r.db).Where("product_id = ?", productID).First(&item).Error
+	if err != nil {
+		return nil, TranslateError(err)
+	}
+	return &item, nil
+}
+
+// GetByProductIDForUpdate retrieves the inventory item of a product with SELECT ... FOR UPDATE.
+// It must run inside a unit of work for the lock to be held until commit.
+func (r *InventoryGormRepository) GetByProductIDForUpdate(ctx context.Context, productID uuid.UUID) (*entities.InventoryItem, error) {
+	var item entities.InventoryItem
+	err := DB(ctx, r.db).Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("product_id = ?", productID).First(&item).Error
+	if err != nil {
+		return nil, TranslateError(err)
+	}
+	return &item, nil
+}
+
+// Update saves the stock levels if the version is unchanged and stores domain events in the outbox
+func (r *InventoryGormRepository) Update(ctx context.Context, item *entities.InventoryItem) error {
+	return outbox.SaveWithEvents(DB(ctx, r.db), "Inventory", item.ID, &item.AggregateRoot, func(tx *gorm.DB) error {
+		result := tx.Model(&entities.InventoryItem{}).
+			Where("id = ? AND version = ?", item.ID, item.Version).
+			Updates(map[string]interface{}{
+				"on_hand":    item.OnHand,
+				"reserved":   item.Reserved,
+				"version":    item.Version + 1,
+				"updated_at": time.Now(),
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return repositories.ErrConcurrentModification
+		}
+
+		item.Version++
+		return nil
+	})
+}
+
+// StockReservationGormRepository implements StockReservationRepository using GORM
+type StockReservationGormRepository struct {
+	db *gorm.DB
+}
+
+// NewStockReservationGormRepository creates a new stock reservation GORM repository
+func NewStockReservationGormRepository(db *gorm.DB) repositories.StockReservationRepository {
+	return &StockReservationGormRepository{db: db}
+}
+
+// Create creates a new stock reservation
+func (r *StockReservationGormRepository) Create(ctx context.Context, reservation *entities.StockReservation) error {
+	return TranslateError(DB(ctx, r.db).Create(reservation).Error)
+}
+
+// GetActiveByOrderID retrieves the reservations still held for an order
+func (r *StockReservationGormRepository) GetActiveByOrderID(ctx context.Context, orderID uuid.UUID) ([]*entities.StockReservation, error) {
+	var reservations []*entities.StockReservation
+	err := DB(ctx, r.db).
+		Where("order_id = ? AND status = ?", orderID, entities.ReservationStatusActive).
+		Order("product_id").
+		Find(&reservations).Error
+	return reservations, err
+}
+
+// Update updates a stock reservation
+func (r *StockReservationGormRepository) Update(ctx context.Context, reservation *entities.StockReservation) error {
+	return TranslateError(DB(ctx, r.db).Save(reservation).Error)
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"goclean/internal/domain/entities"
 	"goclean/internal/domain/repositories"
+	"goclean/internal/infrastructure/outbox"
+	"time"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
@@ -19,17 +21,19 @@ func NewProductGormRepository(db *gorm.DB) repositories.ProductRepository {
 	return &ProductGormRepository{db: db}
 }
 
-// Create creates a new product
+// Create creates a new product and stores its domain events in the outbox
 func (r *ProductGormRepository) Create(ctx context.Context, product *entities.Product) error {
-	return r.db.WithContext(ctx).Create(product).Error
+	return outbox.SaveWithEvents(DB(ctx, r.db), "Product", product.ID, &product.AggregateRoot, func(tx *gorm.DB) error {
+		return TranslateError(tx.Create(product).Error)
+	})
 }
 
 // GetByID retrieves a product by ID
 func (r *ProductGormRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.Product, error) {
 	var product entities.Product
-	err := r.db.WithContext(ctx).Where("id = ?", id).First(&product).Error
+	err := DB(ctx, r.db).Where("id = ?", id).First(&product).Error
 	if err != nil {
-		return nil, err
+		return nil, TranslateError(err)
 	}
 	return &product, nil
 }
@@ -37,72 +41,91 @@ func (r *ProductGormRepository) GetByID(ctx context.Context, id uuid.UUID) (*ent
 // GetBySKU retrieves a product by SKU
 func (r *ProductGormRepository) GetBySKU(ctx context.Context, sku string) (*entities.Product, error) {
 	var product entities.Product
-	err := r.db.WithContext(ctx).Where("sku = ?", sku).First(&product).Error
+	err := DB(ctx, r.db).Where("sku = ?", sku).First(&product).Error
 	if err != nil {
-		return nil, err
+		return nil, TranslateError(err)
 	}
 	return &product, nil
 }
 
-// Update updates a product
+// Update updates a product and stores its domain events in the outbox
 func (r *ProductGormRepository) Update(ctx context.Context, product *entities.Product) error {
-	return r.db.WithContext(ctx).Save(product).Error
+	return outbox.SaveWithEvents(DB(ctx, r.db), "Product", product.ID, &product.AggregateRoot, func(tx *gorm.DB) error {
+		return SaveSoftDeletable(tx, &entities.Product{}, &product.BaseEntity, func(tx *gorm.DB) error {
+			return TranslateError(tx.Save(product).Error)
+		})
+	})
 }
 
-// Delete deletes a product (hard delete)
+// Delete deletes a product with its inventory record and stock reservations (hard delete)
 func (r *ProductGormRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	return r.db.WithContext(ctx).Unscoped().Delete(&entities.Product{}, "id = ?", id).Error
+	return DB(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		for _, model := range []interface{}{&entities.StockReservation{}, &entities.InventoryItem{}} {
+			if err := tx.Unscoped().Delete(model, "product_id = ?", id).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Unscoped().Delete(&entities.Product{}, "id = ?", id).Error
+	})
 }
 
 // GetByIDIncludeDeleted gets a product by ID including soft deleted
 func (r *ProductGormRepository) GetByIDIncludeDeleted(ctx context.Context, id uuid.UUID) (*entities.Product, error) {
 	var product entities.Product
-	err := r.db.WithContext(ctx).Unscoped().Where("id = ?", id).First(&product).Error
+	err := DB(ctx, r.db).Unscoped().Where("id = ?", id).First(&product).Error
 	if err != nil {
-		return nil, err
+		return nil, TranslateError(err)
 	}
 	return &product, nil
 }
 
 // SoftDelete soft deletes a product
 func (r *ProductGormRepository) SoftDelete(ctx context.Context, id uuid.UUID) error {
-	return r.db.WithContext(ctx).Delete(&entities.Product{}, "id = ?", id).Error
+	return SoftDeleteByID(DB(ctx, r.db), &entities.Product{}, id)
 }
 
 // Restore restores a soft deleted product
 func (r *ProductGormRepository) Restore(ctx context.Context, id uuid.UUID) error {
-	return r.db.WithContext(ctx).Model(&entities.Product{}).Unscoped().
-		Where("id = ? AND deleted_at IS NOT NULL", id).
-		Update("deleted_at", nil).Error
+	return RestoreByID(DB(ctx, r.db), &entities.Product{}, id)
 }
 
 // ListIncludeDeleted lists products including soft deleted
 func (r *ProductGormRepository) ListIncludeDeleted(ctx context.Context, offset, limit int) ([]*entities.Product, error) {
 	var products []*entities.Product
-	err := r.db.WithContext(ctx).Unscoped().Offset(offset).Limit(limit).Find(&products).Error
+	err := DB(ctx, r.db).Unscoped().Offset(offset).Limit(limit).Find(&products).Error
 	return products, err
 }
 
 // ListDeleted lists only soft deleted products
 func (r *ProductGormRepository) ListDeleted(ctx context.Context, offset, limit int) ([]*entities.Product, error) {
 	var products []*entities.Product
-	err := r.db.WithContext(ctx).Unscoped().
+	err := DB(ctx, r.db).Unscoped().
 		Where("deleted_at IS NOT NULL").
 		Offset(offset).Limit(limit).Find(&products).Error
 	return products, err
 }
 
+// ListDeletedBefore retrieves products soft deleted before the given time, oldest deletions first
+func (r *ProductGormRepository) ListDeletedBefore(ctx context.Context, before time.Time, offset, limit int) ([]*entities.Product, error) {
+	var products []*entities.Product
+	err := DB(ctx, r.db).Unscoped().
+		Where("deleted_at IS NOT NULL AND deleted_at < ?", before).
+		Order("deleted_at, id").
+		Offset(offset).Limit(limit).Find(&products).Error
+	return products, err
+}
+
 // List retrieves products with pagination
 func (r *ProductGormRepository) List(ctx context.Context, offset, limit int) ([]*entities.Product, error) {
 	var products []*entities.Product
-	err := r.db.WithContext(ctx).Offset(offset).Limit(limit).Find(&products).Error
+	err := DB(ctx, r.db).Offset(offset).Limit(limit).Find(&products).Error
 	return products, err
 }
 
 // ListByCategory retrieves products by category with pagination
 func (r *ProductGormRepository) ListByCategory(ctx context.Context, category string, offset, limit int) ([]*entities.Product, error) {
 	var products []*entities.Product
-	err := r.db.WithContext(ctx).Where("category = ?", category).Offset(offset).Limit(limit).Find(&products).Error
+	err := DB(ctx, r.db).Where("category = ?", category).Offset(offset).Limit(limit).Find(&products).Error
 	return products, err
 }
 
@@ -110,11 +133,16 @@ func (r *ProductGormRepository) ListByCategory(ctx context.Context, category str
 func (r *ProductGormRepository) Search(ctx context.Context, query string, offset, limit int) ([]*entities.Product, error) {
 	var products []*entities.Product
 	searchQuery := "%" + query + "%"
-	err := r.db.WithContext(ctx).Where("name ILIKE ? OR description ILIKE ?", searchQuery, searchQuery).
+	err := DB(ctx, r.db).Where("name ILIKE ? OR description ILIKE ?", searchQuery, searchQuery).
 		Offset(offset).Limit(limit).Find(&products).Error
 	return products, err
 }
 
+// orderStatusHistoryByTime orders preloaded status history from oldest to newest
+func orderStatusHistoryByTime(db *gorm.DB) *gorm.DB {
+	return db.Order("changed_at ASC")
+}
+
 // OrderGormRepository implements OrderRepository using GORM
 type OrderGormRepository struct {
 	db *gorm.DB
@@ -125,17 +153,19 @@ func NewOrderGormRepository(db *gorm.DB) repositories.OrderRepository {
 	return &OrderGormRepository{db: db}
 }
 
-// Create creates a new order
+// Create creates a new order and stores its domain events in the outbox
 func (r *OrderGormRepository) Create(ctx context.Context, order *entities.Order) error {
-	return r.db.WithContext(ctx).Create(order).Error
+	return outbox.SaveWithEvents(DB(ctx, r.db), "Order", order.ID, &order.AggregateRoot, func(tx *gorm.DB) error {
+		return TranslateError(tx.Create(order).Error)
+	})
 }
 
 // GetByID retrieves an order by ID
 func (r *OrderGormRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.Order, error) {
 	var order entities.Order
-	err := r.db.WithContext(ctx).Preload("Items").Where("id = ?", id).First(&order).Error
+	err := DB(ctx, r.db).Preload("Items").Preload("StatusHistory", orderStatusHistoryByTime).Where("id = ?", id).First(&order).Error
 	if err != nil {
-		return nil, err
+		return nil, TranslateError(err)
 	}
 	return &order, nil
 }
@@ -143,67 +173,109 @@ func (r *OrderGormRepository) GetByID(ctx context.Context, id uuid.UUID) (*entit
 // GetByUserID retrieves orders by user ID with pagination
 func (r *OrderGormRepository) GetByUserID(ctx context.Context, userID uuid.UUID, offset, limit int) ([]*entities.Order, error) {
 	var orders []*entities.Order
-	err := r.db.WithContext(ctx).Preload("Items").Where("user_id = ?", userID).
+	err := DB(ctx, r.db).Preload("Items").Preload("StatusHistory", orderStatusHistoryByTime).Where("user_id = ?", userID).
 		Offset(offset).Limit(limit).Find(&orders).Error
 	return orders, err
 }
 
-// Update updates an order
+// GetByUserIDIncludeDeleted gets orders by user ID including soft deleted, oldest first
+func (r *OrderGormRepository) GetByUserIDIncludeDeleted(ctx context.Context, userID uuid.UUID, offset, limit int) ([]*entities.Order, error) {
+	var orders []*entities.Order
+	err := DB(ctx, r.db).Unscoped().Preload("Items").Preload("StatusHistory", orderStatusHistoryByTime).Where("user_id = ?", userID).
+		Order("created_at, id").
+		Offset(offset).Limit(limit).Find(&orders).Error
+	return orders, err
+}
+
+// ListStatusChangesBy retrieves the order status changes made by a user, oldest first
+func (r *OrderGormRepository) ListStatusChangesBy(ctx context.Context, userID uuid.UUID, offset, limit int) ([]*entities.OrderStatusHistory, error) {
+	var changes []*entities.OrderStatusHistory
+	err := DB(ctx, r.db).Unscoped().Where("changed_by = ?", userID).
+		Order("changed_at, id").
+		Offset(offset).Limit(limit).Find(&changes).Error
+	return changes, err
+}
+
+// Update updates an order and stores its domain events in the outbox
 func (r *OrderGormRepository) Update(ctx context.Context, order *entities.Order) error {
-	return r.db.WithContext(ctx).Save(order).Error
+	return outbox.SaveWithEvents(DB(ctx, r.db), "Order", order.ID, &order.AggregateRoot, func(tx *gorm.DB) error {
+		return SaveSoftDeletable(tx, &entities.Order{}, &order.BaseEntity, func(tx *gorm.DB) error {
+			return TranslateError(tx.Save(order).Error)
+		}, OrderDependents...)
+	})
 }
 
-// Delete deletes an order (hard delete)
+// Delete deletes an order with its items, status history and stock reservations (hard delete).
+// Dependents are deleted explicitly since AutoMigrate does not add the cascade to existing tables.
 func (r *OrderGormRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	return r.db.WithContext(ctx).Unscoped().Delete(&entities.Order{}, "id = ?", id).Error
+	return DB(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		for _, model := range []interface{}{&entities.StockReservation{}, &entities.OrderItem{}, &entities.OrderStatusHistory{}} {
+			if err := tx.Unscoped().Delete(model, "order_id = ?", id).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Unscoped().Delete(&entities.Order{}, "id = ?", id).Error
+	})
 }
 
 // GetByIDIncludeDeleted gets an order by ID including soft deleted
 func (r *OrderGormRepository) GetByIDIncludeDeleted(ctx context.Context, id uuid.UUID) (*entities.Order, error) {
 	var order entities.Order
-	err := r.db.WithContext(ctx).Unscoped().Preload("Items").Where("id = ?", id).First(&order).Error
+	err := DB(ctx, r.db).Unscoped().Preload("Items").Preload("StatusHistory", orderStatusHistoryByTime).Where("id = ?", id).First(&order).Error
 	if err != nil {
-		return nil, err
+		return nil, TranslateError(err)
 	}
 	return &order, nil
 }
 
-// SoftDelete soft deletes an order
+// SoftDelete soft deletes an order and its items
 func (r *OrderGormRepository) SoftDelete(ctx context.Context, id uuid.UUID) error {
-	return r.db.WithContext(ctx).Delete(&entities.Order{}, "id = ?", id).Error
+	return DB(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		return SoftDeleteByID(tx, &entities.Order{}, id, OrderDependents...)
+	})
 }
 
-// Restore restores a soft deleted order
+// Restore restores a soft deleted order and the items deleted along with it
 func (r *OrderGormRepository) Restore(ctx context.Context, id uuid.UUID) error {
-	return r.db.WithContext(ctx).Model(&entities.Order{}).Unscoped().
-		Where("id = ? AND deleted_at IS NOT NULL", id).
-		Update("deleted_at", nil).Error
+	return DB(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		return RestoreByID(tx, &entities.Order{}, id, OrderDependents...)
+	})
 }
 
 // ListIncludeDeleted lists orders including soft deleted
 func (r *OrderGormRepository) ListIncludeDeleted(ctx context.Context, offset, limit int) ([]*entities.Order, error) {
 	var orders []*entities.Order
-	err := r.db.WithContext(ctx).Unscoped().Preload("Items").Offset(offset).Limit(limit).Find(&orders).Error
+	err := DB(ctx, r.db).Unscoped().Preload("Items").Preload("StatusHistory", orderStatusHistoryByTime).Offset(offset).Limit(limit).Find(&orders).Error
 	return orders, err
 }
 
 // ListDeleted lists only soft deleted orders
 func (r *OrderGormRepository) ListDeleted(ctx context.Context, offset, limit int) ([]*entities.Order, error) {
 	var orders []*entities.Order
-	err := r.db.WithContext(ctx).Unscoped().Preload("Items").
+	err := DB(ctx, r.db).Unscoped().Preload("Items").Preload("StatusHistory", orderStatusHistoryByTime).
 		Where("deleted_at IS NOT NULL").
 		Offset(offset).Limit(limit).Find(&orders).Error
 	return orders, err
 }
 
+// ListDeletedBefore retrieves orders soft deleted before the given time, oldest deletions first
+func (r *OrderGormRepository) ListDeletedBefore(ctx context.Context, before time.Time, offset, limit int) ([]*entities.Order, error) {
+	var orders []*entities.Order
+	err := DB(ctx, r.db).Unscoped().Preload("Items").Preload("StatusHistory", orderStatusHistoryByTime).
+		Where("deleted_at IS NOT NULL AND deleted_at < ?", before).
+		Order("deleted_at, id").
+		Offset(offset).Limit(limit).Find(&orders).Error
+	return orders, err
+}
+
 // List retrieves orders with pagination
 func (r *OrderGormRepository) List(ctx context.Context, offset, limit int) ([]*entities.Order, error) {
 	var orders []*entities.Order
-	err := r.db.WithContext(ctx).Preload("Items").Offset(offset).Limit(limit).Find(&orders).Error
+	err := DB(ctx, r.db).Preload("Items").Preload("StatusHistory", orderStatusHistoryByTime).Offset(offset).Limit(limit).Find(&orders).Error
 	return orders, err
 }
 
 // UpdateStatus updates order status
 func (r *OrderGormRepository) UpdateStatus(ctx context.Context, id uuid.UUID, status entities.OrderStatus) error {
-	return r.db.WithContext(ctx).Model(&entities.Order{}).Where("id = ?", id).Update("status", status).Error
+	return DB(ctx, r.db).Model(&entities.Order{}).Where("id = ?", id).Update("status", status).Error
 }
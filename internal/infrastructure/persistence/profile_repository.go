@@ -4,6 +4,8 @@ import (
 	"context"
 	"goclean/internal/domain/entities"
 	"goclean/internal/domain/repositories"
+	"goclean/internal/infrastructure/outbox"
+	"time"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
@@ -21,15 +23,15 @@ func NewProfileGormRepository(db *gorm.DB) repositories.ProfileRepository {
 
 // Create creates a new profile
 func (r *ProfileGormRepository) Create(ctx context.Context, profile *entities.Profile) error {
-	return r.db.WithContext(ctx).Create(profile).Error
+	return TranslateError(DB(ctx, r.db).Create(profile).Error)
 }
 
 // GetByID retrieves a profile by ID
 func (r *ProfileGormRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.Profile, error) {
 	var profile entities.Profile
-	err := r.db.WithContext(ctx).Where("id = ?", id).First(&profile).Error
+	err := DB(ctx, r.db).Where("id = ?", id).First(&profile).Error
 	if err != nil {
-		return nil, err
+		return nil, TranslateError(err)
 	}
 	return &profile, nil
 }
@@ -37,9 +39,9 @@ func (r *ProfileGormRepository) GetByID(ctx context.Context, id uuid.UUID) (*ent
 // GetByIDIncludeDeleted gets a profile by ID including soft deleted
 func (r *ProfileGormRepository) GetByIDIncludeDeleted(ctx context.Context, id uuid.UUID) (*entities.Profile, error) {
 	var profile entities.Profile
-	err := r.db.WithContext(ctx).Unscoped().Where("id = ?", id).First(&profile).Error
+	err := DB(ctx, r.db).Unscoped().Where("id = ?", id).First(&profile).Error
 	if err != nil {
-		return nil, err
+		return nil, TranslateError(err)
 	}
 	return &profile, nil
 }
@@ -47,31 +49,52 @@ func (r *ProfileGormRepository) GetByIDIncludeDeleted(ctx context.Context, id uu
 // GetByUserID retrieves a profile by user ID
 func (r *ProfileGormRepository) GetByUserID(ctx context.Context, userID uuid.UUID) (*entities.Profile, error) {
 	var profile entities.Profile
-	err := r.db.WithContext(ctx).Where("user_id = ?", userID).First(&profile).Error
+	err := DB(ctx, r.db).Where("user_id = ?", userID).First(&profile).Error
 	if err != nil {
-		return nil, err
+		return nil, TranslateError(err)
 	}
 	return &profile, nil
 }
 
-// Update updates a profile
+// Update updates a profile and stores its domain events in the outbox
 func (r *ProfileGormRepository) Update(ctx context.Context, profile *entities.Profile) error {
-	return r.db.WithContext(ctx).Save(profile).Error
+	return outbox.SaveWithEvents(DB(ctx, r.db), "Profile", profile.ID, &profile.AggregateRoot, func(tx *gorm.DB) error {
+		return SaveSoftDeletable(tx, &entities.Profile{}, &profile.BaseEntity, func(tx *gorm.DB) error {
+			return TranslateError(tx.Save(profile).Error)
+		})
+	})
 }
 
 // Delete deletes a profile (hard delete)
 func (r *ProfileGormRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	return r.db.WithContext(ctx).Unscoped().Delete(&entities.Profile{}, "id = ?", id).Error
+	return DB(ctx, r.db).Unscoped().Delete(&entities.Profile{}, "id = ?", id).Error
 }
 
 // SoftDelete soft deletes a profile
 func (r *ProfileGormRepository) SoftDelete(ctx context.Context, id uuid.UUID) error {
-	return r.db.WithContext(ctx).Delete(&entities.Profile{}, "id = ?", id).Error
+	return SoftDeleteByID(DB(ctx, r.db), &entities.Profile{}, id)
 }
 
 // Restore restores a soft deleted profile
 func (r *ProfileGormRepository) Restore(ctx context.Context, id uuid.UUID) error {
-	return r.db.WithContext(ctx).Model(&entities.Profile{}).Unscoped().
-		Where("id = ? AND deleted_at IS NOT NULL", id).
-		Update("deleted_at", nil).Error
+	return RestoreByID(DB(ctx, r.db), &entities.Profile{}, id)
+}
+
+// ListDeleted lists only soft deleted profiles
+func (r *ProfileGormRepository) ListDeleted(ctx context.Context, offset, limit int) ([]*entities.Profile, error) {
+	var profiles []*entities.Profile
+	err := DB(ctx, r.db).Unscoped().
+		Where("deleted_at IS NOT NULL").
+		Offset(offset).Limit(limit).Find(&profiles).Error
+	return profiles, err
+}
+
+// ListDeletedBefore retrieves profiles soft deleted before the given time, oldest deletions first
+func (r *ProfileGormRepository) ListDeletedBefore(ctx context.Context, before time.Time, offset, limit int) ([]*entities.Profile, error) {
+	var profiles []*entities.Profile
+	err := DB(ctx, r.db).Unscoped().
+		Where("deleted_at IS NOT NULL AND deleted_at < ?", before).
+		Order("deleted_at, id").
+		Offset(offset).Limit(limit).Find(&profiles).Error
+	return profiles, err
 }
@@ -0,0 +1,104 @@
+package persistence
+
+import (
+	"database/sql"
+	"errors"
+	"goclean/internal/domain/entities"
+	"goclean/internal/domain/repositories"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Dependent is a table whose rows are soft deleted and restored along with their parent
+type Dependent struct {
+	Model      interface{} // Dependent model, e.g. &entities.Profile{}
+	ForeignKey string      // Column referencing the parent, e.g. "user_id"
+}
+
+var (
+	// UserDependents are soft deleted and restored along with a user
+	UserDependents = []Dependent{{Model: &entities.Profile{}, ForeignKey: "user_id"}}
+	// OrderDependents are soft deleted and restored along with an order
+	OrderDependents = []Dependent{{Model: &entities.OrderItem{}, ForeignKey: "order_id"}}
+)
+
+// SaveSoftDeletable saves an entity with save and carries a change of its soft delete
+// state over to its dependents. save runs Unscoped, so deleted rows can be updated and
+// restored. Dependents are deleted along with the entity, with its time and reason, and
+// restored along with it if they were deleted at the same time; rows deleted on their
+// own stay deleted.
+func SaveSoftDeletable(tx *gorm.DB, model interface{}, entity *entities.BaseEntity, save func(tx *gorm.DB) error, dependents ...Dependent) error {
+	stored, err := storedDeletedAt(tx, model, entity.ID)
+	if err != nil {
+		return err
+	}
+
+	if err := save(tx.Unscoped()); err != nil {
+		return err
+	}
+	return cascade(tx, entity.ID, stored, entity.DeletedAt, entity.DeletionReason, dependents)
+}
+
+// SoftDeleteByID soft deletes the row of model with the given ID and its dependents.
+// It returns repositories.ErrNotFound if no such row exists or it is already deleted.
+func SoftDeleteByID(tx *gorm.DB, model interface{}, id uuid.UUID, dependents ...Dependent) error {
+	deletedAt := gorm.DeletedAt{Time: time.Now(), Valid: true}
+	result := tx.Model(model).Where("id = ?", id).Update("deleted_at", deletedAt)
+	if result.Error != nil {
+		return TranslateError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return repositories.ErrNotFound
+	}
+	return cascade(tx, id, gorm.DeletedAt{}, deletedAt, "", dependents)
+}
+
+// RestoreByID restores the soft deleted row of model with the given ID and the
+// dependents deleted along with it
+func RestoreByID(tx *gorm.DB, model interface{}, id uuid.UUID, dependents ...Dependent) error {
+	stored, err := storedDeletedAt(tx, model, id)
+	if err != nil || !stored.Valid {
+		return err
+	}
+
+	err = tx.Unscoped().Model(model).Where("id = ?", id).
+		Updates(map[string]interface{}{"deleted_at": nil, "deletion_reason": ""}).Error
+	if err != nil {
+		return TranslateError(err)
+	}
+	return cascade(tx, id, stored, gorm.DeletedAt{}, "", dependents)
+}
+
+// storedDeletedAt reads the deletion time stored for the row of model with the given ID;
+// it is not valid for rows that are not deleted or do not exist yet
+func storedDeletedAt(tx *gorm.DB, model interface{}, id uuid.UUID) (gorm.DeletedAt, error) {
+	var stored gorm.DeletedAt
+	err := tx.Unscoped().Model(model).Select("deleted_at").Where("id = ?", id).Row().Scan(&stored)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return stored, TranslateError(err)
+	}
+	return stored, nil
+}
+
+// cascade carries the change of a parent's soft delete state from before to after over
+// to its dependents
+func cascade(tx *gorm.DB, parentID uuid.UUID, before, after gorm.DeletedAt, reason string, dependents []Dependent) error {
+	for _, dependent := range dependents {
+		var err error
+		switch {
+		case !before.Valid && after.Valid:
+			// The scope skips rows that are already deleted, so they keep their own time and reason
+			err = tx.Model(dependent.Model).Where(dependent.ForeignKey+" = ?", parentID).
+				Updates(map[string]interface{}{"deleted_at": after, "deletion_reason": reason}).Error
+		case before.Valid && !after.Valid:
+			err = tx.Unscoped().Model(dependent.Model).Where(dependent.ForeignKey+" = ? AND deleted_at = ?", parentID, before).
+				Updates(map[string]interface{}{"deleted_at": nil, "deletion_reason": ""}).Error
+		}
+		if err != nil {
+			return TranslateError(err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+package persistence
+
+import (
+	"context"
+	"goclean/internal/domain/repositories"
+
+	"gorm.io/gorm"
+)
+
+// txContextKey is the context key under which the active transaction is stored
+type txContextKey struct{}
+
+// GormUnitOfWork implements UnitOfWork using GORM transactions
+type GormUnitOfWork struct {
+	db *gorm.DB
+}
+
+// NewGormUnitOfWork creates a new GORM unit of work
+func NewGormUnitOfWork(db *gorm.DB) repositories.UnitOfWork {
+	return &GormUnitOfWork{db: db}
+}
+
+// Do runs fn inside a transaction. Calls nested inside an existing unit of work
+// join the outer transaction instead of starting a new one.
+func (u *GormUnitOfWork) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(txContextKey{}).(*gorm.DB); ok {
+		return fn(ctx)
+	}
+
+	return u.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(context.WithValue(ctx, txContextKey{}, tx))
+	})
+}
+
+// DB returns the transaction carried by ctx, or db when no unit of work is active.
+// Repositories use it so their calls take part in the caller's transaction.
+func DB(ctx context.Context, db *gorm.DB) *gorm.DB {
+	if tx, ok := ctx.Value(txContextKey{}).(*gorm.DB); ok {
+		return tx.WithContext(ctx)
+	}
+	return db.WithContext(ctx)
+}
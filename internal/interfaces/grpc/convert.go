@@ -0,0 +1,176 @@
+package grpc
+
+import (
+	"context"
+	pb "goclean/api/proto/v1"
+	"goclean/internal/domain/entities"
+	"goclean/internal/infrastructure/auth"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// parseID parses a UUID request field
+func parseID(field, value string) (uuid.UUID, error) {
+	id, err := uuid.Parse(value)
+	if err != nil {
+		return uuid.Nil, status.Errorf(codes.InvalidArgument, "invalid %s", field)
+	}
+	return id, nil
+}
+
+// currentUser returns the authenticated user's claims and ID
+func currentUser(ctx context.Context) (*auth.UserClaims, uuid.UUID, error) {
+	claims, ok := auth.UserClaimsFromContext(ctx)
+	if !ok {
+		return nil, uuid.Nil, status.Error(codes.Unauthenticated, "user not authenticated")
+	}
+
+	userID, err := uuid.Parse(claims.UserID)
+	if err != nil {
+		return nil, uuid.Nil, status.Error(codes.Unauthenticated, "invalid user ID")
+	}
+	return claims, userID, nil
+}
+
+// pagination returns the offset and limit with the same default as the REST API
+func pagination(offset, limit int32) (int, int) {
+	if limit == 0 {
+		limit = 10
+	}
+	return int(offset), int(limit)
+}
+
+// toTimestamp converts an optional time to a protobuf timestamp
+func toTimestamp(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
+
+// toProtoMoney converts a money value object to its protobuf message
+func toProtoMoney(m entities.Money) *pb.Money {
+	return &pb.Money{Amount: m.Amount, Currency: m.Currency}
+}
+
+// fromProtoMoney converts a protobuf money message, defaulting the currency
+func fromProtoMoney(m *pb.Money) entities.Money {
+	if m == nil {
+		return entities.Money{Currency: entities.DefaultCurrency}
+	}
+	currency := m.GetCurrency()
+	if currency == "" {
+		currency = entities.DefaultCurrency
+	}
+	return entities.Money{Amount: m.GetAmount(), Currency: currency}
+}
+
+// orderStatusToProto maps order statuses to the protobuf enum
+var orderStatusToProto = map[entities.OrderStatus]pb.OrderStatus{
+	entities.OrderStatusPending:   pb.OrderStatus_ORDER_STATUS_PENDING,
+	entities.OrderStatusConfirmed: pb.OrderStatus_ORDER_STATUS_CONFIRMED,
+	entities.OrderStatusShipped:   pb.OrderStatus_ORDER_STATUS_SHIPPED,
+	entities.OrderStatusDelivered: pb.OrderStatus_ORDER_STATUS_DELIVERED,
+	entities.OrderStatusCancelled: pb.OrderStatus_ORDER_STATUS_CANCELLED,
+}
+
+// toProtoOrderStatus converts an order status to the protobuf enum
+func toProtoOrderStatus(s entities.OrderStatus) pb.OrderStatus {
+	return orderStatusToProto[s]
+}
+
+// fromProtoOrderStatus converts the protobuf enum to an order status
+func fromProtoOrderStatus(s pb.OrderStatus) (entities.OrderStatus, bool) {
+	for orderStatus, protoStatus := range orderStatusToProto {
+		if protoStatus == s {
+			return orderStatus, true
+		}
+	}
+	return "", false
+}
+
+// toProtoUser converts a user and optional profile to its protobuf message
+func toProtoUser(user *entities.User, profile *entities.Profile) *pb.User {
+	message := &pb.User{
+		Id:        user.ID.String(),
+		Email:     user.Email,
+		Username:  user.Username,
+		FirstName: user.FirstName,
+		LastName:  user.LastName,
+		IsActive:  user.IsActive,
+		CreatedAt: timestamppb.New(user.CreatedAt),
+		UpdatedAt: timestamppb.New(user.UpdatedAt),
+	}
+
+	if profile != nil {
+		message.Profile = &pb.Profile{
+			Id:          profile.ID.String(),
+			UserId:      profile.UserID.String(),
+			Bio:         profile.Bio,
+			Avatar:      profile.Avatar,
+			DateOfBirth: toTimestamp(profile.DateOfBirth),
+			CreatedAt:   timestamppb.New(profile.CreatedAt),
+			UpdatedAt:   timestamppb.New(profile.UpdatedAt),
+		}
+	}
+
+	return message
+}
+
+// toProtoProduct converts a product to its protobuf message
+func toProtoProduct(product *entities.Product) *pb.Product {
+	return &pb.Product{
+		Id:          product.ID.String(),
+		Name:        product.Name,
+		Description: product.Description,
+		Price:       toProtoMoney(product.Price),
+		Sku:         product.SKU,
+		Category:    product.Category,
+		IsActive:    product.IsActive,
+		CreatedBy:   product.CreatedBy.String(),
+		CreatedAt:   timestamppb.New(product.CreatedAt),
+		UpdatedAt:   timestamppb.New(product.UpdatedAt),
+	}
+}
+
+// toProtoOrder converts an order aggregate to its protobuf message
+func toProtoOrder(order *entities.Order) *pb.Order {
+	items := make([]*pb.OrderItem, len(order.Items))
+	for i, item := range order.Items {
+		items[i] = &pb.OrderItem{
+			Id:        item.ID.String(),
+			OrderId:   item.OrderID.String(),
+			ProductId: item.ProductID.String(),
+			Quantity:  int32(item.Quantity),
+			Price:     toProtoMoney(item.Price),
+			CreatedAt: timestamppb.New(item.CreatedAt),
+		}
+	}
+
+	history := make([]*pb.OrderStatusChange, len(order.StatusHistory))
+	for i, entry := range order.StatusHistory {
+		history[i] = &pb.OrderStatusChange{
+			Id:         entry.ID.String(),
+			FromStatus: toProtoOrderStatus(entry.FromStatus),
+			ToStatus:   toProtoOrderStatus(entry.ToStatus),
+			ChangedBy:  entry.ChangedBy.String(),
+			Reason:     entry.Reason,
+			ChangedAt:  timestamppb.New(entry.ChangedAt),
+		}
+	}
+
+	return &pb.Order{
+		Id:            order.ID.String(),
+		UserId:        order.UserID.String(),
+		Status:        toProtoOrderStatus(order.Status),
+		TotalPrice:    toProtoMoney(order.TotalPrice),
+		Items:         items,
+		StatusHistory: history,
+		CreatedAt:     timestamppb.New(order.CreatedAt),
+		UpdatedAt:     timestamppb.New(order.UpdatedAt),
+	}
+}
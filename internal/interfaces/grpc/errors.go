@@ -0,0 +1,86 @@
+package grpc
+
+import (
+	"errors"
+	"goclean/internal/application/authz"
+	"goclean/internal/application/validation"
+	"goclean/internal/domain"
+	"goclean/internal/domain/repositories"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+)
+
+// errorDomain identifies this service in google.rpc.ErrorInfo details
+const errorDomain = "goclean"
+
+// toStatusError maps domain and application errors to gRPC status errors. The status
+// carries a google.rpc.ErrorInfo whose reason is the same stable code the HTTP API
+// returns, and validation failures also list their fields in a google.rpc.BadRequest.
+// Unexpected errors are reported as Internal without their message.
+func toStatusError(err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+
+	var (
+		fieldErrors validation.Errors
+		domainErr   *domain.Error
+	)
+	switch {
+	case errors.As(err, &fieldErrors):
+		violations := make([]*errdetails.BadRequest_FieldViolation, len(fieldErrors))
+		for i, fieldError := range fieldErrors {
+			violations[i] = &errdetails.BadRequest_FieldViolation{
+				Field:       fieldError.Field,
+				Description: fieldError.Message,
+			}
+		}
+		return newStatusError(codes.InvalidArgument, "validation_failed", err.Error(),
+			&errdetails.BadRequest{FieldViolations: violations})
+	case errors.Is(err, authz.ErrUnauthenticated):
+		return newStatusError(codes.Unauthenticated, "unauthenticated", err.Error())
+	case errors.Is(err, repositories.ErrConcurrentModification):
+		// The client may retry the whole operation
+		return newStatusError(codes.Aborted, repositories.ErrConcurrentModification.Code, err.Error())
+	case errors.As(err, &domainErr):
+		return newStatusError(kindCode(domainErr.Kind), domainErr.Code, err.Error())
+	default:
+		return newStatusError(codes.Internal, "internal_error", "an unexpected error occurred")
+	}
+}
+
+// newStatusError creates a status error carrying the error code as google.rpc.ErrorInfo
+// followed by any further details
+func newStatusError(code codes.Code, reason, message string, details ...protoadapt.MessageV1) error {
+	st := status.New(code, message)
+	details = append([]protoadapt.MessageV1{&errdetails.ErrorInfo{Reason: reason, Domain: errorDomain}}, details...)
+	withDetails, err := st.WithDetails(details...)
+	if err != nil {
+		return st.Err()
+	}
+	return withDetails.Err()
+}
+
+// kindCode maps a domain error kind to its gRPC status code
+func kindCode(kind domain.Kind) codes.Code {
+	switch kind {
+	case domain.ErrNotFound:
+		return codes.NotFound
+	case domain.ErrConflict:
+		return codes.AlreadyExists
+	case domain.ErrValidation:
+		return codes.InvalidArgument
+	case domain.ErrForbidden:
+		return codes.PermissionDenied
+	case domain.ErrInvalidStateTransition:
+		return codes.FailedPrecondition
+	default:
+		return codes.Internal
+	}
+}
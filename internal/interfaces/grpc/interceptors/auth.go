@@ -0,0 +1,120 @@
+package interceptors
+
+import (
+	"context"
+	"errors"
+	"goclean/internal/infrastructure/auth"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// AuthInterceptor provides JWT authentication and role-based authorization for gRPC
+type AuthInterceptor struct {
+	authService   auth.TokenValidator
+	revocations   *auth.RevocationService
+	publicMethods []string
+	methodRoles   map[string][]string
+}
+
+// NewAuthInterceptor creates a new auth interceptor.
+// publicMethods are full method name prefixes that skip authentication, like the HTTP skipPaths.
+// methodRoles maps full method names to the roles allowed to call them; any listed role is sufficient.
+// Revocation checks are skipped when revocations is nil.
+func NewAuthInterceptor(authService auth.TokenValidator, revocations *auth.RevocationService, publicMethods []string, methodRoles map[string][]string) *AuthInterceptor {
+	return &AuthInterceptor{
+		authService:   authService,
+		revocations:   revocations,
+		publicMethods: publicMethods,
+		methodRoles:   methodRoles,
+	}
+}
+
+// Unary returns a unary server interceptor that authenticates and authorizes calls
+func (i *AuthInterceptor) Unary() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, err := i.authorize(ctx, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// Stream returns a stream server interceptor that authenticates and authorizes calls
+func (i *AuthInterceptor) Stream() grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := i.authorize(stream.Context(), info.FullMethod)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &authenticatedStream{ServerStream: stream, ctx: ctx})
+	}
+}
+
+// authorize validates the bearer token and checks the method's required roles.
+// It returns a context carrying the user claims.
+func (i *AuthInterceptor) authorize(ctx context.Context, method string) (context.Context, error) {
+	// Check if method should be skipped
+	for _, publicMethod := range i.publicMethods {
+		if strings.HasPrefix(method, publicMethod) {
+			return ctx, nil
+		}
+	}
+
+	// Get authorization metadata
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "missing metadata")
+	}
+	values := md.Get("authorization")
+	if len(values) == 0 || values[0] == "" {
+		return nil, status.Error(codes.Unauthenticated, "missing authorization header")
+	}
+
+	// Validate token
+	claims, err := i.authService.ValidateToken(ctx, values[0])
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "invalid token: "+err.Error())
+	}
+
+	// Reject revoked tokens
+	if i.revocations != nil {
+		if err := i.revocations.Check(ctx, claims); err != nil {
+			if errors.Is(err, auth.ErrTokenRevoked) {
+				return nil, status.Error(codes.Unauthenticated, "token has been revoked")
+			}
+			return nil, status.Error(codes.Unavailable, "unable to verify token")
+		}
+	}
+
+	// Check required roles
+	if roles, ok := i.methodRoles[method]; ok && len(roles) > 0 {
+		hasRole := false
+		for _, role := range roles {
+			if claims.HasRole(role) {
+				hasRole = true
+				break
+			}
+		}
+		if !hasRole {
+			return nil, status.Error(codes.PermissionDenied, "insufficient permissions")
+		}
+	}
+
+	return auth.WithUserClaims(ctx, claims), nil
+}
+
+// authenticatedStream wraps a server stream to carry the authenticated context
+type authenticatedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+// Context returns the authenticated context
+func (s *authenticatedStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,78 @@
+package interceptors
+
+import (
+	"context"
+	"goclean/internal/application/commands"
+	"goclean/internal/infrastructure/auth"
+	"goclean/pkg/logger"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ProvisioningInterceptor creates or syncs the local user for the authenticated subject
+type ProvisioningInterceptor struct {
+	provisioner *commands.UserProvisioner
+	logger      *logger.Logger
+}
+
+// NewProvisioningInterceptor creates a new provisioning interceptor
+func NewProvisioningInterceptor(provisioner *commands.UserProvisioner, logger *logger.Logger) *ProvisioningInterceptor {
+	return &ProvisioningInterceptor{
+		provisioner: provisioner,
+		logger:      logger,
+	}
+}
+
+// Unary returns a unary server interceptor that provisions the caller.
+// It must run after the auth interceptor.
+func (i *ProvisioningInterceptor) Unary() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := i.provision(ctx); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// Stream returns a stream server interceptor that provisions the caller.
+// It must run after the auth interceptor.
+func (i *ProvisioningInterceptor) Stream() grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := i.provision(stream.Context()); err != nil {
+			return err
+		}
+		return handler(srv, stream)
+	}
+}
+
+// provision provisions the user from the claims in ctx. Deleted and erased users are denied;
+// other failures are logged and do not block the call.
+func (i *ProvisioningInterceptor) provision(ctx context.Context) error {
+	claims, ok := auth.UserClaimsFromContext(ctx)
+	if !ok {
+		return nil
+	}
+
+	id, err := uuid.Parse(claims.UserID)
+	if err != nil || claims.Email == "" || claims.Username == "" {
+		return nil
+	}
+
+	err = i.provisioner.Provision(ctx, commands.ProvisionUserCommand{
+		ID:        id,
+		Email:     claims.Email,
+		Username:  claims.Username,
+		FirstName: claims.FirstName,
+		LastName:  claims.LastName,
+	})
+	if commands.IsAccountClosed(err) {
+		return status.Error(codes.PermissionDenied, "user account has been closed")
+	}
+	if err != nil {
+		i.logger.Warn("Failed to provision user", "user_id", claims.UserID, "error", err)
+	}
+	return nil
+}
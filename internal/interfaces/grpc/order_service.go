@@ -0,0 +1,209 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	pb "goclean/api/proto/v1"
+	"goclean/internal/application/authz"
+	"goclean/internal/application/bus"
+	"goclean/internal/application/commands"
+	"goclean/internal/application/queries"
+	"goclean/internal/domain/entities"
+	"goclean/internal/domain/services"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// OrderService implements the gRPC OrderService
+type OrderService struct {
+	pb.UnimplementedOrderServiceServer
+	commandBus *bus.CommandBus
+	queryBus   *bus.QueryBus
+}
+
+// NewOrderService creates a new gRPC order service
+func NewOrderService(commandBus *bus.CommandBus, queryBus *bus.QueryBus) *OrderService {
+	return &OrderService{
+		commandBus: commandBus,
+		queryBus:   queryBus,
+	}
+}
+
+// CreateOrder places a new order for the authenticated user
+func (s *OrderService) CreateOrder(ctx context.Context, req *pb.CreateOrderRequest) (*pb.CreateOrderResponse, error) {
+	_, userID, err := currentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Convert to command
+	items := make([]commands.CreateOrderItemData, len(req.GetItems()))
+	for i, item := range req.GetItems() {
+		productID, err := parseID("product ID", item.GetProductId())
+		if err != nil {
+			return nil, err
+		}
+		items[i] = commands.CreateOrderItemData{
+			ProductID: productID,
+			Quantity:  int(item.GetQuantity()),
+		}
+	}
+
+	// Execute command
+	order, err := bus.DispatchWithResult[*entities.Order](ctx, s.commandBus, commands.CreateOrderCommand{
+		UserID: userID,
+		Items:  items,
+	})
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &pb.CreateOrderResponse{
+		Id:      order.ID.String(),
+		Message: "Order created successfully",
+	}, nil
+}
+
+// GetOrder retrieves an order owned by the caller, or any order for admins
+func (s *OrderService) GetOrder(ctx context.Context, req *pb.GetOrderRequest) (*pb.GetOrderResponse, error) {
+	order, err := s.loadAccessibleOrder(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.GetOrderResponse{
+		Order: toProtoOrder(order),
+	}, nil
+}
+
+// GetUserOrders retrieves a user's orders; users may only list their own unless they are admins
+func (s *OrderService) GetUserOrders(ctx context.Context, req *pb.GetUserOrdersRequest) (*pb.ListOrdersResponse, error) {
+	_, userID, err := currentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.GetUserId() != "" {
+		if userID, err = parseID("user ID", req.GetUserId()); err != nil {
+			return nil, err
+		}
+	}
+
+	offset, limit := pagination(req.GetOffset(), req.GetLimit())
+
+	result, err := bus.Ask[*queries.OrdersResult](ctx, s.queryBus, queries.GetOrdersByUserIDQuery{
+		UserID: userID,
+		Offset: offset,
+		Limit:  limit,
+	})
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return toProtoOrdersResponse(result, offset, limit), nil
+}
+
+// ListOrders retrieves all orders with pagination
+func (s *OrderService) ListOrders(ctx context.Context, req *pb.ListOrdersRequest) (*pb.ListOrdersResponse, error) {
+	offset, limit := pagination(req.GetOffset(), req.GetLimit())
+
+	result, err := bus.Ask[*queries.OrdersResult](ctx, s.queryBus, queries.ListOrdersQuery{
+		Offset: offset,
+		Limit:  limit,
+	})
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return toProtoOrdersResponse(result, offset, limit), nil
+}
+
+// UpdateOrderStatus moves an order to a new status
+func (s *OrderService) UpdateOrderStatus(ctx context.Context, req *pb.UpdateOrderStatusRequest) (*pb.UpdateOrderStatusResponse, error) {
+	_, changedBy, err := currentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := parseID("order ID", req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	orderStatus, ok := fromProtoOrderStatus(req.GetStatus())
+	if !ok {
+		return nil, status.Error(codes.InvalidArgument, "invalid order status")
+	}
+
+	if err := s.commandBus.Dispatch(ctx, commands.UpdateOrderStatusCommand{
+		ID:        id,
+		Status:    orderStatus,
+		ChangedBy: changedBy,
+		Reason:    req.GetReason(),
+	}); err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &pb.UpdateOrderStatusResponse{
+		Message: "Order status updated successfully",
+	}, nil
+}
+
+// CancelOrder cancels an order owned by the caller, or any order for admins
+func (s *OrderService) CancelOrder(ctx context.Context, req *pb.CancelOrderRequest) (*emptypb.Empty, error) {
+	order, err := s.loadAccessibleOrder(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	_, cancelledBy, _ := currentUser(ctx)
+	if err := s.commandBus.Dispatch(ctx, commands.CancelOrderCommand{
+		ID:          order.ID,
+		CancelledBy: cancelledBy,
+		Reason:      req.GetReason(),
+	}); err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+// loadAccessibleOrder loads an order if the caller may access it.
+// Orders of other users are reported as not found so their existence is not revealed.
+func (s *OrderService) loadAccessibleOrder(ctx context.Context, rawID string) (*entities.Order, error) {
+	id, err := parseID("order ID", rawID)
+	if err != nil {
+		return nil, err
+	}
+
+	// The query handler only returns orders the caller may read
+	result, err := bus.Ask[*queries.OrderResult](ctx, s.queryBus, queries.GetOrderByIDQuery{ID: id})
+	if errors.Is(err, authz.ErrForbidden) {
+		return nil, toStatusError(services.ErrOrderNotFound)
+	}
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return result.Order, nil
+}
+
+// toProtoOrdersResponse converts an orders query result to a list response
+func toProtoOrdersResponse(result *queries.OrdersResult, offset, limit int) *pb.ListOrdersResponse {
+	orders := make([]*pb.Order, len(result.Orders))
+	for i, order := range result.Orders {
+		orders[i] = toProtoOrder(order)
+	}
+
+	return &pb.ListOrdersResponse{
+		Orders: orders,
+		Total:  int32(result.Total),
+		Pagination: &pb.PaginationInfo{
+			Offset: int32(offset),
+			Limit:  int32(limit),
+			Total:  int32(result.Total),
+		},
+	}
+}
@@ -0,0 +1,185 @@
+package grpc
+
+import (
+	"context"
+	pb "goclean/api/proto/v1"
+	"goclean/internal/application/bus"
+	"goclean/internal/application/commands"
+	"goclean/internal/application/queries"
+	"goclean/internal/domain/entities"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// ProductService implements the gRPC ProductService
+type ProductService struct {
+	pb.UnimplementedProductServiceServer
+	commandBus *bus.CommandBus
+	queryBus   *bus.QueryBus
+}
+
+// NewProductService creates a new gRPC product service
+func NewProductService(commandBus *bus.CommandBus, queryBus *bus.QueryBus) *ProductService {
+	return &ProductService{
+		commandBus: commandBus,
+		queryBus:   queryBus,
+	}
+}
+
+// CreateProduct creates a new product
+func (s *ProductService) CreateProduct(ctx context.Context, req *pb.CreateProductRequest) (*pb.CreateProductResponse, error) {
+	_, createdBy, err := currentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Convert to command
+	cmd := commands.CreateProductCommand{
+		Name:        req.GetName(),
+		Description: req.GetDescription(),
+		Price:       fromProtoMoney(req.GetPrice()),
+		SKU:         req.GetSku(),
+		Category:    req.GetCategory(),
+		Stock:       int(req.GetStock()),
+		CreatedBy:   createdBy,
+	}
+
+	// Execute command
+	product, err := bus.DispatchWithResult[*entities.Product](ctx, s.commandBus, cmd)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &pb.CreateProductResponse{
+		Id:      product.ID.String(),
+		Message: "Product created successfully",
+	}, nil
+}
+
+// GetProduct retrieves a product by ID
+func (s *ProductService) GetProduct(ctx context.Context, req *pb.GetProductRequest) (*pb.GetProductResponse, error) {
+	id, err := parseID("product ID", req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := bus.Ask[*queries.ProductResult](ctx, s.queryBus, queries.GetProductByIDQuery{ID: id})
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &pb.GetProductResponse{
+		Product: toProtoProduct(result.Product),
+	}, nil
+}
+
+// ListProducts retrieves products with pagination and an optional category filter
+func (s *ProductService) ListProducts(ctx context.Context, req *pb.ListProductsRequest) (*pb.ListProductsResponse, error) {
+	offset, limit := pagination(req.GetOffset(), req.GetLimit())
+
+	var result *queries.ProductsResult
+	var err error
+
+	if req.GetCategory() != "" {
+		result, err = bus.Ask[*queries.ProductsResult](ctx, s.queryBus, queries.ListProductsByCategoryQuery{
+			Category: req.GetCategory(),
+			Offset:   offset,
+			Limit:    limit,
+		})
+	} else {
+		result, err = bus.Ask[*queries.ProductsResult](ctx, s.queryBus, queries.ListProductsQuery{
+			Offset: offset,
+			Limit:  limit,
+		})
+	}
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return toProtoProductsResponse(result, offset, limit), nil
+}
+
+// SearchProducts searches products with pagination
+func (s *ProductService) SearchProducts(ctx context.Context, req *pb.SearchProductsRequest) (*pb.ListProductsResponse, error) {
+	if req.GetQuery() == "" {
+		return nil, status.Error(codes.InvalidArgument, "query is required")
+	}
+
+	offset, limit := pagination(req.GetOffset(), req.GetLimit())
+
+	result, err := bus.Ask[*queries.ProductsResult](ctx, s.queryBus, queries.SearchProductsQuery{
+		Query:  req.GetQuery(),
+		Offset: offset,
+		Limit:  limit,
+	})
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return toProtoProductsResponse(result, offset, limit), nil
+}
+
+// UpdateProduct partially updates a product
+func (s *ProductService) UpdateProduct(ctx context.Context, req *pb.UpdateProductRequest) (*pb.UpdateProductResponse, error) {
+	id, err := parseID("product ID", req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	cmd := commands.UpdateProductCommand{
+		ID:          id,
+		Name:        req.Name,
+		Description: req.Description,
+		SKU:         req.Sku,
+		Category:    req.Category,
+		IsActive:    req.IsActive,
+	}
+	if req.Price != nil {
+		price := fromProtoMoney(req.GetPrice())
+		cmd.Price = &price
+	}
+
+	product, err := bus.DispatchWithResult[*entities.Product](ctx, s.commandBus, cmd)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &pb.UpdateProductResponse{
+		Message: "Product updated successfully",
+		Product: toProtoProduct(product),
+	}, nil
+}
+
+// DeleteProduct soft deletes a product
+func (s *ProductService) DeleteProduct(ctx context.Context, req *pb.DeleteProductRequest) (*emptypb.Empty, error) {
+	id, err := parseID("product ID", req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.commandBus.Dispatch(ctx, commands.SoftDeleteProductCommand{ID: id, Reason: req.GetReason()}); err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+// toProtoProductsResponse converts a products query result to a list response
+func toProtoProductsResponse(result *queries.ProductsResult, offset, limit int) *pb.ListProductsResponse {
+	products := make([]*pb.Product, len(result.Products))
+	for i, product := range result.Products {
+		products[i] = toProtoProduct(product)
+	}
+
+	return &pb.ListProductsResponse{
+		Products: products,
+		Total:    int32(result.Total),
+		Pagination: &pb.PaginationInfo{
+			Offset: int32(offset),
+			Limit:  int32(limit),
+			Total:  int32(result.Total),
+		},
+	}
+}
@@ -0,0 +1,93 @@
+package grpc
+
+import (
+	pb "goclean/api/proto/v1"
+	"goclean/internal/application/commands"
+	"goclean/internal/infrastructure/auth"
+	"goclean/internal/interfaces/grpc/interceptors"
+	"goclean/pkg/logger"
+	"net"
+
+	googleGrpc "google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
+)
+
+// publicMethods lists method prefixes that do not require authentication
+var publicMethods = []string{
+	"/grpc.reflection.",
+	pb.UserService_CreateUser_FullMethodName,        // Public registration
+	pb.ProductService_GetProduct_FullMethodName,     // Public
+	pb.ProductService_ListProducts_FullMethodName,   // Public
+	pb.ProductService_SearchProducts_FullMethodName, // Public
+}
+
+// methodRoles lists the roles required to call a method; authenticated users may call unlisted methods
+var methodRoles = map[string][]string{
+	pb.UserService_ListUsers_FullMethodName:          {"admin"},
+	pb.UserService_DeleteUser_FullMethodName:         {"admin"},
+	pb.ProductService_UpdateProduct_FullMethodName:   {"admin"},
+	pb.ProductService_DeleteProduct_FullMethodName:   {"admin"},
+	pb.OrderService_ListOrders_FullMethodName:        {"admin"},
+	pb.OrderService_UpdateOrderStatus_FullMethodName: {"admin"},
+}
+
+// Server represents the gRPC server
+type Server struct {
+	server *googleGrpc.Server
+	logger *logger.Logger
+}
+
+// NewServer creates a new gRPC server with all services registered
+func NewServer(
+	logger *logger.Logger,
+	authService auth.TokenValidator,
+	revocations *auth.RevocationService,
+	userProvisioner *commands.UserProvisioner,
+	userService *UserService,
+	productService *ProductService,
+	orderService *OrderService,
+) *Server {
+	// Auth interceptors; provisioning runs after authentication
+	authInterceptor := interceptors.NewAuthInterceptor(authService, revocations, publicMethods, methodRoles)
+	provisioningInterceptor := interceptors.NewProvisioningInterceptor(userProvisioner, logger)
+
+	grpcServer := googleGrpc.NewServer(
+		googleGrpc.ChainUnaryInterceptor(authInterceptor.Unary(), provisioningInterceptor.Unary()),
+		googleGrpc.ChainStreamInterceptor(authInterceptor.Stream(), provisioningInterceptor.Stream()),
+	)
+
+	// Register services
+	pb.RegisterUserServiceServer(grpcServer, userService)
+	pb.RegisterProductServiceServer(grpcServer, productService)
+	pb.RegisterOrderServiceServer(grpcServer, orderService)
+
+	// Enable reflection for development (helps with tools like grpcurl)
+	reflection.Register(grpcServer)
+
+	return &Server{
+		server: grpcServer,
+		logger: logger,
+	}
+}
+
+// Start starts the gRPC server and blocks until it stops
+func (s *Server) Start(address string) error {
+	lis, err := net.Listen("tcp", address)
+	if err != nil {
+		return err
+	}
+
+	s.logger.Info("Starting gRPC server", "address", address)
+	return s.server.Serve(lis)
+}
+
+// Stop stops the gRPC server gracefully
+func (s *Server) Stop() {
+	s.logger.Info("Stopping gRPC server")
+	s.server.GracefulStop()
+}
+
+// ForceStop stops the gRPC server immediately, closing open connections
+func (s *Server) ForceStop() {
+	s.server.Stop()
+}
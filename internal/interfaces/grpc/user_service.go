@@ -0,0 +1,157 @@
+package grpc
+
+import (
+	"context"
+	pb "goclean/api/proto/v1"
+	"goclean/internal/application/bus"
+	"goclean/internal/application/commands"
+	"goclean/internal/application/queries"
+	"goclean/internal/domain/entities"
+
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// UserService implements the gRPC UserService
+type UserService struct {
+	pb.UnimplementedUserServiceServer
+	commandBus *bus.CommandBus
+	queryBus   *bus.QueryBus
+}
+
+// NewUserService creates a new gRPC user service
+func NewUserService(commandBus *bus.CommandBus, queryBus *bus.QueryBus) *UserService {
+	return &UserService{
+		commandBus: commandBus,
+		queryBus:   queryBus,
+	}
+}
+
+// CreateUser creates a new user
+func (s *UserService) CreateUser(ctx context.Context, req *pb.CreateUserRequest) (*pb.CreateUserResponse, error) {
+	// Convert to command
+	cmd := commands.CreateUserCommand{
+		Email:     req.GetEmail(),
+		Username:  req.GetUsername(),
+		FirstName: req.GetFirstName(),
+		LastName:  req.GetLastName(),
+	}
+
+	if req.Profile != nil {
+		cmd.Profile = &commands.CreateProfileData{
+			Bio:         req.Profile.GetBio(),
+			Avatar:      req.Profile.GetAvatar(),
+			DateOfBirth: req.Profile.GetDateOfBirth(),
+		}
+	}
+
+	// Execute command
+	user, err := bus.DispatchWithResult[*entities.User](ctx, s.commandBus, cmd)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &pb.CreateUserResponse{
+		Id:      user.ID.String(),
+		Message: "User created successfully",
+	}, nil
+}
+
+// GetUser retrieves a user by ID; users may only read themselves unless they are admins
+func (s *UserService) GetUser(ctx context.Context, req *pb.GetUserRequest) (*pb.GetUserResponse, error) {
+	id, err := parseID("user ID", req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := bus.Ask[*queries.UserResult](ctx, s.queryBus, queries.GetUserByIDQuery{ID: id})
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &pb.GetUserResponse{
+		User: toProtoUser(result.User, result.Profile),
+	}, nil
+}
+
+// GetCurrentUser retrieves the authenticated user
+func (s *UserService) GetCurrentUser(ctx context.Context, _ *emptypb.Empty) (*pb.GetUserResponse, error) {
+	_, userID, err := currentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := bus.Ask[*queries.UserResult](ctx, s.queryBus, queries.GetUserByIDQuery{ID: userID})
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &pb.GetUserResponse{
+		User: toProtoUser(result.User, result.Profile),
+	}, nil
+}
+
+// ListUsers retrieves users with pagination
+func (s *UserService) ListUsers(ctx context.Context, req *pb.ListUsersRequest) (*pb.ListUsersResponse, error) {
+	offset, limit := pagination(req.GetOffset(), req.GetLimit())
+
+	result, err := bus.Ask[*queries.UsersResult](ctx, s.queryBus, queries.ListUsersQuery{
+		Offset: offset,
+		Limit:  limit,
+	})
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	users := make([]*pb.User, len(result.Users))
+	for i, userResult := range result.Users {
+		users[i] = toProtoUser(userResult.User, userResult.Profile)
+	}
+
+	return &pb.ListUsersResponse{
+		Users: users,
+		Total: int32(result.Total),
+		Pagination: &pb.PaginationInfo{
+			Offset: int32(offset),
+			Limit:  int32(limit),
+			Total:  int32(result.Total),
+		},
+	}, nil
+}
+
+// UpdateUser partially updates a user; users may only update themselves unless they are admins
+func (s *UserService) UpdateUser(ctx context.Context, req *pb.UpdateUserRequest) (*pb.UpdateUserResponse, error) {
+	id, err := parseID("user ID", req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := bus.DispatchWithResult[*entities.User](ctx, s.commandBus, commands.UpdateUserCommand{
+		ID:        id,
+		Email:     req.Email,
+		Username:  req.Username,
+		FirstName: req.FirstName,
+		LastName:  req.LastName,
+	})
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &pb.UpdateUserResponse{
+		Message: "User updated successfully",
+		User:    toProtoUser(user, user.Profile),
+	}, nil
+}
+
+// DeleteUser soft deletes a user
+func (s *UserService) DeleteUser(ctx context.Context, req *pb.DeleteUserRequest) (*emptypb.Empty, error) {
+	id, err := parseID("user ID", req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.commandBus.Dispatch(ctx, commands.SoftDeleteUserCommand{ID: id, Reason: req.GetReason()}); err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
@@ -0,0 +1,185 @@
+package handlers
+
+import (
+	"goclean/internal/application/bus"
+	"goclean/internal/application/commands"
+	"goclean/internal/application/dto"
+	"goclean/internal/application/queries"
+	"goclean/internal/domain/entities"
+	"goclean/internal/infrastructure/auth"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// APIKeyHandler handles API key administration HTTP requests
+type APIKeyHandler struct {
+	commandBus *bus.CommandBus
+	queryBus   *bus.QueryBus
+}
+
+// NewAPIKeyHandler creates a new API key handler
+func NewAPIKeyHandler(commandBus *bus.CommandBus, queryBus *bus.QueryBus) *APIKeyHandler {
+	return &APIKeyHandler{
+		commandBus: commandBus,
+		queryBus:   queryBus,
+	}
+}
+
+// IssueAPIKey issues a new API key
+// @Summary Issue an API key
+// @Description Issue an API key acting as the given owner with the given scopes (admin only). The plaintext key is only returned in this response.
+// @Tags api-keys
+// @Accept json
+// @Produce json
+// @Param key body dto.CreateAPIKeyRequest true "API key data"
+// @Success 201 {object} dto.IssuedAPIKeyAPIResponse
+// @Failure 400 {object} dto.ProblemDetails
+// @Failure 401 {object} dto.ProblemDetails
+// @Failure 403 {object} dto.ProblemDetails
+// @Failure 404 {object} dto.ProblemDetails
+// @Failure 500 {object} dto.ProblemDetails
+// @Router /api/v1/admin/api-keys [post]
+// @Security BearerAuth
+func (h *APIKeyHandler) IssueAPIKey(c echo.Context) error {
+	var req dto.CreateAPIKeyRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
+	}
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	claims, ok := c.Get("user_claims").(*auth.UserClaims)
+	if !ok {
+		return echo.NewHTTPError(http.StatusUnauthorized, "User not authenticated")
+	}
+
+	createdBy, err := uuid.Parse(claims.UserID)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid user ID")
+	}
+
+	cmd := commands.IssueAPIKeyCommand{
+		Name:      req.Name,
+		OwnerID:   req.OwnerID,
+		Scopes:    req.Scopes,
+		ExpiresAt: req.ExpiresAt,
+		CreatedBy: createdBy,
+	}
+
+	issued, err := bus.DispatchWithResult[*commands.IssuedAPIKey](c.Request().Context(), h.commandBus, cmd)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusCreated, dto.APIResponse[*dto.IssuedAPIKeyDTO]{
+		Success: true,
+		Data: &dto.IssuedAPIKeyDTO{
+			APIKeyDTO: toAPIKeyDTO(issued.APIKey),
+			Key:       issued.Key,
+		},
+		Message: "API key issued successfully; store the key now, it cannot be retrieved again",
+	})
+}
+
+// ListAPIKeys lists API keys
+// @Summary List API keys
+// @Description List API keys, optionally filtered by owner (admin only)
+// @Tags api-keys
+// @Produce json
+// @Param owner_id query string false "Owner user ID"
+// @Param offset query int false "Offset" default(0)
+// @Param limit query int false "Limit" default(10)
+// @Success 200 {object} dto.APIKeysListResponse
+// @Failure 400 {object} dto.ProblemDetails
+// @Failure 401 {object} dto.ProblemDetails
+// @Failure 403 {object} dto.ProblemDetails
+// @Failure 500 {object} dto.ProblemDetails
+// @Router /api/v1/admin/api-keys [get]
+// @Security BearerAuth
+func (h *APIKeyHandler) ListAPIKeys(c echo.Context) error {
+	offset, limit := paginationParams(c)
+	query := queries.ListAPIKeysQuery{
+		Offset: offset,
+		Limit:  limit,
+	}
+
+	if ownerParam := c.QueryParam("owner_id"); ownerParam != "" {
+		ownerID, err := uuid.Parse(ownerParam)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "Invalid owner ID")
+		}
+		query.OwnerID = &ownerID
+	}
+
+	keys, err := bus.Ask[[]*entities.APIKey](c.Request().Context(), h.queryBus, query)
+	if err != nil {
+		return err
+	}
+
+	keyDTOs := make([]dto.APIKeyDTO, len(keys))
+	for i, key := range keys {
+		keyDTOs[i] = toAPIKeyDTO(key)
+	}
+
+	return c.JSON(http.StatusOK, dto.PaginatedResponse[[]dto.APIKeyDTO]{
+		APIResponse: dto.APIResponse[[]dto.APIKeyDTO]{
+			Success: true,
+			Data:    keyDTOs,
+		},
+		Pagination: dto.PaginationInfo{
+			Offset: offset,
+			Limit:  limit,
+			Total:  len(keyDTOs), // In a real implementation, you'd get total count separately
+		},
+	})
+}
+
+// RevokeAPIKey revokes an API key
+// @Summary Revoke an API key
+// @Description Revoke an API key; requests using it are rejected immediately (admin only)
+// @Tags api-keys
+// @Produce json
+// @Param id path string true "API key ID"
+// @Success 200 {object} dto.MessageAPIResponse
+// @Failure 400 {object} dto.ProblemDetails
+// @Failure 401 {object} dto.ProblemDetails
+// @Failure 403 {object} dto.ProblemDetails
+// @Failure 404 {object} dto.ProblemDetails
+// @Failure 500 {object} dto.ProblemDetails
+// @Router /api/v1/admin/api-keys/{id} [delete]
+// @Security BearerAuth
+func (h *APIKeyHandler) RevokeAPIKey(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid API key ID")
+	}
+
+	cmd := commands.RevokeAPIKeyCommand{ID: id}
+	if err := h.commandBus.Dispatch(c.Request().Context(), cmd); err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, dto.APIResponse[interface{}]{
+		Success: true,
+		Message: "API key revoked successfully",
+	})
+}
+
+// toAPIKeyDTO converts an API key entity to a DTO
+func toAPIKeyDTO(key *entities.APIKey) dto.APIKeyDTO {
+	return dto.APIKeyDTO{
+		ID:         key.ID,
+		Name:       key.Name,
+		KeyID:      key.KeyID,
+		OwnerID:    key.OwnerID,
+		Scopes:     key.Scopes,
+		ExpiresAt:  key.ExpiresAt,
+		LastUsedAt: key.LastUsedAt,
+		RevokedAt:  key.RevokedAt,
+		CreatedBy:  key.CreatedBy,
+		CreatedAt:  key.CreatedAt,
+	}
+}
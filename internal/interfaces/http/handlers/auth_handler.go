@@ -0,0 +1,295 @@
+package handlers
+
+import (
+	"errors"
+	"goclean/internal/application/bus"
+	"goclean/internal/application/commands"
+	"goclean/internal/application/dto"
+	"goclean/internal/infrastructure/auth"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// RefreshCookieConfig controls delivery of refresh tokens as HttpOnly cookies
+type RefreshCookieConfig struct {
+	Enabled bool
+	Name    string
+	Path    string
+	Domain  string
+	Secure  bool
+}
+
+// AuthHandler handles authentication HTTP requests
+type AuthHandler struct {
+	authProvider auth.AuthProvider
+	revocations  *auth.RevocationService
+	commandBus   *bus.CommandBus
+	cookie       RefreshCookieConfig
+}
+
+// NewAuthHandler creates a new auth handler
+func NewAuthHandler(
+	authProvider auth.AuthProvider,
+	revocations *auth.RevocationService,
+	commandBus *bus.CommandBus,
+	cookie RefreshCookieConfig,
+) *AuthHandler {
+	if cookie.Name == "" {
+		cookie.Name = "refresh_token"
+	}
+	if cookie.Path == "" {
+		cookie.Path = "/api/v1/auth"
+	}
+	return &AuthHandler{
+		authProvider: authProvider,
+		revocations:  revocations,
+		commandBus:   commandBus,
+		cookie:       cookie,
+	}
+}
+
+// Login authenticates a user with username and password
+// @Summary Log in
+// @Description Authenticate with username and password. When refresh cookies are enabled the refresh token is set as an HttpOnly cookie instead of returned in the body.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param credentials body dto.LoginRequest true "User credentials"
+// @Success 200 {object} dto.TokenAPIResponse
+// @Failure 400 {object} dto.ProblemDetails
+// @Failure 401 {object} dto.ProblemDetails
+// @Failure 500 {object} dto.ProblemDetails
+// @Router /api/v1/auth/login [post]
+func (h *AuthHandler) Login(c echo.Context) error {
+	var req dto.LoginRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
+	}
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	tokens, err := h.authProvider.Login(c.Request().Context(), req.Username, req.Password)
+	if err != nil {
+		return authError(err, "Invalid username or password")
+	}
+
+	return c.JSON(http.StatusOK, dto.APIResponse[*dto.TokenDTO]{
+		Success: true,
+		Data:    h.issueTokens(c, tokens),
+		Message: "Login successful",
+	})
+}
+
+// Refresh exchanges a refresh token for new tokens
+// @Summary Refresh tokens
+// @Description Exchange a refresh token from the request body or refresh cookie for new tokens
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body dto.RefreshTokenRequest false "Refresh token"
+// @Success 200 {object} dto.TokenAPIResponse
+// @Failure 400 {object} dto.ProblemDetails
+// @Failure 401 {object} dto.ProblemDetails
+// @Failure 500 {object} dto.ProblemDetails
+// @Router /api/v1/auth/refresh [post]
+func (h *AuthHandler) Refresh(c echo.Context) error {
+	var req dto.RefreshTokenRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
+	}
+
+	refreshToken := h.refreshToken(c, req.RefreshToken)
+	if refreshToken == "" {
+		return echo.NewHTTPError(http.StatusBadRequest, "Refresh token is required")
+	}
+
+	tokens, err := h.authProvider.RefreshToken(c.Request().Context(), refreshToken)
+	if err != nil {
+		if errors.Is(err, auth.ErrInvalidCredentials) {
+			h.clearRefreshCookie(c)
+		}
+		return authError(err, "Invalid or expired refresh token")
+	}
+
+	return c.JSON(http.StatusOK, dto.APIResponse[*dto.TokenDTO]{
+		Success: true,
+		Data:    h.issueTokens(c, tokens),
+		Message: "Token refreshed successfully",
+	})
+}
+
+// Logout ends the user's session at the identity provider
+// @Summary Log out
+// @Description End the session of the access token and the refresh token from the request body or refresh cookie, and clear the cookie
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body dto.LogoutRequest false "Refresh token"
+// @Success 200 {object} dto.MessageAPIResponse
+// @Failure 400 {object} dto.ProblemDetails
+// @Failure 401 {object} dto.ProblemDetails
+// @Failure 500 {object} dto.ProblemDetails
+// @Router /api/v1/auth/logout [post]
+// @Security BearerAuth
+func (h *AuthHandler) Logout(c echo.Context) error {
+	claims, ok := c.Get("user_claims").(*auth.UserClaims)
+	if !ok {
+		return echo.NewHTTPError(http.StatusUnauthorized, "User not authenticated")
+	}
+
+	var req dto.LogoutRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
+	}
+
+	refreshToken := h.refreshToken(c, req.RefreshToken)
+	if refreshToken == "" {
+		return echo.NewHTTPError(http.StatusBadRequest, "Refresh token is required")
+	}
+
+	// Clear the cookie even if the provider rejects the token
+	h.clearRefreshCookie(c)
+
+	// Reject access tokens of the ended session until they expire. The session is taken
+	// from the validated access token, never from the unverified refresh token, and is
+	// revoked first so a failure leaves the provider session intact for a retry.
+	if h.revocations != nil {
+		if err := h.revocations.RevokeSession(c.Request().Context(), claims); err != nil {
+			return err
+		}
+	}
+
+	if err := h.authProvider.Logout(c.Request().Context(), refreshToken); err != nil {
+		return authError(err, "Invalid or expired refresh token")
+	}
+
+	return c.JSON(http.StatusOK, dto.APIResponse[interface{}]{
+		Success: true,
+		Message: "Logout successful",
+	})
+}
+
+// RevokeUserSessions revokes all sessions, tokens and API keys of a user
+// @Summary Revoke user sessions
+// @Description Revoke all sessions, previously issued tokens and API keys of a user (admin only)
+// @Tags auth
+// @Produce json
+// @Param id path string true "User ID"
+// @Success 200 {object} dto.MessageAPIResponse
+// @Failure 400 {object} dto.ProblemDetails
+// @Failure 401 {object} dto.ProblemDetails
+// @Failure 403 {object} dto.ProblemDetails
+// @Failure 500 {object} dto.ProblemDetails
+// @Router /api/v1/admin/users/{id}/sessions/revoke [post]
+// @Security BearerAuth
+func (h *AuthHandler) RevokeUserSessions(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid user ID")
+	}
+
+	cmd := commands.RevokeUserSessionsCommand{UserID: id}
+	if err := h.commandBus.Dispatch(c.Request().Context(), cmd); err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, dto.APIResponse[interface{}]{
+		Success: true,
+		Message: "User sessions revoked successfully",
+	})
+}
+
+// UserInfo returns the authenticated user's identity from the access token
+// @Summary Get user info
+// @Description Get identity and roles of the authenticated user from the access token
+// @Tags auth
+// @Produce json
+// @Success 200 {object} dto.UserInfoAPIResponse
+// @Failure 401 {object} dto.ProblemDetails
+// @Router /api/v1/auth/userinfo [get]
+// @Security BearerAuth
+func (h *AuthHandler) UserInfo(c echo.Context) error {
+	claims, ok := c.Get("user_claims").(*auth.UserClaims)
+	if !ok {
+		return echo.NewHTTPError(http.StatusUnauthorized, "User not authenticated")
+	}
+
+	return c.JSON(http.StatusOK, dto.APIResponse[*dto.UserInfoDTO]{
+		Success: true,
+		Data: &dto.UserInfoDTO{
+			ID:            claims.UserID,
+			Email:         claims.Email,
+			Username:      claims.Username,
+			FirstName:     claims.FirstName,
+			LastName:      claims.LastName,
+			EmailVerified: claims.EmailVerified,
+			Roles:         claims.Roles(),
+		},
+	})
+}
+
+// issueTokens converts tokens to a DTO, moving the refresh token into a cookie when enabled
+func (h *AuthHandler) issueTokens(c echo.Context, tokens *auth.TokenSet) *dto.TokenDTO {
+	tokenDTO := &dto.TokenDTO{
+		AccessToken:      tokens.AccessToken,
+		RefreshToken:     tokens.RefreshToken,
+		TokenType:        tokens.TokenType,
+		ExpiresIn:        tokens.ExpiresIn,
+		RefreshExpiresIn: tokens.RefreshExpiresIn,
+	}
+
+	if h.cookie.Enabled && tokens.RefreshToken != "" {
+		c.SetCookie(h.newRefreshCookie(tokens.RefreshToken, tokens.RefreshExpiresIn))
+		tokenDTO.RefreshToken = ""
+	}
+
+	return tokenDTO
+}
+
+// refreshToken returns the token from the request body, falling back to the refresh cookie
+func (h *AuthHandler) refreshToken(c echo.Context, bodyToken string) string {
+	if bodyToken != "" || !h.cookie.Enabled {
+		return bodyToken
+	}
+	cookie, err := c.Cookie(h.cookie.Name)
+	if err != nil {
+		return ""
+	}
+	return cookie.Value
+}
+
+// clearRefreshCookie expires the refresh cookie
+func (h *AuthHandler) clearRefreshCookie(c echo.Context) {
+	if h.cookie.Enabled {
+		c.SetCookie(h.newRefreshCookie("", -1))
+	}
+}
+
+// newRefreshCookie builds the HttpOnly refresh cookie; maxAge 0 makes it a session cookie
+func (h *AuthHandler) newRefreshCookie(value string, maxAge int) *http.Cookie {
+	return &http.Cookie{
+		Name:     h.cookie.Name,
+		Value:    value,
+		Path:     h.cookie.Path,
+		Domain:   h.cookie.Domain,
+		MaxAge:   maxAge,
+		Secure:   h.cookie.Secure,
+		HttpOnly: true,
+		SameSite: http.SameSiteStrictMode,
+	}
+}
+
+// authError maps auth provider errors to HTTP errors with client messages
+func authError(err error, invalidMessage string) error {
+	switch {
+	case errors.Is(err, auth.ErrInvalidCredentials):
+		return echo.NewHTTPError(http.StatusUnauthorized, invalidMessage).SetInternal(err)
+	case errors.Is(err, auth.ErrUnsupportedOperation):
+		return echo.NewHTTPError(http.StatusNotImplemented, "Operation not supported by the configured auth provider").SetInternal(err)
+	default:
+		return echo.NewHTTPError(http.StatusInternalServerError, "Authentication service unavailable").SetInternal(err)
+	}
+}
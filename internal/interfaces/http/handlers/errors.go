@@ -0,0 +1,111 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"goclean/internal/application/authz"
+	"goclean/internal/application/dto"
+	"goclean/internal/application/validation"
+	"goclean/internal/domain"
+	"goclean/pkg/logger"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+// MIMEApplicationProblemJSON is the media type of RFC 7807 problem details
+const MIMEApplicationProblemJSON = "application/problem+json"
+
+// ErrorHandler renders every error returned by handlers and middleware as RFC 7807
+// problem details. Handlers return domain and application errors as they are and
+// reject malformed requests with echo.HTTPError. Unexpected errors are logged and
+// reported without their message so internals do not leak to clients.
+func ErrorHandler(logger *logger.Logger) echo.HTTPErrorHandler {
+	return func(err error, c echo.Context) {
+		if c.Response().Committed {
+			return
+		}
+
+		problem := toProblem(err)
+		problem.Instance = c.Request().URL.Path
+		if problem.Status >= http.StatusInternalServerError {
+			logger.Error("Request failed",
+				"method", c.Request().Method,
+				"uri", c.Request().RequestURI,
+				"status", problem.Status,
+				"error", err,
+			)
+		}
+
+		if c.Request().Method == http.MethodHead {
+			err = c.NoContent(problem.Status)
+		} else {
+			var body []byte
+			body, err = json.Marshal(problem)
+			if err == nil {
+				err = c.Blob(problem.Status, MIMEApplicationProblemJSON, body)
+			}
+		}
+		if err != nil {
+			logger.Error("Failed to write error response", "error", err)
+		}
+	}
+}
+
+// toProblem maps an error to problem details with a stable code
+func toProblem(err error) dto.ProblemDetails {
+	var (
+		fieldErrors validation.Errors
+		httpErr     *echo.HTTPError
+		domainErr   *domain.Error
+	)
+	switch {
+	case errors.As(err, &fieldErrors):
+		problem := newProblem(http.StatusBadRequest, "validation_failed", "One or more fields are invalid")
+		problem.Errors = fieldErrors
+		return problem
+	case errors.As(err, &httpErr):
+		return newProblem(httpErr.Code, statusCode(httpErr.Code), fmt.Sprint(httpErr.Message))
+	case errors.Is(err, authz.ErrUnauthenticated):
+		return newProblem(http.StatusUnauthorized, "unauthenticated", err.Error())
+	case errors.As(err, &domainErr):
+		return newProblem(kindStatus(domainErr.Kind), domainErr.Code, err.Error())
+	default:
+		return newProblem(http.StatusInternalServerError, "internal_error", "An unexpected error occurred")
+	}
+}
+
+// newProblem creates problem details; the code extension identifies the problem,
+// so the type is left as about:blank
+func newProblem(status int, code, detail string) dto.ProblemDetails {
+	return dto.ProblemDetails{
+		Type:   "about:blank",
+		Title:  http.StatusText(status),
+		Status: status,
+		Detail: detail,
+		Code:   code,
+	}
+}
+
+// kindStatus maps a domain error kind to its HTTP status code
+func kindStatus(kind domain.Kind) int {
+	switch kind {
+	case domain.ErrNotFound:
+		return http.StatusNotFound
+	case domain.ErrConflict, domain.ErrInvalidStateTransition:
+		return http.StatusConflict
+	case domain.ErrValidation:
+		return http.StatusBadRequest
+	case domain.ErrForbidden:
+		return http.StatusForbidden
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// statusCode derives a code from an HTTP status, e.g. "method_not_allowed" for 405
+func statusCode(status int) string {
+	return strings.ToLower(strings.ReplaceAll(http.StatusText(status), " ", "_"))
+}
@@ -0,0 +1,381 @@
+package handlers
+
+import (
+	"errors"
+	"goclean/internal/application/authz"
+	"goclean/internal/application/bus"
+	"goclean/internal/application/commands"
+	"goclean/internal/application/dto"
+	"goclean/internal/application/queries"
+	"goclean/internal/domain/entities"
+	"goclean/internal/domain/services"
+	"goclean/internal/infrastructure/auth"
+	"net/http"
+	"path"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// OrderHandler handles order-related HTTP requests
+type OrderHandler struct {
+	commandBus *bus.CommandBus
+	queryBus   *bus.QueryBus
+}
+
+// NewOrderHandler creates a new order handler
+func NewOrderHandler(commandBus *bus.CommandBus, queryBus *bus.QueryBus) *OrderHandler {
+	return &OrderHandler{
+		commandBus: commandBus,
+		queryBus:   queryBus,
+	}
+}
+
+// CreateOrder places a new order for the current user
+// @Summary Create a new order
+// @Description Place an order for the authenticated user; prices are taken from the catalogue and stock is reserved
+// @Tags orders
+// @Accept json
+// @Produce json
+// @Param order body dto.CreateOrderRequest true "Order data"
+// @Success 201 {object} dto.OrderAPIResponse
+// @Header 201 {string} Location "URL of the created order"
+// @Failure 400 {object} dto.ProblemDetails
+// @Failure 401 {object} dto.ProblemDetails
+// @Failure 409 {object} dto.ProblemDetails
+// @Failure 500 {object} dto.ProblemDetails
+// @Router /api/v1/orders [post]
+// @Security BearerAuth
+func (h *OrderHandler) CreateOrder(c echo.Context) error {
+	var req dto.CreateOrderRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
+	}
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	userID, err := currentUserID(c)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, "User not authenticated")
+	}
+
+	// Convert to command
+	items := make([]commands.CreateOrderItemData, len(req.Items))
+	for i, item := range req.Items {
+		items[i] = commands.CreateOrderItemData{
+			ProductID: item.ProductID,
+			Quantity:  item.Quantity,
+		}
+	}
+	cmd := commands.CreateOrderCommand{
+		UserID: userID,
+		Items:  items,
+	}
+
+	// Execute command
+	order, err := bus.DispatchWithResult[*entities.Order](c.Request().Context(), h.commandBus, cmd)
+	if err != nil {
+		return err
+	}
+
+	setLocation(c, order.ID)
+	return c.JSON(http.StatusCreated, dto.APIResponse[*dto.OrderDTO]{
+		Success: true,
+		Data:    toOrderDTO(order),
+		Message: "Order created successfully",
+	})
+}
+
+// GetOrder retrieves an order by ID
+// @Summary Get order by ID
+// @Description Get an order by ID; users can only read their own orders unless they are admins
+// @Tags orders
+// @Produce json
+// @Param id path string true "Order ID"
+// @Success 200 {object} dto.OrderAPIResponse
+// @Failure 400 {object} dto.ProblemDetails
+// @Failure 401 {object} dto.ProblemDetails
+// @Failure 404 {object} dto.ProblemDetails
+// @Router /api/v1/orders/{id} [get]
+// @Security BearerAuth
+func (h *OrderHandler) GetOrder(c echo.Context) error {
+	order, err := h.loadAccessibleOrder(c)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, dto.APIResponse[*dto.OrderDTO]{
+		Success: true,
+		Data:    toOrderDTO(order),
+	})
+}
+
+// ListMyOrders retrieves the current user's orders with pagination
+// @Summary List my orders
+// @Description Get the authenticated user's orders with pagination
+// @Tags orders
+// @Produce json
+// @Param offset query int false "Offset" default(0)
+// @Param limit query int false "Limit" default(10)
+// @Success 200 {object} dto.OrdersListResponse
+// @Failure 401 {object} dto.ProblemDetails
+// @Failure 500 {object} dto.ProblemDetails
+// @Router /api/v1/orders [get]
+// @Security BearerAuth
+func (h *OrderHandler) ListMyOrders(c echo.Context) error {
+	userID, err := currentUserID(c)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, "User not authenticated")
+	}
+
+	offset, limit := paginationParams(c)
+	query := queries.GetOrdersByUserIDQuery{
+		UserID: userID,
+		Offset: offset,
+		Limit:  limit,
+	}
+	result, err := bus.Ask[*queries.OrdersResult](c.Request().Context(), h.queryBus, query)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, toOrdersListResponse(result, offset, limit))
+}
+
+// ListOrders retrieves all orders with pagination
+// @Summary List all orders
+// @Description Get every user's orders with pagination (admin only)
+// @Tags orders
+// @Produce json
+// @Param offset query int false "Offset" default(0)
+// @Param limit query int false "Limit" default(10)
+// @Success 200 {object} dto.OrdersListResponse
+// @Failure 401 {object} dto.ProblemDetails
+// @Failure 403 {object} dto.ProblemDetails
+// @Failure 500 {object} dto.ProblemDetails
+// @Router /api/v1/admin/orders [get]
+// @Security BearerAuth
+func (h *OrderHandler) ListOrders(c echo.Context) error {
+	offset, limit := paginationParams(c)
+	query := queries.ListOrdersQuery{
+		Offset: offset,
+		Limit:  limit,
+	}
+	result, err := bus.Ask[*queries.OrdersResult](c.Request().Context(), h.queryBus, query)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, toOrdersListResponse(result, offset, limit))
+}
+
+// UpdateOrderStatus moves an order to a new status
+// @Summary Update order status
+// @Description Move an order through its lifecycle (admin only)
+// @Tags orders
+// @Accept json
+// @Produce json
+// @Param id path string true "Order ID"
+// @Param status body dto.UpdateOrderStatusRequest true "New status"
+// @Success 200 {object} dto.OrderAPIResponse
+// @Failure 400 {object} dto.ProblemDetails
+// @Failure 401 {object} dto.ProblemDetails
+// @Failure 403 {object} dto.ProblemDetails
+// @Failure 404 {object} dto.ProblemDetails
+// @Failure 409 {object} dto.ProblemDetails
+// @Router /api/v1/admin/orders/{id}/status [patch]
+// @Security BearerAuth
+func (h *OrderHandler) UpdateOrderStatus(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid order ID")
+	}
+
+	var req dto.UpdateOrderStatusRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
+	}
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	changedBy, err := currentUserID(c)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, "User not authenticated")
+	}
+
+	cmd := commands.UpdateOrderStatusCommand{
+		ID:        id,
+		Status:    entities.OrderStatus(req.Status),
+		ChangedBy: changedBy,
+		Reason:    req.Reason,
+	}
+	order, err := bus.DispatchWithResult[*entities.Order](c.Request().Context(), h.commandBus, cmd)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, dto.APIResponse[*dto.OrderDTO]{
+		Success: true,
+		Data:    toOrderDTO(order),
+		Message: "Order status updated successfully",
+	})
+}
+
+// CancelOrder cancels an order that has not shipped yet
+// @Summary Cancel order
+// @Description Cancel an order and release its reserved stock; users can only cancel their own orders unless they are admins
+// @Tags orders
+// @Accept json
+// @Produce json
+// @Param id path string true "Order ID"
+// @Param cancellation body dto.CancelOrderRequest false "Cancellation reason"
+// @Success 200 {object} dto.OrderAPIResponse
+// @Failure 400 {object} dto.ProblemDetails
+// @Failure 401 {object} dto.ProblemDetails
+// @Failure 404 {object} dto.ProblemDetails
+// @Failure 409 {object} dto.ProblemDetails
+// @Router /api/v1/orders/{id}/cancel [post]
+// @Security BearerAuth
+func (h *OrderHandler) CancelOrder(c echo.Context) error {
+	order, err := h.loadAccessibleOrder(c)
+	if err != nil {
+		return err
+	}
+
+	var req dto.CancelOrderRequest
+	if c.Request().ContentLength > 0 {
+		if err := c.Bind(&req); err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
+		}
+		if err := c.Validate(&req); err != nil {
+			return err
+		}
+	}
+
+	cancelledBy, _ := currentUserID(c)
+	cmd := commands.CancelOrderCommand{
+		ID:          order.ID,
+		CancelledBy: cancelledBy,
+		Reason:      req.Reason,
+	}
+	cancelled, err := bus.DispatchWithResult[*entities.Order](c.Request().Context(), h.commandBus, cmd)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, dto.APIResponse[*dto.OrderDTO]{
+		Success: true,
+		Data:    toOrderDTO(cancelled),
+		Message: "Order cancelled successfully",
+	})
+}
+
+// loadAccessibleOrder loads the order in the path if the current user may access it.
+// Orders of other users are reported as not found so their existence is not revealed.
+func (h *OrderHandler) loadAccessibleOrder(c echo.Context) (*entities.Order, error) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return nil, echo.NewHTTPError(http.StatusBadRequest, "Invalid order ID")
+	}
+
+	// The query handler only returns orders the current user may read
+	result, err := bus.Ask[*queries.OrderResult](c.Request().Context(), h.queryBus, queries.GetOrderByIDQuery{ID: id})
+	if errors.Is(err, authz.ErrForbidden) {
+		return nil, services.ErrOrderNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return result.Order, nil
+}
+
+// currentUserID returns the ID of the authenticated user
+func currentUserID(c echo.Context) (uuid.UUID, error) {
+	claims, ok := c.Get("user_claims").(*auth.UserClaims)
+	if !ok {
+		return uuid.Nil, errors.New("user not authenticated")
+	}
+	return uuid.Parse(claims.UserID)
+}
+
+// setLocation points the Location header at a resource created under the request path
+func setLocation(c echo.Context, id uuid.UUID) {
+	c.Response().Header().Set(echo.HeaderLocation, path.Join(c.Request().URL.Path, id.String()))
+}
+
+// paginationParams reads offset and limit query parameters
+func paginationParams(c echo.Context) (int, int) {
+	offset, _ := strconv.Atoi(c.QueryParam("offset"))
+	limit, _ := strconv.Atoi(c.QueryParam("limit"))
+	if limit == 0 {
+		limit = 10
+	}
+	return offset, limit
+}
+
+// toOrdersListResponse converts an orders query result to a paginated response
+func toOrdersListResponse(result *queries.OrdersResult, offset, limit int) dto.PaginatedResponse[[]dto.OrderDTO] {
+	orderDTOs := make([]dto.OrderDTO, len(result.Orders))
+	for i, order := range result.Orders {
+		orderDTOs[i] = *toOrderDTO(order)
+	}
+
+	return dto.PaginatedResponse[[]dto.OrderDTO]{
+		APIResponse: dto.APIResponse[[]dto.OrderDTO]{
+			Success: true,
+			Data:    orderDTOs,
+		},
+		Pagination: dto.PaginationInfo{
+			Offset: offset,
+			Limit:  limit,
+			Total:  result.Total,
+		},
+	}
+}
+
+// toOrderDTO converts an order aggregate to its DTO
+func toOrderDTO(order *entities.Order) *dto.OrderDTO {
+	items := make([]dto.OrderItemDTO, len(order.Items))
+	for i, item := range order.Items {
+		items[i] = dto.OrderItemDTO{
+			ID:        item.ID,
+			OrderID:   item.OrderID,
+			ProductID: item.ProductID,
+			Quantity:  item.Quantity,
+			Price:     dto.MoneyDTO{Amount: item.Price.Amount, Currency: item.Price.Currency},
+			CreatedAt: item.CreatedAt,
+		}
+	}
+
+	history := make([]dto.OrderStatusHistoryDTO, len(order.StatusHistory))
+	for i := range order.StatusHistory {
+		history[i] = toOrderStatusHistoryDTO(&order.StatusHistory[i])
+	}
+
+	return &dto.OrderDTO{
+		ID:            order.ID,
+		UserID:        order.UserID,
+		Status:        string(order.Status),
+		TotalPrice:    dto.MoneyDTO{Amount: order.TotalPrice.Amount, Currency: order.TotalPrice.Currency},
+		Items:         items,
+		StatusHistory: history,
+		CreatedAt:     order.CreatedAt,
+		UpdatedAt:     order.UpdatedAt,
+	}
+}
+
+// toOrderStatusHistoryDTO converts an order status change to its DTO
+func toOrderStatusHistoryDTO(entry *entities.OrderStatusHistory) dto.OrderStatusHistoryDTO {
+	return dto.OrderStatusHistoryDTO{
+		ID:         entry.ID,
+		FromStatus: string(entry.FromStatus),
+		ToStatus:   string(entry.ToStatus),
+		ChangedBy:  entry.ChangedBy,
+		Reason:     entry.Reason,
+		ChangedAt:  entry.ChangedAt,
+	}
+}
@@ -0,0 +1,110 @@
+package handlers
+
+import (
+	"goclean/internal/application/bus"
+	"goclean/internal/application/commands"
+	"goclean/internal/application/dto"
+	"goclean/internal/domain/services"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// PrivacyHandler handles data export and erasure requests for users
+type PrivacyHandler struct {
+	commandBus *bus.CommandBus
+}
+
+// NewPrivacyHandler creates a new privacy handler
+func NewPrivacyHandler(commandBus *bus.CommandBus) *PrivacyHandler {
+	return &PrivacyHandler{
+		commandBus: commandBus,
+	}
+}
+
+// ExportUserData exports everything stored about a user
+// @Summary Export user data
+// @Description Export the user, profile, orders with their items, API keys and audit entries as a JSON archive for a subject access request (admin only)
+// @Tags admin
+// @Produce json
+// @Param id path string true "User ID"
+// @Success 200 {object} dto.UserDataExportDTO
+// @Failure 400 {object} dto.ProblemDetails
+// @Failure 401 {object} dto.ProblemDetails
+// @Failure 403 {object} dto.ProblemDetails
+// @Failure 404 {object} dto.ProblemDetails
+// @Router /api/v1/admin/users/{id}/export [post]
+// @Security BearerAuth
+func (h *PrivacyHandler) ExportUserData(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid user ID")
+	}
+
+	cmd := commands.ExportUserDataCommand{UserID: id}
+	export, err := bus.DispatchWithResult[*services.UserDataExport](c.Request().Context(), h.commandBus, cmd)
+	if err != nil {
+		return err
+	}
+
+	c.Response().Header().Set(echo.HeaderContentDisposition, `attachment; filename="user-`+id.String()+`.json"`)
+	return c.JSON(http.StatusOK, toUserDataExportDTO(export))
+}
+
+// EraseUserData erases the personal data of a user
+// @Summary Erase user data
+// @Description Anonymise the personal fields of a user and its profile and revoke its API keys, tokens and sessions; orders are kept for accounting (admin only)
+// @Tags admin
+// @Produce json
+// @Param id path string true "User ID"
+// @Success 200 {object} dto.MessageAPIResponse
+// @Failure 400 {object} dto.ProblemDetails
+// @Failure 401 {object} dto.ProblemDetails
+// @Failure 403 {object} dto.ProblemDetails
+// @Failure 404 {object} dto.ProblemDetails
+// @Failure 409 {object} dto.ProblemDetails
+// @Router /api/v1/admin/users/{id}/erase [post]
+// @Security BearerAuth
+func (h *PrivacyHandler) EraseUserData(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid user ID")
+	}
+
+	if err := h.commandBus.Dispatch(c.Request().Context(), commands.EraseUserDataCommand{UserID: id}); err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, dto.APIResponse[interface{}]{
+		Success: true,
+		Message: "User data erased successfully",
+	})
+}
+
+// toUserDataExportDTO converts a user data export to its archive DTO
+func toUserDataExportDTO(export *services.UserDataExport) *dto.UserDataExportDTO {
+	archive := &dto.UserDataExportDTO{
+		ExportedAt:   export.ExportedAt,
+		User:         toUserDTO(export.User, export.Profile),
+		ErasedAt:     export.User.ErasedAt,
+		Orders:       make([]*dto.OrderDTO, len(export.Orders)),
+		APIKeys:      make([]dto.APIKeyDTO, len(export.APIKeys)),
+		AuditEntries: make([]dto.AuditEntryDTO, len(export.AuditEntries)),
+	}
+	archive.DeletedAt, _ = export.User.DeletionInfo()
+
+	for i, order := range export.Orders {
+		archive.Orders[i] = toOrderDTO(order)
+	}
+	for i, key := range export.APIKeys {
+		archive.APIKeys[i] = toAPIKeyDTO(key)
+	}
+	for i, entry := range export.AuditEntries {
+		archive.AuditEntries[i] = dto.AuditEntryDTO{
+			OrderID:               entry.OrderID,
+			OrderStatusHistoryDTO: toOrderStatusHistoryDTO(entry),
+		}
+	}
+	return archive
+}
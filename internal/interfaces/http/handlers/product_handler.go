@@ -1,10 +1,11 @@
 package handlers
 
 import (
-	"context"
+	"goclean/internal/application/bus"
 	"goclean/internal/application/commands"
 	"goclean/internal/application/dto"
 	"goclean/internal/application/queries"
+	"goclean/internal/domain/entities"
 	"goclean/internal/infrastructure/auth"
 	"net/http"
 	"strconv"
@@ -15,18 +16,15 @@ import (
 
 // ProductHandler handles product-related HTTP requests
 type ProductHandler struct {
-	productCommandHandler *commands.ProductCommandHandler
-	productQueryHandler   *queries.ProductQueryHandler
+	commandBus *bus.CommandBus
+	queryBus   *bus.QueryBus
 }
 
 // NewProductHandler creates a new product handler
-func NewProductHandler(
-	productCommandHandler *commands.ProductCommandHandler,
-	productQueryHandler *queries.ProductQueryHandler,
-) *ProductHandler {
+func NewProductHandler(commandBus *bus.CommandBus, queryBus *bus.QueryBus) *ProductHandler {
 	return &ProductHandler{
-		productCommandHandler: productCommandHandler,
-		productQueryHandler:   productQueryHandler,
+		commandBus: commandBus,
+		queryBus:   queryBus,
 	}
 }
 
@@ -38,57 +36,57 @@ func NewProductHandler(
 // @Produce json
 // @Param product body dto.CreateProductRequest true "Product data"
 // @Success 201 {object} dto.ProductAPIResponse
-// @Failure 400 {object} dto.ErrorAPIResponse
-// @Failure 401 {object} dto.ErrorAPIResponse
-// @Failure 500 {object} dto.ErrorAPIResponse
+// @Header 201 {string} Location "URL of the created product"
+// @Failure 400 {object} dto.ProblemDetails
+// @Failure 401 {object} dto.ProblemDetails
+// @Failure 500 {object} dto.ProblemDetails
 // @Router /api/v1/products [post]
 // @Security BearerAuth
 func (h *ProductHandler) CreateProduct(c echo.Context) error {
 	var req dto.CreateProductRequest
 	if err := c.Bind(&req); err != nil {
-		return c.JSON(http.StatusBadRequest, dto.APIResponse[interface{}]{
-			Success: false,
-			Error:   "Invalid request body",
-		})
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
+	}
+	if err := c.Validate(&req); err != nil {
+		return err
 	}
 
 	// Get current user from context
 	claims, ok := c.Get("user_claims").(*auth.UserClaims)
 	if !ok {
-		return c.JSON(http.StatusUnauthorized, dto.APIResponse[interface{}]{
-			Success: false,
-			Error:   "User not authenticated",
-		})
+		return echo.NewHTTPError(http.StatusUnauthorized, "User not authenticated")
 	}
 
 	createdBy, err := uuid.Parse(claims.UserID)
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, dto.APIResponse[interface{}]{
-			Success: false,
-			Error:   "Invalid user ID",
-		})
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid user ID")
+	}
+
+	if req.Price.Currency == "" {
+		req.Price.Currency = entities.DefaultCurrency
 	}
 
 	// Convert to command
 	cmd := commands.CreateProductCommand{
 		Name:        req.Name,
 		Description: req.Description,
-		Price:       req.Price,
+		Price:       entities.Money{Amount: req.Price.Amount, Currency: req.Price.Currency},
 		SKU:         req.SKU,
 		Category:    req.Category,
+		Stock:       req.Stock,
 		CreatedBy:   createdBy,
 	}
 
 	// Execute command
-	if err := h.productCommandHandler.Handle(context.Background(), cmd); err != nil {
-		return c.JSON(http.StatusInternalServerError, dto.APIResponse[interface{}]{
-			Success: false,
-			Error:   err.Error(),
-		})
+	product, err := bus.DispatchWithResult[*entities.Product](c.Request().Context(), h.commandBus, cmd)
+	if err != nil {
+		return err
 	}
 
-	return c.JSON(http.StatusCreated, dto.APIResponse[interface{}]{
+	setLocation(c, product.ID)
+	return c.JSON(http.StatusCreated, dto.APIResponse[*dto.ProductDTO]{
 		Success: true,
+		Data:    toProductDTO(product),
 		Message: "Product created successfully",
 	})
 }
@@ -100,45 +98,25 @@ func (h *ProductHandler) CreateProduct(c echo.Context) error {
 // @Produce json
 // @Param id path string true "Product ID"
 // @Success 200 {object} dto.ProductAPIResponse
-// @Failure 400 {object} dto.ErrorAPIResponse
-// @Failure 404 {object} dto.ErrorAPIResponse
+// @Failure 400 {object} dto.ProblemDetails
+// @Failure 404 {object} dto.ProblemDetails
 // @Router /api/v1/products/{id} [get]
 func (h *ProductHandler) GetProduct(c echo.Context) error {
 	idStr := c.Param("id")
 	id, err := uuid.Parse(idStr)
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, dto.APIResponse[interface{}]{
-			Success: false,
-			Error:   "Invalid product ID",
-		})
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid product ID")
 	}
 
 	query := queries.GetProductByIDQuery{ID: id}
-	result, err := h.productQueryHandler.Handle(context.Background(), query)
+	result, err := bus.Ask[*queries.ProductResult](c.Request().Context(), h.queryBus, query)
 	if err != nil {
-		return c.JSON(http.StatusNotFound, dto.APIResponse[interface{}]{
-			Success: false,
-			Error:   "Product not found",
-		})
-	}
-
-	// Convert to DTO
-	productDTO := &dto.ProductDTO{
-		ID:          result.Product.ID,
-		Name:        result.Product.Name,
-		Description: result.Product.Description,
-		Price:       result.Product.Price,
-		SKU:         result.Product.SKU,
-		Category:    result.Product.Category,
-		IsActive:    result.Product.IsActive,
-		CreatedBy:   result.Product.CreatedBy,
-		CreatedAt:   result.Product.CreatedAt,
-		UpdatedAt:   result.Product.UpdatedAt,
+		return err
 	}
 
 	return c.JSON(http.StatusOK, dto.APIResponse[*dto.ProductDTO]{
 		Success: true,
-		Data:    productDTO,
+		Data:    toProductDTO(result.Product),
 	})
 }
 
@@ -152,7 +130,7 @@ func (h *ProductHandler) GetProduct(c echo.Context) error {
 // @Param category query string false "Category filter"
 // @Param search query string false "Search query"
 // @Success 200 {object} dto.ProductsListResponse
-// @Failure 400 {object} dto.ErrorAPIResponse
+// @Failure 400 {object} dto.ProblemDetails
 // @Router /api/v1/products [get]
 func (h *ProductHandler) ListProducts(c echo.Context) error {
 	offset, _ := strconv.Atoi(c.QueryParam("offset"))
@@ -173,12 +151,9 @@ func (h *ProductHandler) ListProducts(c echo.Context) error {
 			Offset: offset,
 			Limit:  limit,
 		}
-		result, err = h.productQueryHandler.HandleSearch(context.Background(), query)
+		result, err = bus.Ask[*queries.ProductsResult](c.Request().Context(), h.queryBus, query)
 		if err != nil {
-			return c.JSON(http.StatusInternalServerError, dto.APIResponse[interface{}]{
-				Success: false,
-				Error:   err.Error(),
-			})
+			return err
 		}
 	} else if category != "" {
 		query := queries.ListProductsByCategoryQuery{
@@ -186,42 +161,25 @@ func (h *ProductHandler) ListProducts(c echo.Context) error {
 			Offset:   offset,
 			Limit:    limit,
 		}
-		result, err = h.productQueryHandler.HandleByCategory(context.Background(), query)
+		result, err = bus.Ask[*queries.ProductsResult](c.Request().Context(), h.queryBus, query)
 		if err != nil {
-			return c.JSON(http.StatusInternalServerError, dto.APIResponse[interface{}]{
-				Success: false,
-				Error:   err.Error(),
-			})
+			return err
 		}
 	} else {
 		query := queries.ListProductsQuery{
 			Offset: offset,
 			Limit:  limit,
 		}
-		result, err = h.productQueryHandler.HandleList(context.Background(), query)
+		result, err = bus.Ask[*queries.ProductsResult](c.Request().Context(), h.queryBus, query)
 		if err != nil {
-			return c.JSON(http.StatusInternalServerError, dto.APIResponse[interface{}]{
-				Success: false,
-				Error:   err.Error(),
-			})
+			return err
 		}
 	}
 
 	// Convert to DTOs
 	productDTOs := make([]dto.ProductDTO, len(result.Products))
 	for i, product := range result.Products {
-		productDTOs[i] = dto.ProductDTO{
-			ID:          product.ID,
-			Name:        product.Name,
-			Description: product.Description,
-			Price:       product.Price,
-			SKU:         product.SKU,
-			Category:    product.Category,
-			IsActive:    product.IsActive,
-			CreatedBy:   product.CreatedBy,
-			CreatedAt:   product.CreatedAt,
-			UpdatedAt:   product.UpdatedAt,
-		}
+		productDTOs[i] = *toProductDTO(product)
 	}
 
 	return c.JSON(http.StatusOK, dto.PaginatedResponse[[]dto.ProductDTO]{
@@ -236,3 +194,199 @@ func (h *ProductHandler) ListProducts(c echo.Context) error {
 		},
 	})
 }
+
+// UpdateProduct partially updates a product
+// @Summary Update product
+// @Description Update the given fields of a product; omitted fields are left unchanged (admin only)
+// @Tags products
+// @Accept json
+// @Produce json
+// @Param id path string true "Product ID"
+// @Param product body dto.UpdateProductRequest true "Fields to update"
+// @Success 200 {object} dto.ProductAPIResponse
+// @Failure 400 {object} dto.ProblemDetails
+// @Failure 401 {object} dto.ProblemDetails
+// @Failure 403 {object} dto.ProblemDetails
+// @Failure 404 {object} dto.ProblemDetails
+// @Failure 409 {object} dto.ProblemDetails
+// @Router /api/v1/products/{id} [patch]
+// @Security BearerAuth
+func (h *ProductHandler) UpdateProduct(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid product ID")
+	}
+
+	var req dto.UpdateProductRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
+	}
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	cmd := commands.UpdateProductCommand{
+		ID:          id,
+		Name:        req.Name,
+		Description: req.Description,
+		SKU:         req.SKU,
+		Category:    req.Category,
+		IsActive:    req.IsActive,
+	}
+	if req.Price != nil {
+		if req.Price.Currency == "" {
+			req.Price.Currency = entities.DefaultCurrency
+		}
+		cmd.Price = &entities.Money{Amount: req.Price.Amount, Currency: req.Price.Currency}
+	}
+
+	product, err := bus.DispatchWithResult[*entities.Product](c.Request().Context(), h.commandBus, cmd)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, dto.APIResponse[*dto.ProductDTO]{
+		Success: true,
+		Data:    toProductDTO(product),
+		Message: "Product updated successfully",
+	})
+}
+
+// DeleteProduct deletes a product
+// @Summary Delete product
+// @Description Soft delete a product so it is no longer sold, optionally recording why (admin only)
+// @Tags products
+// @Accept json
+// @Produce json
+// @Param id path string true "Product ID"
+// @Param request body dto.SoftDeleteRequest false "Deletion reason"
+// @Success 200 {object} dto.MessageAPIResponse
+// @Failure 400 {object} dto.ProblemDetails
+// @Failure 401 {object} dto.ProblemDetails
+// @Failure 403 {object} dto.ProblemDetails
+// @Failure 404 {object} dto.ProblemDetails
+// @Failure 409 {object} dto.ProblemDetails
+// @Router /api/v1/products/{id} [delete]
+// @Security BearerAuth
+func (h *ProductHandler) DeleteProduct(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid product ID")
+	}
+
+	// The body is optional; without one the product is deleted without a reason
+	var req dto.SoftDeleteRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
+	}
+
+	cmd := commands.SoftDeleteProductCommand{ID: id, Reason: req.Reason}
+	if err := h.commandBus.Dispatch(c.Request().Context(), cmd); err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, dto.APIResponse[interface{}]{
+		Success: true,
+		Message: "Product deleted successfully",
+	})
+}
+
+// RestockProduct adds stock for a product
+// @Summary Restock product
+// @Description Add stock for a product and return its updated stock level (admin only)
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param id path string true "Product ID"
+// @Param restock body dto.RestockProductRequest true "Quantity to add"
+// @Success 200 {object} dto.StockLevelAPIResponse
+// @Failure 400 {object} dto.ProblemDetails
+// @Failure 401 {object} dto.ProblemDetails
+// @Failure 403 {object} dto.ProblemDetails
+// @Failure 404 {object} dto.ProblemDetails
+// @Router /api/v1/admin/products/{id}/restock [post]
+// @Security BearerAuth
+func (h *ProductHandler) RestockProduct(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid product ID")
+	}
+
+	var req dto.RestockProductRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
+	}
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	cmd := commands.RestockProductCommand{ProductID: id, Quantity: req.Quantity}
+	item, err := bus.DispatchWithResult[*entities.InventoryItem](c.Request().Context(), h.commandBus, cmd)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, dto.APIResponse[*dto.StockLevelDTO]{
+		Success: true,
+		Data:    toStockLevelDTO(item),
+		Message: "Product restocked successfully",
+	})
+}
+
+// GetProductStock retrieves the stock level of a product
+// @Summary Get product stock level
+// @Description Get the stock on hand, reserved and available for a product (admin only)
+// @Tags admin
+// @Produce json
+// @Param id path string true "Product ID"
+// @Success 200 {object} dto.StockLevelAPIResponse
+// @Failure 400 {object} dto.ProblemDetails
+// @Failure 401 {object} dto.ProblemDetails
+// @Failure 403 {object} dto.ProblemDetails
+// @Failure 404 {object} dto.ProblemDetails
+// @Router /api/v1/admin/products/{id}/stock [get]
+// @Security BearerAuth
+func (h *ProductHandler) GetProductStock(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid product ID")
+	}
+
+	query := queries.GetProductStockQuery{ProductID: id}
+	item, err := bus.Ask[*entities.InventoryItem](c.Request().Context(), h.queryBus, query)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, dto.APIResponse[*dto.StockLevelDTO]{
+		Success: true,
+		Data:    toStockLevelDTO(item),
+	})
+}
+
+// toProductDTO converts a product to its DTO
+func toProductDTO(product *entities.Product) *dto.ProductDTO {
+	return &dto.ProductDTO{
+		ID:          product.ID,
+		Name:        product.Name,
+		Description: product.Description,
+		Price:       dto.MoneyDTO{Amount: product.Price.Amount, Currency: product.Price.Currency},
+		SKU:         product.SKU,
+		Category:    product.Category,
+		IsActive:    product.IsActive,
+		CreatedBy:   product.CreatedBy,
+		CreatedAt:   product.CreatedAt,
+		UpdatedAt:   product.UpdatedAt,
+	}
+}
+
+// toStockLevelDTO converts an inventory item to its stock level DTO
+func toStockLevelDTO(item *entities.InventoryItem) *dto.StockLevelDTO {
+	return &dto.StockLevelDTO{
+		ProductID: item.ProductID,
+		OnHand:    item.OnHand,
+		Reserved:  item.Reserved,
+		Available: item.Available(),
+		UpdatedAt: item.UpdatedAt,
+	}
+}
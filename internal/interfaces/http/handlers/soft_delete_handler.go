@@ -1,174 +1,172 @@
 package handlers
 
 import (
-	"net/http"
-	"strconv"
-
+	"goclean/internal/application/bus"
 	"goclean/internal/application/commands"
+	"goclean/internal/application/dto"
 	"goclean/internal/application/queries"
+	"goclean/internal/domain/entities"
+	"net/http"
 
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
 )
 
-// SoftDeleteHandler handles soft delete operations for various entities
-type SoftDeleteHandler struct {
-	softDeleteUserHandler *commands.SoftDeleteUserCommandHandler
-	restoreUserHandler    *commands.RestoreUserCommandHandler
-	deletedUsersHandler   *queries.DeletedUsersQueryHandler
+// SoftDeleteHandler handles the soft delete lifecycle endpoints of one entity type,
+// rendering entities of type T as D
+type SoftDeleteHandler[T entities.SoftDeletable, D any] struct {
+	commandBus *bus.CommandBus
+	queryBus   *bus.QueryBus
+	name       string // Entity name used in messages, e.g. "Order"
+	toDTO      func(T) D
 }
 
-// NewSoftDeleteHandler creates a new soft delete handler
-func NewSoftDeleteHandler(
-	softDeleteUserHandler *commands.SoftDeleteUserCommandHandler,
-	restoreUserHandler *commands.RestoreUserCommandHandler,
-	deletedUsersHandler *queries.DeletedUsersQueryHandler,
-) *SoftDeleteHandler {
-	return &SoftDeleteHandler{
-		softDeleteUserHandler: softDeleteUserHandler,
-		restoreUserHandler:    restoreUserHandler,
-		deletedUsersHandler:   deletedUsersHandler,
+// NewSoftDeleteHandler creates a soft delete handler
+func NewSoftDeleteHandler[T entities.SoftDeletable, D any](commandBus *bus.CommandBus, queryBus *bus.QueryBus, name string, toDTO func(T) D) *SoftDeleteHandler[T, D] {
+	return &SoftDeleteHandler[T, D]{
+		commandBus: commandBus,
+		queryBus:   queryBus,
+		name:       name,
+		toDTO:      toDTO,
 	}
 }
 
-// SoftDeleteUser godoc
-// @Summary Soft delete a user
-// @Description Marks a user as deleted without permanently removing from database
-// @Tags users
-// @Accept json
-// @Produce json
-// @Param id path string true "User ID"
-// @Param body body commands.SoftDeleteUserCommand true "Soft delete command"
-// @Success 204 "User soft deleted successfully"
-// @Failure 400 {object} map[string]string
-// @Failure 404 {object} map[string]string
-// @Failure 500 {object} map[string]string
-// @Router /users/{id}/soft-delete [delete]
-func (h *SoftDeleteHandler) SoftDeleteUser(c echo.Context) error {
-	idStr := c.Param("id")
-	userID, err := uuid.Parse(idStr)
-	if err != nil {
-		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid user ID"})
-	}
-
-	var req commands.SoftDeleteUserCommand
-	req.UserID = userID
-
-	if err := c.Bind(&req); err == nil {
-		// If body is provided, use it (for reason, etc.)
-	}
+// SoftDeleteHandlers holds the soft delete handlers of every aggregate
+type SoftDeleteHandlers struct {
+	Users    *SoftDeleteHandler[*entities.User, *dto.UserDTO]
+	Profiles *SoftDeleteHandler[*entities.Profile, *dto.ProfileDTO]
+	Products *SoftDeleteHandler[*entities.Product, *dto.ProductDTO]
+	Orders   *SoftDeleteHandler[*entities.Order, *dto.OrderDTO]
+}
 
-	if err := h.softDeleteUserHandler.Handle(c.Request().Context(), req); err != nil {
-		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+// NewSoftDeleteHandlers creates the soft delete handlers of every aggregate
+func NewSoftDeleteHandlers(commandBus *bus.CommandBus, queryBus *bus.QueryBus) *SoftDeleteHandlers {
+	return &SoftDeleteHandlers{
+		Users: NewSoftDeleteHandler(commandBus, queryBus, "User", func(user *entities.User) *dto.UserDTO {
+			return toUserDTO(user, user.Profile)
+		}),
+		Profiles: NewSoftDeleteHandler(commandBus, queryBus, "Profile", toProfileDTO),
+		Products: NewSoftDeleteHandler(commandBus, queryBus, "Product", toProductDTO),
+		Orders:   NewSoftDeleteHandler(commandBus, queryBus, "Order", toOrderDTO),
 	}
-
-	return c.NoContent(http.StatusNoContent)
 }
 
-// RestoreUser godoc
-// @Summary Restore a soft deleted user
-// @Description Restores a previously soft deleted user
-// @Tags users
+// SoftDelete soft deletes an entity
+// @Summary Soft delete an entity
+// @Description Mark a user, profile, product or order as deleted, optionally recording why; orders must be delivered or cancelled first (admin only)
+// @Tags admin
 // @Accept json
 // @Produce json
-// @Param id path string true "User ID"
-// @Param body body commands.RestoreUserCommand true "Restore command"
-// @Success 204 "User restored successfully"
-// @Failure 400 {object} map[string]string
-// @Failure 404 {object} map[string]string
-// @Failure 500 {object} map[string]string
-// @Router /users/{id}/restore [post]
-func (h *SoftDeleteHandler) RestoreUser(c echo.Context) error {
-	idStr := c.Param("id")
-	userID, err := uuid.Parse(idStr)
+// @Param resource path string true "Resource type" Enums(users, profiles, products, orders)
+// @Param id path string true "Entity ID"
+// @Param request body dto.SoftDeleteRequest false "Deletion reason"
+// @Success 200 {object} dto.MessageAPIResponse
+// @Failure 400 {object} dto.ProblemDetails
+// @Failure 401 {object} dto.ProblemDetails
+// @Failure 403 {object} dto.ProblemDetails
+// @Failure 404 {object} dto.ProblemDetails
+// @Failure 409 {object} dto.ProblemDetails
+// @Router /api/v1/admin/{resource}/{id} [delete]
+// @Security BearerAuth
+func (h *SoftDeleteHandler[T, D]) SoftDelete(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid user ID"})
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid "+h.name+" ID")
 	}
 
-	var req commands.RestoreUserCommand
-	req.UserID = userID
-
-	if err := c.Bind(&req); err == nil {
-		// If body is provided, use it (for reason, etc.)
+	// The body is optional; without one the entity is deleted without a reason
+	var req dto.SoftDeleteRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
 	}
 
-	if err := h.restoreUserHandler.Handle(c.Request().Context(), req); err != nil {
-		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	cmd := commands.SoftDeleteCommand[T]{ID: id, Reason: req.Reason}
+	if err := h.commandBus.Dispatch(c.Request().Context(), cmd); err != nil {
+		return err
 	}
 
-	return c.NoContent(http.StatusNoContent)
+	return c.JSON(http.StatusOK, dto.APIResponse[interface{}]{
+		Success: true,
+		Message: h.name + " deleted successfully",
+	})
 }
 
-// GetDeletedUsers godoc
-// @Summary Get deleted users
-// @Description Retrieves a list of soft deleted users
-// @Tags users
-// @Accept json
+// Restore restores a soft deleted entity
+// @Summary Restore a soft deleted entity
+// @Description Restore a soft deleted user, profile, product or order (admin only)
+// @Tags admin
 // @Produce json
-// @Param offset query int false "Offset for pagination" default(0)
-// @Param limit query int false "Limit for pagination" default(10)
-// @Success 200 {array} entities.User
-// @Failure 400 {object} map[string]string
-// @Failure 500 {object} map[string]string
-// @Router /users/deleted [get]
-func (h *SoftDeleteHandler) GetDeletedUsers(c echo.Context) error {
-	offsetStr := c.QueryParam("offset")
-	limitStr := c.QueryParam("limit")
-
-	offset := 0
-	limit := 10
-
-	if offsetStr != "" {
-		if parsed, err := strconv.Atoi(offsetStr); err == nil {
-			offset = parsed
-		}
-	}
-
-	if limitStr != "" {
-		if parsed, err := strconv.Atoi(limitStr); err == nil {
-			limit = parsed
-		}
-	}
-
-	query := queries.GetDeletedUsersQuery{
-		Offset: offset,
-		Limit:  limit,
+// @Param resource path string true "Resource type" Enums(users, profiles, products, orders)
+// @Param id path string true "Entity ID"
+// @Success 200 {object} dto.MessageAPIResponse
+// @Failure 400 {object} dto.ProblemDetails
+// @Failure 401 {object} dto.ProblemDetails
+// @Failure 403 {object} dto.ProblemDetails
+// @Failure 404 {object} dto.ProblemDetails
+// @Failure 409 {object} dto.ProblemDetails
+// @Router /api/v1/admin/{resource}/{id}/restore [post]
+// @Security BearerAuth
+func (h *SoftDeleteHandler[T, D]) Restore(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid "+h.name+" ID")
 	}
 
-	users, err := h.deletedUsersHandler.GetDeletedUsers(c.Request().Context(), query)
-	if err != nil {
-		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	if err := h.commandBus.Dispatch(c.Request().Context(), commands.RestoreCommand[T]{ID: id}); err != nil {
+		return err
 	}
 
-	return c.JSON(http.StatusOK, users)
+	return c.JSON(http.StatusOK, dto.APIResponse[interface{}]{
+		Success: true,
+		Message: h.name + " restored successfully",
+	})
 }
 
-// GetUserWithDeleted godoc
-// @Summary Get user including deleted
-// @Description Retrieves a user by ID including soft deleted users
-// @Tags users
-// @Accept json
+// ListDeleted lists soft deleted entities
+// @Summary List soft deleted entities
+// @Description List soft deleted users, profiles, products or orders with when and why they were deleted (admin only)
+// @Tags admin
 // @Produce json
-// @Param id path string true "User ID"
-// @Success 200 {object} entities.User
-// @Failure 400 {object} map[string]string
-// @Failure 404 {object} map[string]string
-// @Router /users/{id}/with-deleted [get]
-func (h *SoftDeleteHandler) GetUserWithDeleted(c echo.Context) error {
-	idStr := c.Param("id")
-	userID, err := uuid.Parse(idStr)
-	if err != nil {
-		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid user ID"})
+// @Param resource path string true "Resource type" Enums(users, profiles, products, orders)
+// @Param offset query int false "Offset" default(0)
+// @Param limit query int false "Limit" default(10)
+// @Success 200 {object} dto.DeletedResourcesListResponse
+// @Failure 400 {object} dto.ProblemDetails
+// @Failure 401 {object} dto.ProblemDetails
+// @Failure 403 {object} dto.ProblemDetails
+// @Router /api/v1/admin/{resource}/deleted [get]
+// @Security BearerAuth
+func (h *SoftDeleteHandler[T, D]) ListDeleted(c echo.Context) error {
+	offset, limit := paginationParams(c)
+	query := queries.ListDeletedQuery[T]{
+		Offset: offset,
+		Limit:  limit,
 	}
 
-	query := queries.GetUserWithDeletedQuery{
-		UserID: userID,
+	deleted, err := bus.Ask[[]T](c.Request().Context(), h.queryBus, query)
+	if err != nil {
+		return err
 	}
 
-	user, err := h.deletedUsersHandler.GetUserWithDeleted(c.Request().Context(), query)
-	if err != nil {
-		return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+	deletedDTOs := make([]dto.DeletedResourceDTO[D], len(deleted))
+	for i, entity := range deleted {
+		deletedAt, reason := entity.DeletionInfo()
+		deletedDTOs[i] = dto.DeletedResourceDTO[D]{
+			Resource:       h.toDTO(entity),
+			DeletedAt:      deletedAt,
+			DeletionReason: reason,
+		}
 	}
 
-	return c.JSON(http.StatusOK, user)
+	return c.JSON(http.StatusOK, dto.PaginatedResponse[[]dto.DeletedResourceDTO[D]]{
+		APIResponse: dto.APIResponse[[]dto.DeletedResourceDTO[D]]{
+			Success: true,
+			Data:    deletedDTOs,
+		},
+		Pagination: dto.PaginationInfo{
+			Offset: offset,
+			Limit:  limit,
+			Total:  len(deletedDTOs), // In a real implementation, you'd get total count separately
+		},
+	})
 }
@@ -1,10 +1,11 @@
 package handlers
 
 import (
-	"context"
+	"goclean/internal/application/bus"
 	"goclean/internal/application/commands"
 	"goclean/internal/application/dto"
 	"goclean/internal/application/queries"
+	"goclean/internal/domain/entities"
 	"goclean/internal/infrastructure/auth"
 	"net/http"
 	"strconv"
@@ -15,18 +16,15 @@ import (
 
 // UserHandler handles user-related HTTP requests
 type UserHandler struct {
-	userCommandHandler *commands.UserCommandHandler
-	userQueryHandler   *queries.UserQueryHandler
+	commandBus *bus.CommandBus
+	queryBus   *bus.QueryBus
 }
 
 // NewUserHandler creates a new user handler
-func NewUserHandler(
-	userCommandHandler *commands.UserCommandHandler,
-	userQueryHandler *queries.UserQueryHandler,
-) *UserHandler {
+func NewUserHandler(commandBus *bus.CommandBus, queryBus *bus.QueryBus) *UserHandler {
 	return &UserHandler{
-		userCommandHandler: userCommandHandler,
-		userQueryHandler:   userQueryHandler,
+		commandBus: commandBus,
+		queryBus:   queryBus,
 	}
 }
 
@@ -38,16 +36,18 @@ func NewUserHandler(
 // @Produce json
 // @Param user body dto.CreateUserRequest true "User data"
 // @Success 201 {object} dto.UserAPIResponse
-// @Failure 400 {object} dto.ErrorAPIResponse
-// @Failure 500 {object} dto.ErrorAPIResponse
+// @Header 201 {string} Location "URL of the created user"
+// @Failure 409 {object} dto.ProblemDetails
+// @Failure 400 {object} dto.ProblemDetails
+// @Failure 500 {object} dto.ProblemDetails
 // @Router /api/v1/users [post]
 func (h *UserHandler) CreateUser(c echo.Context) error {
 	var req dto.CreateUserRequest
 	if err := c.Bind(&req); err != nil {
-		return c.JSON(http.StatusBadRequest, dto.APIResponse[interface{}]{
-			Success: false,
-			Error:   "Invalid request body",
-		})
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
+	}
+	if err := c.Validate(&req); err != nil {
+		return err
 	}
 
 	// Convert to command
@@ -67,88 +67,61 @@ func (h *UserHandler) CreateUser(c echo.Context) error {
 	}
 
 	// Execute command
-	if err := h.userCommandHandler.Handle(context.Background(), cmd); err != nil {
-		return c.JSON(http.StatusInternalServerError, dto.APIResponse[interface{}]{
-			Success: false,
-			Error:   err.Error(),
-		})
+	user, err := bus.DispatchWithResult[*entities.User](c.Request().Context(), h.commandBus, cmd)
+	if err != nil {
+		return err
 	}
 
-	return c.JSON(http.StatusCreated, dto.APIResponse[interface{}]{
+	setLocation(c, user.ID)
+	return c.JSON(http.StatusCreated, dto.APIResponse[*dto.UserDTO]{
 		Success: true,
+		Data:    toUserDTO(user, user.Profile),
 		Message: "User created successfully",
 	})
 }
 
 // GetUser retrieves a user by ID
 // @Summary Get user by ID
-// @Description Get user information by user ID
+// @Description Get user information by user ID; users can only read themselves unless they are admins
 // @Tags users
 // @Produce json
 // @Param id path string true "User ID"
 // @Success 200 {object} dto.UserAPIResponse
-// @Failure 400 {object} dto.ErrorAPIResponse
-// @Failure 404 {object} dto.ErrorAPIResponse
+// @Failure 400 {object} dto.ProblemDetails
+// @Failure 401 {object} dto.ProblemDetails
+// @Failure 403 {object} dto.ProblemDetails
+// @Failure 404 {object} dto.ProblemDetails
 // @Router /api/v1/users/{id} [get]
 // @Security BearerAuth
 func (h *UserHandler) GetUser(c echo.Context) error {
 	idStr := c.Param("id")
 	id, err := uuid.Parse(idStr)
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, dto.APIResponse[interface{}]{
-			Success: false,
-			Error:   "Invalid user ID",
-		})
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid user ID")
 	}
 
 	query := queries.GetUserByIDQuery{ID: id}
-	result, err := h.userQueryHandler.Handle(context.Background(), query)
+	result, err := bus.Ask[*queries.UserResult](c.Request().Context(), h.queryBus, query)
 	if err != nil {
-		return c.JSON(http.StatusNotFound, dto.APIResponse[interface{}]{
-			Success: false,
-			Error:   "User not found",
-		})
-	}
-
-	// Convert to DTO
-	userDTO := &dto.UserDTO{
-		ID:        result.User.ID,
-		Email:     result.User.Email,
-		Username:  result.User.Username,
-		FirstName: result.User.FirstName,
-		LastName:  result.User.LastName,
-		IsActive:  result.User.IsActive,
-		CreatedAt: result.User.CreatedAt,
-		UpdatedAt: result.User.UpdatedAt,
-	}
-
-	if result.Profile != nil {
-		userDTO.Profile = &dto.ProfileDTO{
-			ID:          result.Profile.ID,
-			UserID:      result.Profile.UserID,
-			Bio:         result.Profile.Bio,
-			Avatar:      result.Profile.Avatar,
-			DateOfBirth: result.Profile.DateOfBirth,
-			CreatedAt:   result.Profile.CreatedAt,
-			UpdatedAt:   result.Profile.UpdatedAt,
-		}
+		return err
 	}
 
 	return c.JSON(http.StatusOK, dto.APIResponse[*dto.UserDTO]{
 		Success: true,
-		Data:    userDTO,
+		Data:    toUserDTO(result.User, result.Profile),
 	})
 }
 
 // ListUsers retrieves users with pagination
 // @Summary List users
-// @Description Get list of users with pagination
+// @Description Get list of users with pagination (admin only)
 // @Tags users
 // @Produce json
 // @Param offset query int false "Offset" default(0)
 // @Param limit query int false "Limit" default(10)
 // @Success 200 {object} dto.UsersListResponse
-// @Failure 400 {object} dto.ErrorAPIResponse
+// @Failure 400 {object} dto.ProblemDetails
+// @Failure 403 {object} dto.ProblemDetails
 // @Router /api/v1/users [get]
 // @Security BearerAuth
 func (h *UserHandler) ListUsers(c echo.Context) error {
@@ -163,39 +136,15 @@ func (h *UserHandler) ListUsers(c echo.Context) error {
 		Limit:  limit,
 	}
 
-	result, err := h.userQueryHandler.HandleList(context.Background(), query)
+	result, err := bus.Ask[*queries.UsersResult](c.Request().Context(), h.queryBus, query)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, dto.APIResponse[interface{}]{
-			Success: false,
-			Error:   err.Error(),
-		})
+		return err
 	}
 
 	// Convert to DTOs
 	userDTOs := make([]dto.UserDTO, len(result.Users))
 	for i, userResult := range result.Users {
-		userDTOs[i] = dto.UserDTO{
-			ID:        userResult.User.ID,
-			Email:     userResult.User.Email,
-			Username:  userResult.User.Username,
-			FirstName: userResult.User.FirstName,
-			LastName:  userResult.User.LastName,
-			IsActive:  userResult.User.IsActive,
-			CreatedAt: userResult.User.CreatedAt,
-			UpdatedAt: userResult.User.UpdatedAt,
-		}
-
-		if userResult.Profile != nil {
-			userDTOs[i].Profile = &dto.ProfileDTO{
-				ID:          userResult.Profile.ID,
-				UserID:      userResult.Profile.UserID,
-				Bio:         userResult.Profile.Bio,
-				Avatar:      userResult.Profile.Avatar,
-				DateOfBirth: userResult.Profile.DateOfBirth,
-				CreatedAt:   userResult.Profile.CreatedAt,
-				UpdatedAt:   userResult.Profile.UpdatedAt,
-			}
-		}
+		userDTOs[i] = *toUserDTO(userResult.User, userResult.Profile)
 	}
 
 	return c.JSON(http.StatusOK, dto.PaginatedResponse[[]dto.UserDTO]{
@@ -211,68 +160,157 @@ func (h *UserHandler) ListUsers(c echo.Context) error {
 	})
 }
 
+// UpdateUser partially updates a user
+// @Summary Update user
+// @Description Update the given fields of a user; omitted fields are left unchanged. Users can only update themselves unless they are admins
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param id path string true "User ID"
+// @Param user body dto.UpdateUserRequest true "Fields to update"
+// @Success 200 {object} dto.UserAPIResponse
+// @Failure 400 {object} dto.ProblemDetails
+// @Failure 401 {object} dto.ProblemDetails
+// @Failure 403 {object} dto.ProblemDetails
+// @Failure 404 {object} dto.ProblemDetails
+// @Failure 409 {object} dto.ProblemDetails
+// @Router /api/v1/users/{id} [patch]
+// @Security BearerAuth
+func (h *UserHandler) UpdateUser(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid user ID")
+	}
+
+	var req dto.UpdateUserRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
+	}
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	cmd := commands.UpdateUserCommand{
+		ID:        id,
+		Email:     req.Email,
+		Username:  req.Username,
+		FirstName: req.FirstName,
+		LastName:  req.LastName,
+	}
+
+	user, err := bus.DispatchWithResult[*entities.User](c.Request().Context(), h.commandBus, cmd)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, dto.APIResponse[*dto.UserDTO]{
+		Success: true,
+		Data:    toUserDTO(user, user.Profile),
+		Message: "User updated successfully",
+	})
+}
+
+// DeleteUser deletes a user
+// @Summary Delete user
+// @Description Soft delete a user, optionally recording why (admin only)
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param id path string true "User ID"
+// @Param request body dto.SoftDeleteRequest false "Deletion reason"
+// @Success 200 {object} dto.MessageAPIResponse
+// @Failure 400 {object} dto.ProblemDetails
+// @Failure 401 {object} dto.ProblemDetails
+// @Failure 403 {object} dto.ProblemDetails
+// @Failure 404 {object} dto.ProblemDetails
+// @Failure 409 {object} dto.ProblemDetails
+// @Router /api/v1/users/{id} [delete]
+// @Security BearerAuth
+func (h *UserHandler) DeleteUser(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid user ID")
+	}
+
+	// The body is optional; without one the user is deleted without a reason
+	var req dto.SoftDeleteRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
+	}
+
+	cmd := commands.SoftDeleteUserCommand{ID: id, Reason: req.Reason}
+	if err := h.commandBus.Dispatch(c.Request().Context(), cmd); err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, dto.APIResponse[interface{}]{
+		Success: true,
+		Message: "User deleted successfully",
+	})
+}
+
 // GetCurrentUser retrieves current authenticated user
 // @Summary Get current user
 // @Description Get current authenticated user information
 // @Tags users
 // @Produce json
 // @Success 200 {object} dto.UserAPIResponse
-// @Failure 401 {object} dto.ErrorAPIResponse
-// @Failure 404 {object} dto.ErrorAPIResponse
+// @Failure 401 {object} dto.ProblemDetails
+// @Failure 404 {object} dto.ProblemDetails
 // @Router /api/v1/users/me [get]
 // @Security BearerAuth
 func (h *UserHandler) GetCurrentUser(c echo.Context) error {
 	claims, ok := c.Get("user_claims").(*auth.UserClaims)
 	if !ok {
-		return c.JSON(http.StatusUnauthorized, dto.APIResponse[interface{}]{
-			Success: false,
-			Error:   "User not authenticated",
-		})
+		return echo.NewHTTPError(http.StatusUnauthorized, "User not authenticated")
 	}
 
 	id, err := uuid.Parse(claims.UserID)
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, dto.APIResponse[interface{}]{
-			Success: false,
-			Error:   "Invalid user ID",
-		})
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid user ID")
 	}
 
 	query := queries.GetUserByIDQuery{ID: id}
-	result, err := h.userQueryHandler.Handle(context.Background(), query)
+	result, err := bus.Ask[*queries.UserResult](c.Request().Context(), h.queryBus, query)
 	if err != nil {
-		return c.JSON(http.StatusNotFound, dto.APIResponse[interface{}]{
-			Success: false,
-			Error:   "User not found",
-		})
+		return err
 	}
 
-	// Convert to DTO
+	return c.JSON(http.StatusOK, dto.APIResponse[*dto.UserDTO]{
+		Success: true,
+		Data:    toUserDTO(result.User, result.Profile),
+	})
+}
+
+// toUserDTO converts a user and its optional profile to a DTO
+func toUserDTO(user *entities.User, profile *entities.Profile) *dto.UserDTO {
 	userDTO := &dto.UserDTO{
-		ID:        result.User.ID,
-		Email:     result.User.Email,
-		Username:  result.User.Username,
-		FirstName: result.User.FirstName,
-		LastName:  result.User.LastName,
-		IsActive:  result.User.IsActive,
-		CreatedAt: result.User.CreatedAt,
-		UpdatedAt: result.User.UpdatedAt,
+		ID:        user.ID,
+		Email:     user.Email,
+		Username:  user.Username,
+		FirstName: user.FirstName,
+		LastName:  user.LastName,
+		IsActive:  user.IsActive,
+		CreatedAt: user.CreatedAt,
+		UpdatedAt: user.UpdatedAt,
 	}
 
-	if result.Profile != nil {
-		userDTO.Profile = &dto.ProfileDTO{
-			ID:          result.Profile.ID,
-			UserID:      result.Profile.UserID,
-			Bio:         result.Profile.Bio,
-			Avatar:      result.Profile.Avatar,
-			DateOfBirth: result.Profile.DateOfBirth,
-			CreatedAt:   result.Profile.CreatedAt,
-			UpdatedAt:   result.Profile.UpdatedAt,
-		}
+	if profile != nil {
+		userDTO.Profile = toProfileDTO(profile)
 	}
 
-	return c.JSON(http.StatusOK, dto.APIResponse[*dto.UserDTO]{
-		Success: true,
-		Data:    userDTO,
-	})
+	return userDTO
+}
+
+// toProfileDTO converts a profile to its DTO
+func toProfileDTO(profile *entities.Profile) *dto.ProfileDTO {
+	return &dto.ProfileDTO{
+		ID:          profile.ID,
+		UserID:      profile.UserID,
+		Bio:         profile.Bio,
+		Avatar:      profile.Avatar,
+		DateOfBirth: profile.DateOfBirth,
+		CreatedAt:   profile.CreatedAt,
+		UpdatedAt:   profile.UpdatedAt,
+	}
 }
@@ -1,7 +1,7 @@
 package middleware
 
 import (
-	"context"
+	"errors"
 	"goclean/internal/infrastructure/auth"
 	"net/http"
 	"strings"
@@ -9,21 +9,31 @@ import (
 	"github.com/labstack/echo/v4"
 )
 
-// AuthMiddleware provides JWT authentication middleware
+// AuthMiddleware provides JWT and API key authentication middleware
 type AuthMiddleware struct {
-	authService *auth.AuthService
+	authService auth.TokenValidator
+	revocations *auth.RevocationService
+	apiKeys     auth.APIKeyAuthenticator
 	skipPaths   []string
 }
 
-// NewAuthMiddleware creates a new auth middleware
-func NewAuthMiddleware(authService *auth.AuthService, skipPaths []string) *AuthMiddleware {
+// NewAuthMiddleware creates a new auth middleware. Revocation checks are skipped when revocations
+// is nil and API keys are rejected when apiKeys is nil.
+func NewAuthMiddleware(
+	authService auth.TokenValidator,
+	revocations *auth.RevocationService,
+	apiKeys auth.APIKeyAuthenticator,
+	skipPaths []string,
+) *AuthMiddleware {
 	return &AuthMiddleware{
 		authService: authService,
+		revocations: revocations,
+		apiKeys:     apiKeys,
 		skipPaths:   skipPaths,
 	}
 }
 
-// Authenticate validates JWT token
+// Authenticate validates the JWT token, or the API key when an X-API-Key header is sent
 func (m *AuthMiddleware) Authenticate(next echo.HandlerFunc) echo.HandlerFunc {
 	return func(c echo.Context) error {
 		// Check if path should be skipped
@@ -34,6 +44,18 @@ func (m *AuthMiddleware) Authenticate(next echo.HandlerFunc) echo.HandlerFunc {
 			}
 		}
 
+		// API keys take precedence and are revoked in the database, not the revocation store
+		if apiKey := c.Request().Header.Get(auth.APIKeyHeader); apiKey != "" {
+			if m.apiKeys == nil {
+				return echo.NewHTTPError(http.StatusUnauthorized, "API keys are not accepted")
+			}
+			claims, err := m.apiKeys.AuthenticateAPIKey(c.Request().Context(), apiKey)
+			if err != nil {
+				return echo.NewHTTPError(http.StatusUnauthorized, "Invalid API key")
+			}
+			return next(setUserClaims(c, claims))
+		}
+
 		// Get Authorization header
 		authHeader := c.Request().Header.Get("Authorization")
 		if authHeader == "" {
@@ -41,22 +63,36 @@ func (m *AuthMiddleware) Authenticate(next echo.HandlerFunc) echo.HandlerFunc {
 		}
 
 		// Validate token
-		claims, err := m.authService.ValidateToken(context.Background(), authHeader)
+		claims, err := m.authService.ValidateToken(c.Request().Context(), authHeader)
 		if err != nil {
 			return echo.NewHTTPError(http.StatusUnauthorized, "Invalid token: "+err.Error())
 		}
 
-		// Add user info to context
-		c.Set("user_id", claims.UserID)
-		c.Set("user_email", claims.Email)
-		c.Set("user_username", claims.Username)
-		c.Set("user_roles", claims.Roles)
-		c.Set("user_claims", claims)
+		// Reject revoked tokens
+		if m.revocations != nil {
+			if err := m.revocations.Check(c.Request().Context(), claims); err != nil {
+				if errors.Is(err, auth.ErrTokenRevoked) {
+					return echo.NewHTTPError(http.StatusUnauthorized, "Token has been revoked")
+				}
+				return echo.NewHTTPError(http.StatusServiceUnavailable, "Unable to verify token")
+			}
+		}
 
-		return next(c)
+		return next(setUserClaims(c, claims))
 	}
 }
 
+// setUserClaims adds the authenticated user's info to the Echo and request contexts
+func setUserClaims(c echo.Context, claims *auth.UserClaims) echo.Context {
+	c.Set("user_id", claims.UserID)
+	c.Set("user_email", claims.Email)
+	c.Set("user_username", claims.Username)
+	c.Set("user_roles", claims.Roles())
+	c.Set("user_claims", claims)
+	c.SetRequest(c.Request().WithContext(auth.WithUserClaims(c.Request().Context(), claims)))
+	return c
+}
+
 // RequireRole checks if user has required role
 func (m *AuthMiddleware) RequireRole(role string) echo.MiddlewareFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
@@ -100,3 +136,21 @@ func (m *AuthMiddleware) RequireAnyRole(roles []string) echo.MiddlewareFunc {
 		}
 	}
 }
+
+// RequireClientRole checks if user has a role for the given Keycloak client (resource_access)
+func (m *AuthMiddleware) RequireClientRole(clientID, role string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			claims, ok := c.Get("user_claims").(*auth.UserClaims)
+			if !ok {
+				return echo.NewHTTPError(http.StatusUnauthorized, "User not authenticated")
+			}
+
+			if !claims.HasClientRole(clientID, role) {
+				return echo.NewHTTPError(http.StatusForbidden, "Insufficient permissions")
+			}
+
+			return next(c)
+		}
+	}
+}
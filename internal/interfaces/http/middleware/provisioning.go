@@ -0,0 +1,64 @@
+package middleware
+
+import (
+	"goclean/internal/application/commands"
+	"goclean/internal/infrastructure/auth"
+	"goclean/pkg/logger"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// ProvisioningMiddleware creates or syncs the local user for the authenticated subject
+type ProvisioningMiddleware struct {
+	provisioner *commands.UserProvisioner
+	logger      *logger.Logger
+}
+
+// NewProvisioningMiddleware creates a new provisioning middleware
+func NewProvisioningMiddleware(provisioner *commands.UserProvisioner, logger *logger.Logger) *ProvisioningMiddleware {
+	return &ProvisioningMiddleware{
+		provisioner: provisioner,
+		logger:      logger,
+	}
+}
+
+// Provision provisions the user from the token claims set by Authenticate. Deleted and
+// erased users are denied; other failures are logged and do not block the request.
+func (m *ProvisioningMiddleware) Provision(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		claims, ok := c.Get("user_claims").(*auth.UserClaims)
+		if !ok {
+			return next(c)
+		}
+
+		if cmd, ok := provisionUserCommand(claims); ok {
+			if err := m.provisioner.Provision(c.Request().Context(), cmd); err != nil {
+				if commands.IsAccountClosed(err) {
+					return echo.NewHTTPError(http.StatusForbidden, "User account has been closed")
+				}
+				m.logger.Warn("Failed to provision user", "user_id", claims.UserID, "error", err)
+			}
+		}
+
+		return next(c)
+	}
+}
+
+// provisionUserCommand maps token claims to a provisioning command. Subjects that are
+// not UUIDs or tokens without email and username cannot be provisioned.
+func provisionUserCommand(claims *auth.UserClaims) (commands.ProvisionUserCommand, bool) {
+	id, err := uuid.Parse(claims.UserID)
+	if err != nil || claims.Email == "" || claims.Username == "" {
+		return commands.ProvisionUserCommand{}, false
+	}
+
+	return commands.ProvisionUserCommand{
+		ID:        id,
+		Email:     claims.Email,
+		Username:  claims.Username,
+		FirstName: claims.FirstName,
+		LastName:  claims.LastName,
+	}, true
+}
@@ -1,6 +1,9 @@
 package http
 
 import (
+	"expvar"
+	"goclean/internal/application/commands"
+	"goclean/internal/application/validation"
 	"goclean/internal/infrastructure/auth"
 	"goclean/internal/interfaces/http/handlers"
 	"goclean/internal/interfaces/http/middleware"
@@ -13,35 +16,52 @@ import (
 
 // Server represents the HTTP server
 type Server struct {
-	echo        *echo.Echo
-	logger      *logger.Logger
-	authService *auth.AuthService
+	echo            *echo.Echo
+	logger          *logger.Logger
+	authService     auth.AuthProvider
+	revocations     *auth.RevocationService
+	apiKeys         auth.APIKeyAuthenticator
+	userProvisioner *commands.UserProvisioner
 }
 
 // NewServer creates a new HTTP server
 func NewServer(
 	logger *logger.Logger,
-	authService *auth.AuthService,
+	authService auth.AuthProvider,
+	revocations *auth.RevocationService,
+	apiKeys auth.APIKeyAuthenticator,
+	userProvisioner *commands.UserProvisioner,
+	authHandler *handlers.AuthHandler,
+	apiKeyHandler *handlers.APIKeyHandler,
 	userHandler *handlers.UserHandler,
 	productHandler *handlers.ProductHandler,
+	orderHandler *handlers.OrderHandler,
+	softDeleteHandlers *handlers.SoftDeleteHandlers,
+	privacyHandler *handlers.PrivacyHandler,
 ) *Server {
 	e := echo.New()
 
-	// Configure Echo
+	// Configure Echo; request bodies are checked against their validate tags by c.Validate
+	// and every error is rendered as RFC 7807 problem details
 	e.HideBanner = true
 	e.HidePort = true
+	e.Validator = validation.New()
+	e.HTTPErrorHandler = handlers.ErrorHandler(logger)
 
 	server := &Server{
-		echo:        e,
-		logger:      logger,
-		authService: authService,
+		echo:            e,
+		logger:          logger,
+		authService:     authService,
+		revocations:     revocations,
+		apiKeys:         apiKeys,
+		userProvisioner: userProvisioner,
 	}
 
 	// Setup middleware
 	server.setupMiddleware()
 
 	// Setup routes
-	server.setupRoutes(userHandler, productHandler)
+	server.setupRoutes(authHandler, apiKeyHandler, userHandler, productHandler, orderHandler, softDeleteHandlers, privacyHandler)
 
 	return server
 }
@@ -75,8 +95,13 @@ func (s *Server) setupMiddleware() {
 
 // setupRoutes configures API routes
 func (s *Server) setupRoutes(
+	authHandler *handlers.AuthHandler,
+	apiKeyHandler *handlers.APIKeyHandler,
 	userHandler *handlers.UserHandler,
 	productHandler *handlers.ProductHandler,
+	orderHandler *handlers.OrderHandler,
+	softDeleteHandlers *handlers.SoftDeleteHandlers,
+	privacyHandler *handlers.PrivacyHandler,
 ) {
 	// Health check
 	s.echo.GET("/health", func(c echo.Context) error {
@@ -90,10 +115,11 @@ func (s *Server) setupRoutes(
 	s.echo.GET("/swagger/*", echoSwagger.WrapHandler)
 
 	// Auth middleware
-	authMiddleware := middleware.NewAuthMiddleware(s.authService, []string{
+	authMiddleware := middleware.NewAuthMiddleware(s.authService, s.revocations, s.apiKeys, []string{
 		"/health",
 		"/swagger",
-		"/api/v1/auth",
+		"/api/v1/auth/login",
+		"/api/v1/auth/refresh",
 	})
 
 	// API v1 routes
@@ -105,23 +131,66 @@ func (s *Server) setupRoutes(
 	// Protected routes (auth required)
 	protected := api.Group("")
 	protected.Use(authMiddleware.Authenticate)
+	protected.Use(middleware.NewProvisioningMiddleware(s.userProvisioner, s.logger).Provision)
+
+	// Auth routes
+	public.POST("/auth/login", authHandler.Login)         // Public
+	public.POST("/auth/refresh", authHandler.Refresh)     // Public
+	protected.POST("/auth/logout", authHandler.Logout)    // Auth required
+	protected.GET("/auth/userinfo", authHandler.UserInfo) // Auth required
 
 	// User routes
 	public.POST("/users", userHandler.CreateUser)          // Public registration
 	protected.GET("/users", userHandler.ListUsers)         // Admin only
-	protected.GET("/users/:id", userHandler.GetUser)       // Auth required
+	protected.GET("/users/:id", userHandler.GetUser)       // Self or admin
 	protected.GET("/users/me", userHandler.GetCurrentUser) // Auth required
+	protected.PATCH("/users/:id", userHandler.UpdateUser)  // Self or admin
+	protected.DELETE("/users/:id", userHandler.DeleteUser) // Admin only
 
 	// Product routes
-	public.GET("/products", productHandler.ListProducts)      // Public
-	public.GET("/products/:id", productHandler.GetProduct)    // Public
-	protected.POST("/products", productHandler.CreateProduct) // Auth required
+	public.GET("/products", productHandler.ListProducts)            // Public
+	public.GET("/products/:id", productHandler.GetProduct)          // Public
+	protected.POST("/products", productHandler.CreateProduct)       // Auth required
+	protected.PATCH("/products/:id", productHandler.UpdateProduct)  // Admin only
+	protected.DELETE("/products/:id", productHandler.DeleteProduct) // Admin only
+
+	// Order routes
+	protected.POST("/orders", orderHandler.CreateOrder)            // Auth required
+	protected.GET("/orders", orderHandler.ListMyOrders)            // Auth required
+	protected.GET("/orders/:id", orderHandler.GetOrder)            // Owner or admin
+	protected.POST("/orders/:id/cancel", orderHandler.CancelOrder) // Owner or admin
 
 	// Admin routes (require admin role)
 	admin := protected.Group("/admin")
 	admin.Use(authMiddleware.RequireRole("admin"))
 
-	// Additional admin routes can be added here
+	admin.GET("/orders", orderHandler.ListOrders)                            // Admin only
+	admin.PATCH("/orders/:id/status", orderHandler.UpdateOrderStatus)        // Admin only
+	admin.POST("/products/:id/restock", productHandler.RestockProduct)       // Admin only
+	admin.GET("/products/:id/stock", productHandler.GetProductStock)         // Admin only
+	admin.POST("/users/:id/sessions/revoke", authHandler.RevokeUserSessions) // Admin only
+	admin.POST("/api-keys", apiKeyHandler.IssueAPIKey)                       // Admin only
+	admin.GET("/api-keys", apiKeyHandler.ListAPIKeys)                        // Admin only
+	admin.DELETE("/api-keys/:id", apiKeyHandler.RevokeAPIKey)                // Admin only
+	admin.GET("/metrics", echo.WrapHandler(expvar.Handler()))                // Admin only, command and query metrics
+
+	// Soft delete lifecycle routes (admin only)
+	admin.DELETE("/users/:id", softDeleteHandlers.Users.SoftDelete)
+	admin.POST("/users/:id/restore", softDeleteHandlers.Users.Restore)
+	admin.GET("/users/deleted", softDeleteHandlers.Users.ListDeleted)
+	admin.DELETE("/profiles/:id", softDeleteHandlers.Profiles.SoftDelete)
+	admin.POST("/profiles/:id/restore", softDeleteHandlers.Profiles.Restore)
+	admin.GET("/profiles/deleted", softDeleteHandlers.Profiles.ListDeleted)
+	admin.DELETE("/products/:id", softDeleteHandlers.Products.SoftDelete)
+	admin.POST("/products/:id/restore", softDeleteHandlers.Products.Restore)
+	admin.GET("/products/deleted", softDeleteHandlers.Products.ListDeleted)
+	admin.DELETE("/orders/:id", softDeleteHandlers.Orders.SoftDelete)
+	admin.POST("/orders/:id/restore", softDeleteHandlers.Orders.Restore)
+	admin.GET("/orders/deleted", softDeleteHandlers.Orders.ListDeleted)
+
+	// Data export and erasure routes (admin only)
+	admin.POST("/users/:id/export", privacyHandler.ExportUserData)
+	admin.POST("/users/:id/erase", privacyHandler.EraseUserData)
 }
 
 // Start starts the HTTP server
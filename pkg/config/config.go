@@ -5,16 +5,20 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"time"
 )
 
 // Config holds all application configuration
 type Config struct {
-	Server   ServerConfig   `json:"server"`
-	Database DatabaseConfig `json:"database"`
-	Redis    RedisConfig    `json:"redis"`
-	Keycloak KeycloakConfig `json:"keycloak"`
-	GRPC     GRPCConfig     `json:"grpc"`
-	App      AppConfig      `json:"app"`
+	Server    ServerConfig    `json:"server"`
+	Database  DatabaseConfig  `json:"database"`
+	Redis     RedisConfig     `json:"redis"`
+	Keycloak  KeycloakConfig  `json:"keycloak"`
+	Auth      AuthConfig      `json:"auth"`
+	GRPC      GRPCConfig      `json:"grpc"`
+	Outbox    OutboxConfig    `json:"outbox"`
+	Retention RetentionConfig `json:"retention"`
+	App       AppConfig       `json:"app"`
 }
 
 // ServerConfig holds HTTP server configuration
@@ -42,10 +46,34 @@ type RedisConfig struct {
 
 // KeycloakConfig holds Keycloak configuration
 type KeycloakConfig struct {
-	BaseURL      string `json:"base_url"`
-	Realm        string `json:"realm"`
-	ClientID     string `json:"client_id"`
-	ClientSecret string `json:"client_secret"`
+	BaseURL                string        `json:"base_url"`
+	Realm                  string        `json:"realm"`
+	ClientID               string        `json:"client_id"`
+	ClientSecret           string        `json:"client_secret"`
+	JWKSCacheTTL           time.Duration `json:"jwks_cache_ttl"`
+	JWKSMinRefreshInterval time.Duration `json:"jwks_min_refresh_interval"`
+}
+
+// AuthConfig selects the authentication provider. Keycloak uses KeycloakConfig;
+// oidc and local are configured here.
+type AuthConfig struct {
+	Provider             string        `json:"provider"` // keycloak, oidc or local
+	OIDCIssuerURL        string        `json:"oidc_issuer_url"`
+	OIDCClientID         string        `json:"oidc_client_id"`
+	OIDCClientSecret     string        `json:"oidc_client_secret"`
+	LocalIssuer          string        `json:"local_issuer"`
+	LocalSigningMethod   string        `json:"local_signing_method"`
+	LocalSecret          string        `json:"local_secret"`
+	LocalPrivateKeyFile  string        `json:"local_private_key_file"`
+	LocalAccessTokenTTL  time.Duration `json:"local_access_token_ttl"`
+	LocalRefreshTokenTTL time.Duration `json:"local_refresh_token_ttl"`
+	RefreshCookieEnabled bool          `json:"refresh_cookie_enabled"` // Deliver refresh tokens as HttpOnly cookies
+	RefreshCookieName    string        `json:"refresh_cookie_name"`
+	RefreshCookieDomain  string        `json:"refresh_cookie_domain"`
+	RefreshCookieSecure  bool          `json:"refresh_cookie_secure"`
+	UserSyncInterval     time.Duration `json:"user_sync_interval"`   // How often unchanged token claims are re-synced to the local user
+	RevocationFailOpen   bool          `json:"revocation_fail_open"` // Accept tokens when Redis is unavailable instead of rejecting them
+	MaxTokenLifetime     time.Duration `json:"max_token_lifetime"`   // How long revocations without a token expiry are kept
 }
 
 // GRPCConfig holds gRPC server configuration
@@ -54,6 +82,26 @@ type GRPCConfig struct {
 	Port int    `json:"port"`
 }
 
+// OutboxConfig holds transactional outbox relay configuration
+type OutboxConfig struct {
+	PollInterval time.Duration `json:"poll_interval"`
+	BatchSize    int           `json:"batch_size"`
+	MaxAttempts  int           `json:"max_attempts"`
+	BaseBackoff  time.Duration `json:"base_backoff"`
+	MaxBackoff   time.Duration `json:"max_backoff"`
+}
+
+// RetentionConfig holds how long soft deleted records are kept before they are purged.
+// A zero period keeps that aggregate's records forever. The background job is opt-in: it
+// runs in every HTTP instance that sets an interval, so by default purging is left to the CLI.
+type RetentionConfig struct {
+	Interval       time.Duration `json:"interval"`
+	UsersPeriod    time.Duration `json:"users_period"`
+	ProfilesPeriod time.Duration `json:"profiles_period"`
+	ProductsPeriod time.Duration `json:"products_period"`
+	OrdersPeriod   time.Duration `json:"orders_period"`
+}
+
 // AppConfig holds general application configuration
 type AppConfig struct {
 	Name        string `json:"name"`
@@ -83,15 +131,50 @@ func LoadConfig() (*Config, error) {
 			DB:       getEnvAsInt("REDIS_DB", 0),
 		},
 		Keycloak: KeycloakConfig{
-			BaseURL:      getEnv("KEYCLOAK_BASE_URL", "http://localhost:8081"),
-			Realm:        getEnv("KEYCLOAK_REALM", "goclean"),
-			ClientID:     getEnv("KEYCLOAK_CLIENT_ID", "goclean-api"),
-			ClientSecret: getEnv("KEYCLOAK_CLIENT_SECRET", ""),
+			BaseURL:                getEnv("KEYCLOAK_BASE_URL", "http://localhost:8081"),
+			Realm:                  getEnv("KEYCLOAK_REALM", "goclean"),
+			ClientID:               getEnv("KEYCLOAK_CLIENT_ID", "goclean-api"),
+			ClientSecret:           getEnv("KEYCLOAK_CLIENT_SECRET", ""),
+			JWKSCacheTTL:           getEnvAsDuration("KEYCLOAK_JWKS_CACHE_TTL", time.Hour),
+			JWKSMinRefreshInterval: getEnvAsDuration("KEYCLOAK_JWKS_MIN_REFRESH_INTERVAL", 30*time.Second),
+		},
+		Auth: AuthConfig{
+			Provider:             strings.ToLower(getEnv("AUTH_PROVIDER", "keycloak")),
+			OIDCIssuerURL:        getEnv("AUTH_OIDC_ISSUER_URL", ""),
+			OIDCClientID:         getEnv("AUTH_OIDC_CLIENT_ID", ""),
+			OIDCClientSecret:     getEnv("AUTH_OIDC_CLIENT_SECRET", ""),
+			LocalIssuer:          getEnv("AUTH_LOCAL_ISSUER", "goclean-local"),
+			LocalSigningMethod:   getEnv("AUTH_LOCAL_SIGNING_METHOD", "HS256"),
+			LocalSecret:          getEnv("AUTH_LOCAL_SECRET", ""),
+			LocalPrivateKeyFile:  getEnv("AUTH_LOCAL_PRIVATE_KEY_FILE", ""),
+			LocalAccessTokenTTL:  getEnvAsDuration("AUTH_LOCAL_ACCESS_TOKEN_TTL", 15*time.Minute),
+			LocalRefreshTokenTTL: getEnvAsDuration("AUTH_LOCAL_REFRESH_TOKEN_TTL", 24*time.Hour),
+			RefreshCookieEnabled: getEnvAsBool("AUTH_REFRESH_COOKIE_ENABLED", false),
+			RefreshCookieName:    getEnv("AUTH_REFRESH_COOKIE_NAME", "refresh_token"),
+			RefreshCookieDomain:  getEnv("AUTH_REFRESH_COOKIE_DOMAIN", ""),
+			RefreshCookieSecure:  getEnvAsBool("AUTH_REFRESH_COOKIE_SECURE", true),
+			UserSyncInterval:     getEnvAsDuration("AUTH_USER_SYNC_INTERVAL", 5*time.Minute),
+			RevocationFailOpen:   getEnvAsBool("AUTH_REVOCATION_FAIL_OPEN", false),
+			MaxTokenLifetime:     getEnvAsDuration("AUTH_MAX_TOKEN_LIFETIME", 24*time.Hour),
 		},
 		GRPC: GRPCConfig{
 			Host: getEnv("GRPC_HOST", "localhost"),
 			Port: getEnvAsInt("GRPC_PORT", 9090),
 		},
+		Outbox: OutboxConfig{
+			PollInterval: getEnvAsDuration("OUTBOX_POLL_INTERVAL", 2*time.Second),
+			BatchSize:    getEnvAsInt("OUTBOX_BATCH_SIZE", 100),
+			MaxAttempts:  getEnvAsInt("OUTBOX_MAX_ATTEMPTS", 10),
+			BaseBackoff:  getEnvAsDuration("OUTBOX_BASE_BACKOFF", time.Second),
+			MaxBackoff:   getEnvAsDuration("OUTBOX_MAX_BACKOFF", 5*time.Minute),
+		},
+		Retention: RetentionConfig{
+			Interval:       getEnvAsDuration("RETENTION_INTERVAL", 0),
+			UsersPeriod:    getEnvAsDuration("RETENTION_USERS_PERIOD", 0),
+			ProfilesPeriod: getEnvAsDuration("RETENTION_PROFILES_PERIOD", 0),
+			ProductsPeriod: getEnvAsDuration("RETENTION_PRODUCTS_PERIOD", 0),
+			OrdersPeriod:   getEnvAsDuration("RETENTION_ORDERS_PERIOD", 0),
+		},
 		App: AppConfig{
 			Name:        getEnv("APP_NAME", "GoClean"),
 			Version:     getEnv("APP_VERSION", "1.0.0"),
@@ -116,14 +199,30 @@ func validateConfig(config *Config) error {
 	if config.Database.DBName == "" {
 		return fmt.Errorf("database name is required")
 	}
-	if config.Keycloak.BaseURL == "" {
-		return fmt.Errorf("keycloak base URL is required")
-	}
-	if config.Keycloak.Realm == "" {
-		return fmt.Errorf("keycloak realm is required")
-	}
-	if config.Keycloak.ClientID == "" {
-		return fmt.Errorf("keycloak client ID is required")
+	switch config.Auth.Provider {
+	case "keycloak":
+		if config.Keycloak.BaseURL == "" {
+			return fmt.Errorf("keycloak base URL is required")
+		}
+		if config.Keycloak.Realm == "" {
+			return fmt.Errorf("keycloak realm is required")
+		}
+		if config.Keycloak.ClientID == "" {
+			return fmt.Errorf("keycloak client ID is required")
+		}
+	case "oidc":
+		if config.Auth.OIDCIssuerURL == "" {
+			return fmt.Errorf("OIDC issuer URL is required")
+		}
+		if config.Auth.OIDCClientID == "" {
+			return fmt.Errorf("OIDC client ID is required")
+		}
+	case "local":
+		if config.IsProduction() {
+			return fmt.Errorf("local auth provider must not be used in production")
+		}
+	default:
+		return fmt.Errorf("unknown auth provider %q", config.Auth.Provider)
 	}
 	return nil
 }
@@ -183,6 +282,15 @@ func getEnvAsBool(name string, defaultValue bool) bool {
 	return defaultValue
 }
 
+// getEnvAsDuration gets an environment variable as a duration or returns a default value
+func getEnvAsDuration(name string, defaultValue time.Duration) time.Duration {
+	valueStr := getEnv(name, "")
+	if value, err := time.ParseDuration(valueStr); err == nil {
+		return value
+	}
+	return defaultValue
+}
+
 // getEnvAsSlice gets an environment variable as a slice (comma-separated) or returns a default value
 func getEnvAsSlice(name string, defaultValue []string, separator string) []string {
 	valueStr := getEnv(name, "")
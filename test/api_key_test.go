@@ -0,0 +1,226 @@
+package test
+
+import (
+	"context"
+	"goclean/internal/application/authz"
+	"goclean/internal/application/commands"
+	"goclean/internal/domain/entities"
+	"goclean/internal/domain/repositories"
+	"goclean/internal/domain/services"
+	"goclean/internal/infrastructure/auth"
+	"goclean/internal/infrastructure/persistence"
+	gormPersistence "goclean/internal/infrastructure/persistence/gorm"
+	"goclean/internal/interfaces/http/middleware"
+	"goclean/test/mocks"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIKeyDomainService_Authenticate(t *testing.T) {
+	ownerID := uuid.New()
+	past := time.Now().Add(-time.Hour)
+
+	tests := []struct {
+		name         string
+		prepare      func(key *entities.APIKey, plaintext string) string
+		prepareOwner func(owner *entities.User)
+		ownerDeleted bool
+		expectError  bool
+	}{
+		{
+			name:    "valid key",
+			prepare: func(key *entities.APIKey, plaintext string) string { return plaintext },
+		},
+		{
+			name:        "wrong secret",
+			prepare:     func(key *entities.APIKey, plaintext string) string { return plaintext + "x" },
+			expectError: true,
+		},
+		{
+			name:        "malformed key",
+			prepare:     func(key *entities.APIKey, plaintext string) string { return "not-an-api-key" },
+			expectError: true,
+		},
+		{
+			name: "revoked key",
+			prepare: func(key *entities.APIKey, plaintext string) string {
+				key.Revoke()
+				return plaintext
+			},
+			expectError: true,
+		},
+		{
+			name: "expired key",
+			prepare: func(key *entities.APIKey, plaintext string) string {
+				key.ExpiresAt = &past
+				return plaintext
+			},
+			expectError: true,
+		},
+		{
+			name:         "deleted or purged owner",
+			prepare:      func(key *entities.APIKey, plaintext string) string { return plaintext },
+			ownerDeleted: true,
+			expectError:  true,
+		},
+		{
+			name:         "deactivated owner",
+			prepare:      func(key *entities.APIKey, plaintext string) string { return plaintext },
+			prepareOwner: func(owner *entities.User) { owner.IsActive = false },
+			expectError:  true,
+		},
+		{
+			name:         "erased owner",
+			prepare:      func(key *entities.APIKey, plaintext string) string { return plaintext },
+			prepareOwner: func(owner *entities.User) { require.NoError(t, owner.Erase()) },
+			expectError:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key, plaintext, err := entities.NewAPIKey("billing-batch", ownerID, []string{"orders:read"}, nil, uuid.New())
+			require.NoError(t, err)
+			presented := tt.prepare(key, plaintext)
+
+			owner := entities.NewUser("svc@example.com", "svc", "", "")
+			owner.ID = ownerID
+			if tt.prepareOwner != nil {
+				tt.prepareOwner(owner)
+			}
+			userRepo := new(mocks.MockUserRepository)
+			if tt.ownerDeleted {
+				userRepo.On("GetByID", mock.Anything, ownerID).Return(nil, repositories.ErrNotFound)
+			} else {
+				userRepo.On("GetByID", mock.Anything, ownerID).Return(owner, nil)
+			}
+
+			apiKeyRepo := new(mocks.MockAPIKeyRepository)
+			apiKeyRepo.On("GetByKeyID", mock.Anything, key.KeyID).Return(key, nil)
+			apiKeyRepo.On("UpdateLastUsed", mock.Anything, key.ID, mock.AnythingOfType("time.Time")).Return(nil)
+			service := services.NewAPIKeyDomainService(apiKeyRepo, userRepo)
+
+			authenticated, err := service.Authenticate(context.Background(), presented)
+			if tt.expectError {
+				assert.ErrorIs(t, err, entities.ErrInvalidAPIKey)
+				apiKeyRepo.AssertNotCalled(t, "UpdateLastUsed", mock.Anything, mock.Anything, mock.Anything)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, ownerID, authenticated.OwnerID)
+			assert.NotNil(t, authenticated.LastUsedAt)
+
+			// A second use within the resolution window does not write again
+			_, err = service.Authenticate(context.Background(), presented)
+			require.NoError(t, err)
+			apiKeyRepo.AssertNumberOfCalls(t, "UpdateLastUsed", 1)
+		})
+	}
+}
+
+func TestAPIKeyDomainService_IssueAPIKey(t *testing.T) {
+	ownerID := uuid.New()
+
+	userRepo := new(mocks.MockUserRepository)
+	userRepo.On("GetByID", mock.Anything, ownerID).Return(entities.NewUser("svc@example.com", "svc", "", ""), nil)
+	userRepo.On("GetByID", mock.Anything, mock.Anything).Return(nil, repositories.ErrNotFound)
+	apiKeyRepo := new(mocks.MockAPIKeyRepository)
+	apiKeyRepo.On("Create", mock.Anything, mock.AnythingOfType("*entities.APIKey")).Return(nil)
+	service := services.NewAPIKeyDomainService(apiKeyRepo, userRepo)
+
+	key, plaintext, err := service.IssueAPIKey(context.Background(), "billing-batch", ownerID, []string{"admin"}, nil, uuid.New())
+	require.NoError(t, err)
+	assert.NotContains(t, key.SecretHash, plaintext)
+	keyID, secret, err := entities.ParseAPIKey(plaintext)
+	require.NoError(t, err)
+	assert.Equal(t, key.KeyID, keyID)
+	assert.True(t, key.VerifySecret(secret))
+
+	_, _, err = service.IssueAPIKey(context.Background(), "orphan", uuid.New(), nil, nil, uuid.New())
+	assert.ErrorIs(t, err, services.ErrUserNotFound)
+}
+
+func TestAPIKeyCommandHandler_RejectsReservedScopes(t *testing.T) {
+	apiKeyRepo := new(mocks.MockAPIKeyRepository)
+	handler := commands.NewAPIKeyCommandHandler(services.NewAPIKeyDomainService(apiKeyRepo, new(mocks.MockUserRepository)), authz.NewPolicyAuthorizer(authz.DefaultPolicy()...))
+	admin := authz.NewPrincipal(uuid.New().String(), []string{"admin"}, map[string]string{authz.AttrAuthMethod: authz.AuthMethodToken})
+	ctx := authz.WithPrincipal(context.Background(), admin)
+
+	cmd := commands.IssueAPIKeyCommand{Name: "escalate", OwnerID: uuid.New(), Scopes: []string{"orders:read", authz.RoleSystem}, CreatedBy: uuid.New()}
+	_, err := handler.HandleIssue(ctx, cmd)
+	assert.ErrorIs(t, err, commands.ErrReservedScope)
+	apiKeyRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestSessionCommandHandler_RevokesAPIKeys(t *testing.T) {
+	f := newPrivacyFixture(t)
+	apiKeyRepo := persistence.NewAPIKeyGormRepository(f.db)
+	revoker := &recordingRevoker{}
+	handler := commands.NewSessionCommandHandler(revoker, services.NewAPIKeyDomainService(apiKeyRepo, gormPersistence.NewUserRepository(f.db)), authz.NewPolicyAuthorizer(authz.DefaultPolicy()...))
+	admin := authz.NewPrincipal(uuid.New().String(), []string{"admin"}, map[string]string{authz.AttrAuthMethod: authz.AuthMethodToken})
+
+	require.NoError(t, handler.Handle(authz.WithPrincipal(context.Background(), admin), commands.RevokeUserSessionsCommand{UserID: f.user.ID}))
+	assert.Equal(t, []string{f.user.ID.String()}, revoker.revoked)
+
+	// Keys act as their owner without a session, so they are revoked along with the sessions
+	key, err := apiKeyRepo.GetByID(context.Background(), f.apiKey.ID)
+	require.NoError(t, err)
+	assert.NotNil(t, key.RevokedAt)
+}
+
+// staticAPIKeys authenticates a single plaintext key
+type staticAPIKeys struct {
+	key       *entities.APIKey
+	plaintext string
+}
+
+func (s staticAPIKeys) AuthenticateAPIKey(ctx context.Context, key string) (*auth.UserClaims, error) {
+	if key != s.plaintext {
+		return nil, entities.ErrInvalidAPIKey
+	}
+	return auth.APIKeyClaims(s.key), nil
+}
+
+func TestAuthMiddleware_APIKey(t *testing.T) {
+	key, plaintext, err := entities.NewAPIKey("billing-batch", uuid.New(), []string{"admin"}, nil, uuid.New())
+	require.NoError(t, err)
+	authMiddleware := middleware.NewAuthMiddleware(newLocalProvider(t), nil, staticAPIKeys{key: key, plaintext: plaintext}, nil)
+
+	request := func(authMiddleware *middleware.AuthMiddleware, apiKey, role string) int {
+		e := echo.New()
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/orders", nil)
+		req.Header.Set(auth.APIKeyHeader, apiKey)
+		rec := httptest.NewRecorder()
+
+		handler := authMiddleware.Authenticate(authMiddleware.RequireRole(role)(func(c echo.Context) error {
+			claims, ok := auth.UserClaimsFromContext(c.Request().Context())
+			require.True(t, ok)
+			assert.Equal(t, key.OwnerID.String(), claims.UserID)
+			assert.True(t, claims.IsAPIKey())
+			return c.NoContent(http.StatusOK)
+		}))
+		if err := handler(e.NewContext(req, rec)); err != nil {
+			var httpErr *echo.HTTPError
+			require.ErrorAs(t, err, &httpErr)
+			return httpErr.Code
+		}
+		return rec.Code
+	}
+
+	// Scopes act as roles
+	assert.Equal(t, http.StatusOK, request(authMiddleware, plaintext, "admin"))
+	assert.Equal(t, http.StatusForbidden, request(authMiddleware, plaintext, "auditor"))
+	assert.Equal(t, http.StatusUnauthorized, request(authMiddleware, "gck_unknown_key", "admin"))
+
+	// Without an authenticator API keys are rejected
+	withoutAPIKeys := middleware.NewAuthMiddleware(newLocalProvider(t), nil, nil, nil)
+	assert.Equal(t, http.StatusUnauthorized, request(withoutAPIKeys, plaintext, "admin"))
+}
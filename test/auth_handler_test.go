@@ -0,0 +1,103 @@
+package test
+
+import (
+	"encoding/json"
+	"goclean/internal/application/dto"
+	"goclean/internal/infrastructure/auth"
+	"goclean/internal/interfaces/http/handlers"
+	"goclean/internal/interfaces/http/middleware"
+	"goclean/pkg/logger"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuthHandler_RefreshCookie(t *testing.T) {
+	provider := newLocalProvider(t)
+	handler := handlers.NewAuthHandler(provider, nil, nil, handlers.RefreshCookieConfig{Enabled: true, Secure: true})
+	e := echo.New()
+
+	tokens, err := provider.IssueToken(auth.UserClaims{UserID: "5d0a6bd2-0ad5-4d1c-9a4e-6f3f1d2b7c11"})
+	require.NoError(t, err)
+
+	// Refresh using the cookie instead of the body
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/auth/refresh", nil)
+	req.AddCookie(&http.Cookie{Name: "refresh_token", Value: tokens.RefreshToken})
+	rec := httptest.NewRecorder()
+	require.NoError(t, handler.Refresh(e.NewContext(req, rec)))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var resp dto.APIResponse[dto.TokenDTO]
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	assert.NotEmpty(t, resp.Data.AccessToken)
+	assert.Empty(t, resp.Data.RefreshToken, "refresh token must only be sent as a cookie")
+
+	cookies := rec.Result().Cookies()
+	require.Len(t, cookies, 1)
+	assert.True(t, cookies[0].HttpOnly)
+	assert.True(t, cookies[0].Secure)
+	assert.Equal(t, "/api/v1/auth", cookies[0].Path)
+	assert.NotEmpty(t, cookies[0].Value)
+
+	// Logout clears the cookie
+	req = httptest.NewRequest(http.MethodPost, "/api/v1/auth/logout", nil)
+	req.AddCookie(cookies[0])
+	rec = httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set("user_claims", &auth.UserClaims{UserID: "5d0a6bd2-0ad5-4d1c-9a4e-6f3f1d2b7c11"})
+	require.NoError(t, handler.Logout(c))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	cleared := rec.Result().Cookies()
+	require.Len(t, cleared, 1)
+	assert.Empty(t, cleared[0].Value)
+	assert.Less(t, cleared[0].MaxAge, 0)
+}
+
+func TestAuthHandler_RefreshRejectsInvalidToken(t *testing.T) {
+	handler := handlers.NewAuthHandler(newLocalProvider(t), nil, nil, handlers.RefreshCookieConfig{})
+	e := echo.New()
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/auth/refresh", strings.NewReader(`{"refresh_token":"not-a-token"}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	var httpErr *echo.HTTPError
+	require.ErrorAs(t, handler.Refresh(e.NewContext(req, httptest.NewRecorder())), &httpErr)
+	assert.Equal(t, http.StatusUnauthorized, httpErr.Code)
+
+	// Missing token
+	req = httptest.NewRequest(http.MethodPost, "/api/v1/auth/refresh", nil)
+	require.ErrorAs(t, handler.Refresh(e.NewContext(req, httptest.NewRecorder())), &httpErr)
+	assert.Equal(t, http.StatusBadRequest, httpErr.Code)
+}
+
+func TestAuthHandler_LogoutRevokesOnlyTheCallersSession(t *testing.T) {
+	provider := newLocalProvider(t)
+	revocations := auth.NewRevocationService(newMemoryRevocationStore(), provider, auth.RevocationConfig{}, logger.NewDefault())
+	authMiddleware := middleware.NewAuthMiddleware(provider, revocations, nil, nil)
+	handler := handlers.NewAuthHandler(provider, revocations, nil, handlers.RefreshCookieConfig{})
+	e := echo.New()
+
+	victim, err := provider.IssueToken(auth.UserClaims{UserID: "0f6f3f0e-8a4c-4b8e-9d39-2d0c1b6f5a77", SessionState: "victim-session"})
+	require.NoError(t, err)
+	caller, err := provider.IssueToken(auth.UserClaims{UserID: "5d0a6bd2-0ad5-4d1c-9a4e-6f3f1d2b7c11"})
+	require.NoError(t, err)
+
+	// A refresh token forged with the victim's session is not trusted
+	forged, err := jwt.NewWithClaims(jwt.SigningMethodHS256, &auth.UserClaims{SessionState: "victim-session"}).SignedString([]byte("not-the-secret"))
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/auth/logout", strings.NewReader(`{"refresh_token":"`+forged+`"}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	req.Header.Set(echo.HeaderAuthorization, "Bearer "+caller.AccessToken)
+	rec := httptest.NewRecorder()
+	require.NoError(t, authMiddleware.Authenticate(handler.Logout)(e.NewContext(req, rec)))
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	assert.Equal(t, http.StatusOK, authenticate(t, authMiddleware, victim.AccessToken))
+	assert.Equal(t, http.StatusUnauthorized, authenticate(t, authMiddleware, caller.AccessToken))
+}
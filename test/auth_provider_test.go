@@ -0,0 +1,165 @@
+package test
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"goclean/internal/infrastructure/auth"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testLocalSecret = "local-development-secret-0123456789"
+
+func newLocalProvider(t *testing.T) *auth.LocalProvider {
+	provider, err := auth.NewLocalProvider(auth.LocalConfig{
+		ClientID: "goclean-api",
+		Secret:   testLocalSecret,
+	})
+	require.NoError(t, err)
+	return provider
+}
+
+func TestLocalProvider_IssueAndValidate(t *testing.T) {
+	provider := newLocalProvider(t)
+
+	tokens, err := provider.IssueToken(auth.UserClaims{
+		UserID:      "5d0a6bd2-0ad5-4d1c-9a4e-6f3f1d2b7c11",
+		Username:    "admin",
+		RealmAccess: auth.RoleClaims{Roles: []string{"admin"}},
+	})
+	require.NoError(t, err)
+
+	claims, err := provider.ValidateToken(context.Background(), "Bearer "+tokens.AccessToken)
+	require.NoError(t, err)
+	assert.Equal(t, "5d0a6bd2-0ad5-4d1c-9a4e-6f3f1d2b7c11", claims.UserID)
+	assert.True(t, claims.HasRole("admin"))
+
+	// Refresh tokens are not accepted as access tokens
+	_, err = provider.ValidateToken(context.Background(), tokens.RefreshToken)
+	assert.Error(t, err)
+
+	// Access tokens cannot be used to refresh
+	_, err = provider.RefreshToken(context.Background(), tokens.AccessToken)
+	assert.ErrorIs(t, err, auth.ErrInvalidCredentials)
+
+	refreshed, err := provider.RefreshToken(context.Background(), tokens.RefreshToken)
+	require.NoError(t, err)
+	claims, err = provider.ValidateToken(context.Background(), refreshed.AccessToken)
+	require.NoError(t, err)
+	assert.True(t, claims.HasRole("admin"))
+
+	// Tokens from another secret are rejected
+	other, err := auth.NewLocalProvider(auth.LocalConfig{
+		ClientID: "goclean-api",
+		Secret:   "another-local-development-secret-0123",
+	})
+	require.NoError(t, err)
+	_, err = other.ValidateToken(context.Background(), tokens.AccessToken)
+	assert.Error(t, err)
+}
+
+func TestLocalProvider_RS256(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	keyFile := filepath.Join(t.TempDir(), "local.pem")
+	pemBytes := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+	require.NoError(t, os.WriteFile(keyFile, pemBytes, 0o600))
+
+	provider, err := auth.NewLocalProvider(auth.LocalConfig{
+		ClientID:       "goclean-api",
+		SigningMethod:  "RS256",
+		PrivateKeyFile: keyFile,
+	})
+	require.NoError(t, err)
+
+	tokens, err := provider.IssueToken(auth.UserClaims{UserID: "5d0a6bd2-0ad5-4d1c-9a4e-6f3f1d2b7c11"})
+	require.NoError(t, err)
+
+	_, err = provider.ValidateToken(context.Background(), tokens.AccessToken)
+	assert.NoError(t, err)
+}
+
+func TestNewProvider_RejectsWeakLocalSecret(t *testing.T) {
+	_, err := auth.NewProvider(auth.ProviderConfig{
+		Type:  auth.ProviderLocal,
+		Local: auth.LocalConfig{Secret: "short"},
+	})
+	assert.Error(t, err)
+
+	_, err = auth.NewProvider(auth.ProviderConfig{Type: "unknown"})
+	assert.Error(t, err)
+}
+
+func TestOIDCProvider_DiscoveryValidationAndLogin(t *testing.T) {
+	server := newJWKSServer(t, "/certs")
+	keys := server.setKeys(t, "oidc-key")
+
+	mux := http.NewServeMux()
+	mux.Handle("/certs", server.Config.Handler)
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"issuer":         server.URL,
+			"jwks_uri":       server.URL + "/certs",
+			"token_endpoint": server.URL + "/token",
+		})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		if r.PostForm.Get("password") != "secret" {
+			w.WriteHeader(http.StatusBadRequest)
+			_ = json.NewEncoder(w).Encode(map[string]string{"error": "invalid_grant", "error_description": "Invalid user credentials"})
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"access_token":  "access",
+			"refresh_token": "refresh",
+			"token_type":    "Bearer",
+			"expires_in":    300,
+		})
+	})
+	server.Config.Handler = mux
+
+	provider := auth.NewOIDCProvider(auth.OIDCConfig{
+		IssuerURL: server.URL,
+		ClientID:  "goclean-api",
+	})
+
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+		"sub":          "5d0a6bd2-0ad5-4d1c-9a4e-6f3f1d2b7c11",
+		"iss":          server.URL,
+		"azp":          "goclean-api",
+		"exp":          time.Now().Add(time.Minute).Unix(),
+		"realm_access": map[string]interface{}{"roles": []string{"user"}},
+	})
+	token.Header["kid"] = "oidc-key"
+	signed, err := token.SignedString(keys["oidc-key"])
+	require.NoError(t, err)
+
+	claims, err := provider.ValidateToken(context.Background(), signed)
+	require.NoError(t, err)
+	assert.True(t, claims.HasRole("user"))
+
+	tokens, err := provider.Login(context.Background(), "jane", "secret")
+	require.NoError(t, err)
+	assert.Equal(t, "access", tokens.AccessToken)
+	assert.Equal(t, 300, tokens.ExpiresIn)
+
+	_, err = provider.Login(context.Background(), "jane", "wrong")
+	assert.ErrorIs(t, err, auth.ErrInvalidCredentials)
+
+	// Neither revocation nor end session endpoint is advertised
+	err = provider.Logout(context.Background(), "refresh")
+	assert.ErrorIs(t, err, auth.ErrUnsupportedOperation)
+}
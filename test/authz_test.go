@@ -0,0 +1,109 @@
+package test
+
+import (
+	"context"
+	"goclean/internal/application/authz"
+	"goclean/internal/application/queries"
+	"goclean/internal/domain/entities"
+	"goclean/test/mocks"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPolicyAuthorizer_DefaultPolicy(t *testing.T) {
+	authorizer := authz.NewPolicyAuthorizer(authz.DefaultPolicy()...)
+	ownerID := uuid.New()
+	order := authz.OwnedBy(authz.ResourceOrder, uuid.New(), ownerID)
+
+	owner := authz.NewPrincipal(ownerID.String(), nil, map[string]string{authz.AttrAuthMethod: authz.AuthMethodToken})
+	stranger := authz.NewPrincipal(uuid.New().String(), nil, nil)
+	admin := authz.NewPrincipal(uuid.New().String(), []string{"admin"}, map[string]string{authz.AttrAuthMethod: authz.AuthMethodToken})
+	adminKey := authz.NewPrincipal(uuid.New().String(), []string{"admin"}, map[string]string{authz.AttrAuthMethod: authz.AuthMethodAPIKey})
+	systemToken := authz.NewPrincipal(uuid.New().String(), []string{authz.RoleSystem}, map[string]string{authz.AttrAuthMethod: authz.AuthMethodToken})
+	systemKey := authz.NewPrincipal(uuid.New().String(), []string{authz.RoleSystem}, map[string]string{authz.AttrAuthMethod: authz.AuthMethodAPIKey})
+
+	tests := []struct {
+		name      string
+		principal *authz.Principal
+		action    authz.Action
+		resource  authz.Resource
+		expectErr error
+	}{
+		{"owner reads own order", owner, authz.ActionOrderRead, order, nil},
+		{"owner cancels own order", owner, authz.ActionOrderCancel, order, nil},
+		{"stranger cannot read order", stranger, authz.ActionOrderRead, order, authz.ErrForbidden},
+		{"admin reads any order", admin, authz.ActionOrderRead, order, nil},
+		{"user cannot list all orders", owner, authz.ActionOrderList, authz.Resource{Type: authz.ResourceOrder}, authz.ErrForbidden},
+		{"user cannot restore users", owner, authz.ActionUserRestore, authz.OwnedBy(authz.ResourceUser, ownerID, ownerID), authz.ErrForbidden},
+		{"user updates own account", owner, authz.ActionUserUpdate, authz.OwnedBy(authz.ResourceUser, ownerID, ownerID), nil},
+		{"stranger cannot update account", stranger, authz.ActionUserUpdate, authz.OwnedBy(authz.ResourceUser, ownerID, ownerID), authz.ErrForbidden},
+		{"user cannot delete products", owner, authz.ActionProductDelete, authz.Resource{Type: authz.ResourceProduct}, authz.ErrForbidden},
+		{"admin restores users", admin, authz.ActionUserRestore, authz.OwnedBy(authz.ResourceUser, ownerID, ownerID), nil},
+		{"admin restores orders", admin, authz.Lifecycle(authz.ResourceOrder).Restore, order, nil},
+		{"owner cannot delete own profile", owner, authz.ActionProfileDelete, authz.OwnedBy(authz.ResourceProfile, uuid.New(), ownerID), authz.ErrForbidden},
+		{"admin manages API keys", admin, authz.ActionAPIKeyManage, authz.Resource{Type: authz.ResourceAPIKey}, nil},
+		{"admin API key cannot manage API keys", adminKey, authz.ActionAPIKeyManage, authz.Resource{Type: authz.ResourceAPIKey}, authz.ErrForbidden},
+		{"system principal may do anything", authz.SystemPrincipal(), authz.ActionUserRestore, authz.Resource{Type: authz.ResourceUser}, nil},
+		{"admin cannot purge orders", admin, authz.Lifecycle(authz.ResourceOrder).Purge, authz.Resource{Type: authz.ResourceOrder}, authz.ErrForbidden},
+		{"system principal purges orders", authz.SystemPrincipal(), authz.ActionOrderPurge, authz.Resource{Type: authz.ResourceOrder}, nil},
+		{"token with system role cannot purge", systemToken, authz.ActionOrderPurge, authz.Resource{Type: authz.ResourceOrder}, authz.ErrForbidden},
+		{"API key with system role cannot manage API keys", systemKey, authz.ActionAPIKeyManage, authz.Resource{Type: authz.ResourceAPIKey}, authz.ErrForbidden},
+		{"anonymous caller is unauthenticated", nil, authz.ActionOrderRead, order, authz.ErrUnauthenticated},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := authorizer.Can(context.Background(), tt.principal, tt.action, tt.resource)
+			if tt.expectErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tt.expectErr)
+			}
+		})
+	}
+}
+
+func TestPolicyAuthorizer_ResourceAttributes(t *testing.T) {
+	// Support staff may cancel orders, but only before they ship
+	authorizer := authz.NewPolicyAuthorizer(authz.Rule{
+		Actions:   []authz.Action{authz.ActionOrderCancel},
+		Roles:     []string{"support"},
+		Condition: authz.ResourceAttribute("status", "pending", "confirmed"),
+	})
+	support := authz.NewPrincipal(uuid.New().String(), []string{"support"}, nil)
+
+	pending := authz.Resource{Type: authz.ResourceOrder, Attributes: map[string]string{"status": "pending"}}
+	shipped := authz.Resource{Type: authz.ResourceOrder, Attributes: map[string]string{"status": "shipped"}}
+
+	assert.NoError(t, authorizer.Can(context.Background(), support, authz.ActionOrderCancel, pending))
+	assert.ErrorIs(t, authorizer.Can(context.Background(), support, authz.ActionOrderCancel, shipped), authz.ErrForbidden)
+}
+
+func TestOrderQueryHandler_EnforcesOwnership(t *testing.T) {
+	ownerID := uuid.New()
+	order, err := entities.NewOrder(ownerID, []entities.OrderItem{
+		*entities.NewOrderItem(uuid.Nil, uuid.New(), 1, entities.Money{Amount: 500, Currency: "USD"}),
+	})
+	require.NoError(t, err)
+
+	orderRepo := new(mocks.MockOrderRepository)
+	orderRepo.On("GetByID", mock.Anything, order.ID).Return(order, nil)
+	handler := queries.NewOrderQueryHandler(orderRepo, authz.NewPolicyAuthorizer(authz.DefaultPolicy()...))
+
+	// The same check applies whichever interface put the principal in the context
+	ownerCtx := authz.WithPrincipal(context.Background(), authz.NewPrincipal(ownerID.String(), nil, nil))
+	result, err := handler.Handle(ownerCtx, queries.GetOrderByIDQuery{ID: order.ID})
+	require.NoError(t, err)
+	assert.Equal(t, order.ID, result.Order.ID)
+
+	strangerCtx := authz.WithPrincipal(context.Background(), authz.NewPrincipal(uuid.New().String(), nil, nil))
+	_, err = handler.Handle(strangerCtx, queries.GetOrderByIDQuery{ID: order.ID})
+	assert.ErrorIs(t, err, authz.ErrForbidden)
+
+	_, err = handler.Handle(context.Background(), queries.GetOrderByIDQuery{ID: order.ID})
+	assert.ErrorIs(t, err, authz.ErrUnauthenticated)
+}
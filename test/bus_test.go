@@ -0,0 +1,138 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"goclean/internal/application/authz"
+	"goclean/internal/application/bus"
+	"goclean/internal/application/commands"
+	"goclean/internal/application/queries"
+	"goclean/internal/application/validation"
+	"goclean/internal/domain/repositories"
+	"goclean/test/mocks"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingMiddleware appends its name to calls before and after the handler runs
+func recordingMiddleware(name string, calls *[]string) bus.Middleware {
+	return func(next bus.HandlerFunc) bus.HandlerFunc {
+		return func(ctx context.Context, msg bus.Message) (any, error) {
+			*calls = append(*calls, name+">"+msg.Name)
+			result, err := next(ctx, msg)
+			*calls = append(*calls, name+"<")
+			return result, err
+		}
+	}
+}
+
+func TestCommandBus_DispatchRunsMiddlewareInOrder(t *testing.T) {
+	var calls []string
+	commandBus := bus.NewCommandBus(recordingMiddleware("outer", &calls), recordingMiddleware("inner", &calls))
+
+	var handled commands.DeleteUserCommand
+	bus.RegisterCommand(commandBus, func(ctx context.Context, cmd commands.DeleteUserCommand) error {
+		calls = append(calls, "handler")
+		handled = cmd
+		return nil
+	})
+
+	cmd := commands.DeleteUserCommand{ID: uuid.New()}
+	require.NoError(t, commandBus.Dispatch(context.Background(), cmd))
+	assert.Equal(t, cmd, handled)
+	assert.Equal(t, []string{"outer>DeleteUserCommand", "inner>DeleteUserCommand", "handler", "inner<", "outer<"}, calls)
+
+	err := commandBus.Dispatch(context.Background(), commands.DeleteProductCommand{ID: uuid.New()})
+	assert.ErrorIs(t, err, bus.ErrHandlerNotFound)
+
+	assert.Panics(t, func() {
+		bus.RegisterCommand(commandBus, func(ctx context.Context, cmd commands.DeleteUserCommand) error { return nil })
+	})
+}
+
+func TestQueryBus_AskReturnsTypedResult(t *testing.T) {
+	queryBus := bus.NewQueryBus()
+	bus.RegisterQuery(queryBus, func(ctx context.Context, query queries.ListProductsQuery) (*queries.ProductsResult, error) {
+		return &queries.ProductsResult{Total: query.Limit}, nil
+	})
+
+	result, err := bus.Ask[*queries.ProductsResult](context.Background(), queryBus, queries.ListProductsQuery{Limit: 7})
+	require.NoError(t, err)
+	assert.Equal(t, 7, result.Total)
+
+	_, err = bus.Ask[*queries.OrdersResult](context.Background(), queryBus, queries.ListProductsQuery{Limit: 7})
+	assert.ErrorIs(t, err, bus.ErrUnexpectedResult)
+}
+
+func TestBus_AuthorizationAndValidation(t *testing.T) {
+	commandBus := bus.NewCommandBus(bus.Authorization(), bus.Validation(validation.New()))
+	bus.RegisterCommand(commandBus, func(ctx context.Context, cmd commands.CreateUserCommand) error { return nil })
+	bus.RegisterCommand(commandBus, func(ctx context.Context, cmd commands.CreateOrderCommand) error { return nil })
+
+	anonymous := context.Background()
+	authenticated := authz.WithPrincipal(context.Background(), authz.NewPrincipal(uuid.New().String(), nil, nil))
+	order := commands.CreateOrderCommand{
+		UserID: uuid.New(),
+		Items:  []commands.CreateOrderItemData{{ProductID: uuid.New(), Quantity: 1}},
+	}
+
+	// Registration is open to visitors, everything else needs a principal
+	assert.NoError(t, commandBus.Dispatch(anonymous, commands.CreateUserCommand{
+		Email: "new@example.com", Username: "newcomer", FirstName: "New", LastName: "Comer",
+	}))
+	assert.ErrorIs(t, commandBus.Dispatch(anonymous, order), authz.ErrUnauthenticated)
+	assert.NoError(t, commandBus.Dispatch(authenticated, order))
+
+	order.Items = nil
+	err := commandBus.Dispatch(authenticated, order)
+	assert.ErrorIs(t, err, bus.ErrInvalidMessage)
+	var fieldErrors validation.Errors
+	require.ErrorAs(t, err, &fieldErrors)
+	assert.Equal(t, "items", fieldErrors[0].Field)
+}
+
+// countingUnitOfWork counts the units of work it runs
+type countingUnitOfWork struct {
+	mocks.MockUnitOfWork
+	count int
+}
+
+func (u *countingUnitOfWork) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	u.count++
+	return u.MockUnitOfWork.Do(ctx, fn)
+}
+
+func TestBus_RetryRunsEachAttemptInANewTransaction(t *testing.T) {
+	uow := &countingUnitOfWork{}
+	commandBus := bus.NewCommandBus(
+		bus.Retry(3, time.Millisecond, bus.IsConcurrentModification),
+		bus.Transaction(uow),
+	)
+
+	attempts := 0
+	bus.RegisterCommand(commandBus, func(ctx context.Context, cmd commands.CancelOrderCommand) error {
+		attempts++
+		if attempts < 3 {
+			return repositories.ErrConcurrentModification
+		}
+		return nil
+	})
+	errUnavailable := errors.New("catalogue unavailable")
+	bus.RegisterCommand(commandBus, func(ctx context.Context, cmd commands.DeleteProductCommand) error {
+		attempts++
+		return errUnavailable
+	})
+
+	require.NoError(t, commandBus.Dispatch(context.Background(), commands.CancelOrderCommand{ID: uuid.New()}))
+	assert.Equal(t, 3, attempts)
+	assert.Equal(t, 3, uow.count)
+
+	// Other errors are not retried
+	attempts = 0
+	assert.ErrorIs(t, commandBus.Dispatch(context.Background(), commands.DeleteProductCommand{ID: uuid.New()}), errUnavailable)
+	assert.Equal(t, 1, attempts)
+}
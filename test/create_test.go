@@ -0,0 +1,68 @@
+package test
+
+import (
+	"context"
+	"encoding/json"
+	pb "goclean/api/proto/v1"
+	"goclean/internal/application/bus"
+	"goclean/internal/application/commands"
+	"goclean/internal/application/dto"
+	"goclean/internal/application/validation"
+	"goclean/internal/domain/entities"
+	grpcServer "goclean/internal/interfaces/grpc"
+	"goclean/internal/interfaces/http/handlers"
+	"goclean/pkg/logger"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUserHandler_CreateUserReturnsCreatedUser(t *testing.T) {
+	commandBus := bus.NewCommandBus()
+	bus.RegisterCommandWithResult(commandBus, func(ctx context.Context, cmd commands.CreateUserCommand) (*entities.User, error) {
+		return entities.NewUser(cmd.Email, cmd.Username, cmd.FirstName, cmd.LastName), nil
+	})
+
+	e := echo.New()
+	e.Validator = validation.New()
+	e.HTTPErrorHandler = handlers.ErrorHandler(logger.NewDefault())
+	e.POST("/api/v1/users", handlers.NewUserHandler(commandBus, nil).CreateUser)
+
+	body := `{"email": "jane@example.com", "username": "jane", "first_name": "Jane", "last_name": "Doe"}`
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/users", strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusCreated, rec.Code)
+
+	var response dto.APIResponse[*dto.UserDTO]
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	require.NotNil(t, response.Data)
+	assert.Equal(t, "jane@example.com", response.Data.Email)
+	assert.Equal(t, "/api/v1/users/"+response.Data.ID.String(), rec.Header().Get(echo.HeaderLocation))
+}
+
+func TestUserService_CreateUserReturnsID(t *testing.T) {
+	var created *entities.User
+	commandBus := bus.NewCommandBus()
+	bus.RegisterCommandWithResult(commandBus, func(ctx context.Context, cmd commands.CreateUserCommand) (*entities.User, error) {
+		created = entities.NewUser(cmd.Email, cmd.Username, cmd.FirstName, cmd.LastName)
+		return created, nil
+	})
+	service := grpcServer.NewUserService(commandBus, nil)
+
+	resp, err := service.CreateUser(context.Background(), &pb.CreateUserRequest{
+		Email:     "jane@example.com",
+		Username:  "jane",
+		FirstName: "Jane",
+		LastName:  "Doe",
+	})
+	require.NoError(t, err)
+	require.NotNil(t, created)
+	assert.Equal(t, created.ID.String(), resp.GetId())
+}
@@ -2,7 +2,9 @@ package test
 
 import (
 	"context"
+	"errors"
 	"goclean/internal/domain/entities"
+	"goclean/internal/domain/repositories"
 	"goclean/internal/domain/services"
 	"goclean/test/mocks"
 	"testing"
@@ -10,6 +12,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 func TestUserDomainService_CreateUserWithProfile(t *testing.T) {
@@ -43,6 +46,18 @@ func TestUserDomainService_CreateUserWithProfile(t *testing.T) {
 			expectError: true,
 			errorMsg:    "user already exists",
 		},
+		{
+			name:    "profile creation failure is returned without compensating delete",
+			user:    entities.NewUser("test@example.com", "testuser", "Test", "User"),
+			profile: entities.NewProfile(uuid.New(), "Test bio", "avatar.jpg", nil),
+			setupMocks: func(userRepo *mocks.MockUserRepository, profileRepo *mocks.MockProfileRepository) {
+				userRepo.On("GetByEmail", mock.Anything, "test@example.com").Return(nil, nil)
+				userRepo.On("Create", mock.Anything, mock.AnythingOfType("*entities.User")).Return(nil)
+				profileRepo.On("Create", mock.Anything, mock.AnythingOfType("*entities.Profile")).Return(errors.New("profile insert failed"))
+			},
+			expectError: true,
+			errorMsg:    "profile insert failed",
+		},
 	}
 
 	for _, tt := range tests {
@@ -56,7 +71,7 @@ func TestUserDomainService_CreateUserWithProfile(t *testing.T) {
 			}
 
 			// Create service
-			service := services.NewUserDomainService(userRepo, profileRepo)
+			service := services.NewUserDomainService(&mocks.MockUnitOfWork{}, userRepo, profileRepo)
 
 			// Execute
 			err := service.CreateUserWithProfile(context.Background(), tt.user, tt.profile)
@@ -78,6 +93,170 @@ func TestUserDomainService_CreateUserWithProfile(t *testing.T) {
 	}
 }
 
+// errLookupFailed stands in for a database failure
+var errLookupFailed = errors.New("connection refused")
+
+func TestUserDomainService_ProvisionUser(t *testing.T) {
+	subject := uuid.New()
+
+	tests := []struct {
+		name        string
+		setupMocks  func(*mocks.MockUserRepository)
+		expectError error
+		checkUser   func(*testing.T, *entities.User)
+	}{
+		{
+			name: "unknown subject creates user with subject as ID",
+			setupMocks: func(userRepo *mocks.MockUserRepository) {
+				userRepo.On("GetByIDIncludeDeleted", mock.Anything, subject).Return(nil, repositories.ErrNotFound)
+				userRepo.On("GetByEmail", mock.Anything, "jane@example.com").Return(nil, repositories.ErrNotFound)
+				userRepo.On("GetByUsername", mock.Anything, "jane").Return(nil, repositories.ErrNotFound)
+				userRepo.On("Create", mock.Anything, mock.AnythingOfType("*entities.User")).Return(nil)
+			},
+			checkUser: func(t *testing.T, user *entities.User) {
+				assert.Equal(t, subject, user.ID)
+				require.Len(t, user.DomainEvents(), 1)
+				assert.Equal(t, "UserCreated", user.DomainEvents()[0].EventType())
+			},
+		},
+		{
+			name: "known subject with changed claims is synced",
+			setupMocks: func(userRepo *mocks.MockUserRepository) {
+				existing := entities.NewUserWithID(subject, "old@example.com", "jane", "Jane", "Doe")
+				existing.ClearDomainEvents()
+				userRepo.On("GetByIDIncludeDeleted", mock.Anything, subject).Return(existing, nil)
+				userRepo.On("Update", mock.Anything, existing).Return(nil)
+			},
+			checkUser: func(t *testing.T, user *entities.User) {
+				assert.Equal(t, "jane@example.com", user.Email)
+				assert.Empty(t, user.DomainEvents())
+			},
+		},
+		{
+			name: "known subject with unchanged claims is not updated",
+			setupMocks: func(userRepo *mocks.MockUserRepository) {
+				existing := entities.NewUserWithID(subject, "jane@example.com", "jane", "Jane", "Doe")
+				userRepo.On("GetByIDIncludeDeleted", mock.Anything, subject).Return(existing, nil)
+			},
+		},
+		{
+			name: "deleted user is not recreated",
+			setupMocks: func(userRepo *mocks.MockUserRepository) {
+				existing := entities.NewUserWithID(subject, "jane@example.com", "jane", "Jane", "Doe")
+				existing.Delete("account closed")
+				userRepo.On("GetByIDIncludeDeleted", mock.Anything, subject).Return(existing, nil)
+			},
+			expectError: services.ErrUserDeleted,
+		},
+		{
+			name: "database failure is not mistaken for an unknown subject",
+			setupMocks: func(userRepo *mocks.MockUserRepository) {
+				userRepo.On("GetByIDIncludeDeleted", mock.Anything, subject).Return(nil, errLookupFailed)
+			},
+			expectError: errLookupFailed,
+		},
+		{
+			name: "email owned by another local user",
+			setupMocks: func(userRepo *mocks.MockUserRepository) {
+				userRepo.On("GetByIDIncludeDeleted", mock.Anything, subject).Return(nil, repositories.ErrNotFound)
+				userRepo.On("GetByEmail", mock.Anything, "jane@example.com").Return(entities.NewUser("jane@example.com", "registered", "Jane", "Doe"), nil)
+			},
+			expectError: services.ErrUserAlreadyExists,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userRepo := &mocks.MockUserRepository{}
+			tt.setupMocks(userRepo)
+
+			service := services.NewUserDomainService(&mocks.MockUnitOfWork{}, userRepo, &mocks.MockProfileRepository{})
+
+			user, err := service.ProvisionUser(context.Background(), subject, "jane@example.com", "jane", "Jane", "Doe")
+
+			if tt.expectError != nil {
+				assert.ErrorIs(t, err, tt.expectError)
+			} else {
+				require.NoError(t, err)
+				if tt.checkUser != nil {
+					tt.checkUser(t, user)
+				}
+			}
+
+			userRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUserDomainService_UpdateUser(t *testing.T) {
+	tests := []struct {
+		name        string
+		changes     entities.UserChanges
+		setupMocks  func(*mocks.MockUserRepository, *entities.User)
+		expectError error
+		checkUser   func(*testing.T, *entities.User)
+	}{
+		{
+			name:    "only given fields are changed",
+			changes: entities.UserChanges{FirstName: ptr("Janet")},
+			setupMocks: func(userRepo *mocks.MockUserRepository, user *entities.User) {
+				userRepo.On("Update", mock.Anything, user).Return(nil)
+			},
+			checkUser: func(t *testing.T, user *entities.User) {
+				assert.Equal(t, "Janet", user.FirstName)
+				assert.Equal(t, "Doe", user.LastName)
+				assert.Equal(t, "jane@example.com", user.Email)
+			},
+		},
+		{
+			name:    "unchanged values are not saved",
+			changes: entities.UserChanges{Email: ptr("jane@example.com")},
+		},
+		{
+			name:    "email of another user",
+			changes: entities.UserChanges{Email: ptr("john@example.com")},
+			setupMocks: func(userRepo *mocks.MockUserRepository, user *entities.User) {
+				userRepo.On("GetByEmail", mock.Anything, "john@example.com").Return(entities.NewUser("john@example.com", "john", "John", "Doe"), nil)
+			},
+			expectError: services.ErrEmailTaken,
+		},
+		{
+			name:    "username of another user",
+			changes: entities.UserChanges{Username: ptr("john")},
+			setupMocks: func(userRepo *mocks.MockUserRepository, user *entities.User) {
+				userRepo.On("GetByUsername", mock.Anything, "john").Return(entities.NewUser("john@example.com", "john", "John", "Doe"), nil)
+			},
+			expectError: services.ErrUsernameTaken,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user := entities.NewUser("jane@example.com", "jane", "Jane", "Doe")
+			userRepo := &mocks.MockUserRepository{}
+			userRepo.On("GetByID", mock.Anything, user.ID).Return(user, nil)
+			if tt.setupMocks != nil {
+				tt.setupMocks(userRepo, user)
+			}
+
+			service := services.NewUserDomainService(&mocks.MockUnitOfWork{}, userRepo, &mocks.MockProfileRepository{})
+
+			updated, err := service.UpdateUser(context.Background(), user.ID, tt.changes)
+
+			if tt.expectError != nil {
+				assert.ErrorIs(t, err, tt.expectError)
+			} else {
+				require.NoError(t, err)
+				if tt.checkUser != nil {
+					tt.checkUser(t, updated)
+				}
+			}
+
+			userRepo.AssertExpectations(t)
+		})
+	}
+}
+
 func TestProductDomainService_ValidateProduct(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -89,7 +268,7 @@ func TestProductDomainService_ValidateProduct(t *testing.T) {
 			name: "valid product",
 			product: &entities.Product{
 				Name:  "Test Product",
-				Price: 19.99,
+				Price: entities.Money{Amount: 1999, Currency: "USD"},
 				SKU:   "TEST-001",
 			},
 			expectError: false,
@@ -98,7 +277,7 @@ func TestProductDomainService_ValidateProduct(t *testing.T) {
 			name: "invalid price - zero",
 			product: &entities.Product{
 				Name:  "Test Product",
-				Price: 0,
+				Price: entities.Money{Amount: 0, Currency: "USD"},
 				SKU:   "TEST-001",
 			},
 			expectError: true,
@@ -108,7 +287,7 @@ func TestProductDomainService_ValidateProduct(t *testing.T) {
 			name: "invalid price - negative",
 			product: &entities.Product{
 				Name:  "Test Product",
-				Price: -10.00,
+				Price: entities.Money{Amount: -1000, Currency: "USD"},
 				SKU:   "TEST-001",
 			},
 			expectError: true,
@@ -118,7 +297,7 @@ func TestProductDomainService_ValidateProduct(t *testing.T) {
 			name: "missing name",
 			product: &entities.Product{
 				Name:  "",
-				Price: 19.99,
+				Price: entities.Money{Amount: 1999, Currency: "USD"},
 				SKU:   "TEST-001",
 			},
 			expectError: true,
@@ -128,7 +307,7 @@ func TestProductDomainService_ValidateProduct(t *testing.T) {
 			name: "missing SKU",
 			product: &entities.Product{
 				Name:  "Test Product",
-				Price: 19.99,
+				Price: entities.Money{Amount: 1999, Currency: "USD"},
 				SKU:   "",
 			},
 			expectError: true,
@@ -140,7 +319,7 @@ func TestProductDomainService_ValidateProduct(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			// Setup
 			productRepo := &mocks.MockProductRepository{}
-			service := services.NewProductDomainService(productRepo)
+			service := services.NewProductDomainService(&mocks.MockUnitOfWork{}, productRepo, &mocks.MockInventoryRepository{})
 
 			// Execute
 			err := service.ValidateProduct(tt.product)
@@ -180,3 +359,123 @@ func TestOrderStatus_IsValid(t *testing.T) {
 		})
 	}
 }
+
+func TestOrderStatus_CanTransitionTo(t *testing.T) {
+	tests := []struct {
+		name     string
+		from     entities.OrderStatus
+		to       entities.OrderStatus
+		expected bool
+	}{
+		{"pending to confirmed", entities.OrderStatusPending, entities.OrderStatusConfirmed, true},
+		{"pending to cancelled", entities.OrderStatusPending, entities.OrderStatusCancelled, true},
+		{"pending to delivered", entities.OrderStatusPending, entities.OrderStatusDelivered, false},
+		{"confirmed to shipped", entities.OrderStatusConfirmed, entities.OrderStatusShipped, true},
+		{"confirmed to cancelled", entities.OrderStatusConfirmed, entities.OrderStatusCancelled, true},
+		{"shipped to delivered", entities.OrderStatusShipped, entities.OrderStatusDelivered, true},
+		{"shipped to pending", entities.OrderStatusShipped, entities.OrderStatusPending, false},
+		{"shipped to cancelled", entities.OrderStatusShipped, entities.OrderStatusCancelled, false},
+		{"delivered to cancelled", entities.OrderStatusDelivered, entities.OrderStatusCancelled, false},
+		{"cancelled to pending", entities.OrderStatusCancelled, entities.OrderStatusPending, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := tt.from.CanTransitionTo(tt.to)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestOrder_StatusTransitions(t *testing.T) {
+	userID := uuid.New()
+	adminID := uuid.New()
+
+	order, err := entities.NewOrder(userID, []entities.OrderItem{
+		*entities.NewOrderItem(uuid.Nil, uuid.New(), 1, entities.Money{Amount: 1000, Currency: "USD"}),
+	})
+	assert.NoError(t, err)
+	order.ClearDomainEvents()
+
+	assert.ErrorIs(t, order.Deliver(adminID, ""), entities.ErrInvalidStatusTransition)
+	assert.NoError(t, order.Confirm(adminID, "payment received"))
+	assert.NoError(t, order.Ship(adminID, ""))
+	assert.ErrorIs(t, order.Cancel(userID, "changed my mind"), entities.ErrInvalidStatusTransition)
+	assert.NoError(t, order.Deliver(adminID, ""))
+
+	assert.Equal(t, entities.OrderStatusDelivered, order.Status)
+	assert.Len(t, order.DomainEvents(), 3)
+
+	// Initial status plus three transitions
+	if assert.Len(t, order.StatusHistory, 4) {
+		assert.Equal(t, entities.OrderStatusPending, order.StatusHistory[0].ToStatus)
+		assert.Equal(t, entities.OrderStatusPending, order.StatusHistory[1].FromStatus)
+		assert.Equal(t, entities.OrderStatusConfirmed, order.StatusHistory[1].ToStatus)
+		assert.Equal(t, adminID, order.StatusHistory[1].ChangedBy)
+		assert.Equal(t, "payment received", order.StatusHistory[1].Reason)
+	}
+}
+
+func TestMoney_Arithmetic(t *testing.T) {
+	price := entities.Money{Amount: 1999, Currency: "USD"}
+
+	total, err := price.Multiply(3)
+	assert.NoError(t, err)
+	assert.Equal(t, entities.Money{Amount: 5997, Currency: "USD"}, total)
+
+	sum, err := total.Add(entities.Money{Amount: 3, Currency: "USD"})
+	assert.NoError(t, err)
+	assert.Equal(t, "60.00 USD", sum.String())
+
+	_, err = price.Add(entities.Money{Amount: 100, Currency: "EUR"})
+	assert.ErrorIs(t, err, entities.ErrCurrencyMismatch)
+
+	_, err = entities.NewMoney(100, "us")
+	assert.ErrorIs(t, err, entities.ErrInvalidCurrency)
+
+	assert.Equal(t, "500 JPY", entities.Money{Amount: 500, Currency: "JPY"}.String())
+}
+
+func TestNewOrder_Totals(t *testing.T) {
+	userID := uuid.New()
+
+	order, err := entities.NewOrder(userID, []entities.OrderItem{
+		*entities.NewOrderItem(uuid.Nil, uuid.New(), 3, entities.Money{Amount: 10, Currency: "USD"}),
+		*entities.NewOrderItem(uuid.Nil, uuid.New(), 1, entities.Money{Amount: 20, Currency: "USD"}),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, entities.Money{Amount: 50, Currency: "USD"}, order.TotalPrice)
+
+	_, err = entities.NewOrder(userID, []entities.OrderItem{
+		*entities.NewOrderItem(uuid.Nil, uuid.New(), 1, entities.Money{Amount: 10, Currency: "USD"}),
+		*entities.NewOrderItem(uuid.Nil, uuid.New(), 1, entities.Money{Amount: 10, Currency: "EUR"}),
+	})
+	assert.ErrorIs(t, err, entities.ErrCurrencyMismatch)
+}
+
+func TestInventoryItem_Reservations(t *testing.T) {
+	item := entities.NewInventoryItem(uuid.New(), 5)
+	orderID := uuid.New()
+
+	_, err := item.Reserve(orderID, 6)
+	assert.ErrorIs(t, err, entities.ErrInsufficientStock)
+
+	reservation, err := item.Reserve(orderID, 5)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, item.Available())
+	if assert.Len(t, item.DomainEvents(), 2) {
+		assert.Equal(t, "StockReserved", item.DomainEvents()[0].EventType())
+		assert.Equal(t, "StockDepleted", item.DomainEvents()[1].EventType())
+	}
+
+	assert.NoError(t, item.Release(reservation))
+	assert.Equal(t, 5, item.Available())
+	assert.ErrorIs(t, item.Release(reservation), entities.ErrReservationNotActive)
+
+	reservation, err = item.Reserve(uuid.New(), 2)
+	assert.NoError(t, err)
+	assert.NoError(t, item.Commit(reservation))
+	assert.Equal(t, 3, item.OnHand)
+	assert.Equal(t, 0, item.Reserved)
+	assert.Equal(t, entities.ReservationStatusCommitted, reservation.Status)
+}
@@ -0,0 +1,174 @@
+package test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	pb "goclean/api/proto/v1"
+	"goclean/internal/application/authz"
+	"goclean/internal/application/bus"
+	"goclean/internal/application/dto"
+	"goclean/internal/application/queries"
+	"goclean/internal/application/validation"
+	"goclean/internal/domain"
+	"goclean/internal/domain/entities"
+	"goclean/internal/domain/repositories"
+	"goclean/internal/domain/services"
+	"goclean/internal/infrastructure/persistence"
+	grpcServer "goclean/internal/interfaces/grpc"
+	"goclean/internal/interfaces/http/handlers"
+	"goclean/pkg/logger"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+func TestErrorHandler_RendersProblemDetails(t *testing.T) {
+	tests := []struct {
+		name           string
+		err            error
+		expectedStatus int
+		expectedCode   string
+		expectedDetail string
+	}{
+		{
+			name:           "not found",
+			err:            services.ErrUserNotFound,
+			expectedStatus: http.StatusNotFound,
+			expectedCode:   "user_not_found",
+			expectedDetail: "user not found",
+		},
+		{
+			name:           "conflict",
+			err:            services.ErrProductSKUExists,
+			expectedStatus: http.StatusConflict,
+			expectedCode:   "product_sku_exists",
+			expectedDetail: "product with this SKU already exists",
+		},
+		{
+			name:           "wrapped state transition keeps its context",
+			err:            fmt.Errorf("%w: %s -> %s", entities.ErrInvalidStatusTransition, entities.OrderStatusPending, entities.OrderStatusDelivered),
+			expectedStatus: http.StatusConflict,
+			expectedCode:   "invalid_order_status_transition",
+			expectedDetail: "invalid order status transition: pending -> delivered",
+		},
+		{
+			name:           "forbidden",
+			err:            fmt.Errorf("%w: %s", authz.ErrForbidden, authz.ActionUserList),
+			expectedStatus: http.StatusForbidden,
+			expectedCode:   "permission_denied",
+		},
+		{
+			name:           "unauthenticated",
+			err:            authz.ErrUnauthenticated,
+			expectedStatus: http.StatusUnauthorized,
+			expectedCode:   "unauthenticated",
+		},
+		{
+			name:           "domain validation",
+			err:            entities.ErrInvalidCurrency,
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   "invalid_currency",
+		},
+		{
+			name:           "rejected request",
+			err:            echo.NewHTTPError(http.StatusBadRequest, "Invalid order ID"),
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   "bad_request",
+			expectedDetail: "Invalid order ID",
+		},
+		{
+			name:           "unexpected errors do not leak",
+			err:            errors.New("dial tcp 10.0.0.5:5432: connection refused"),
+			expectedStatus: http.StatusInternalServerError,
+			expectedCode:   "internal_error",
+			expectedDetail: "An unexpected error occurred",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			e.HTTPErrorHandler = handlers.ErrorHandler(logger.NewDefault())
+			e.GET("/api/v1/things/:id", func(c echo.Context) error { return tt.err })
+
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/things/42", nil))
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			assert.Equal(t, handlers.MIMEApplicationProblemJSON, rec.Header().Get(echo.HeaderContentType))
+
+			var problem dto.ProblemDetails
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &problem))
+			assert.Equal(t, tt.expectedStatus, problem.Status)
+			assert.Equal(t, tt.expectedCode, problem.Code)
+			assert.Equal(t, http.StatusText(tt.expectedStatus), problem.Title)
+			assert.Equal(t, "/api/v1/things/42", problem.Instance)
+			if tt.expectedDetail != "" {
+				assert.Equal(t, tt.expectedDetail, problem.Detail)
+			}
+		})
+	}
+}
+
+func TestErrorHandler_ListsFieldErrorsOfInvalidMessages(t *testing.T) {
+	e := echo.New()
+	e.HTTPErrorHandler = handlers.ErrorHandler(logger.NewDefault())
+	e.POST("/api/v1/orders", func(c echo.Context) error {
+		fieldErrors := validation.Errors{{Field: "items", Rule: "min", Param: "1", Message: "must contain at least 1 items"}}
+		return fmt.Errorf("%w: %w", bus.ErrInvalidMessage, fieldErrors)
+	})
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/v1/orders", nil))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	var problem dto.ProblemDetails
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &problem))
+	assert.Equal(t, "validation_failed", problem.Code)
+	require.Len(t, problem.Errors, 1)
+	assert.Equal(t, "items", problem.Errors[0].Field)
+}
+
+func TestTranslateError(t *testing.T) {
+	err := persistence.TranslateError(fmt.Errorf("loading user: %w", gorm.ErrRecordNotFound))
+	assert.ErrorIs(t, err, repositories.ErrNotFound)
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+	assert.Equal(t, services.ErrUserNotFound, repositories.NotFoundAs(err, services.ErrUserNotFound))
+
+	err = persistence.TranslateError(gorm.ErrDuplicatedKey)
+	assert.ErrorIs(t, err, repositories.ErrDuplicate)
+	assert.ErrorIs(t, err, domain.ErrConflict)
+
+	// Failures other than a missing record are never reported as not found
+	errUnavailable := errors.New("connection refused")
+	assert.Equal(t, errUnavailable, persistence.TranslateError(errUnavailable))
+	assert.Equal(t, errUnavailable, repositories.NotFoundAs(errUnavailable, services.ErrUserNotFound))
+}
+
+func TestProductService_ErrorsCarryCodes(t *testing.T) {
+	queryBus := bus.NewQueryBus()
+	bus.RegisterQuery(queryBus, func(ctx context.Context, query queries.GetProductByIDQuery) (*queries.ProductResult, error) {
+		return nil, services.ErrProductNotFound
+	})
+	service := grpcServer.NewProductService(nil, queryBus)
+
+	_, err := service.GetProduct(context.Background(), &pb.GetProductRequest{Id: uuid.NewString()})
+	st, ok := status.FromError(err)
+	require.True(t, ok)
+	assert.Equal(t, codes.NotFound, st.Code())
+	require.NotEmpty(t, st.Details())
+	info, ok := st.Details()[0].(*errdetails.ErrorInfo)
+	require.True(t, ok)
+	assert.Equal(t, "product_not_found", info.Reason)
+}
@@ -0,0 +1,67 @@
+package test
+
+import (
+	"context"
+	pb "goclean/api/proto/v1"
+	"goclean/internal/infrastructure/auth"
+	"goclean/internal/interfaces/grpc/interceptors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestAuthInterceptor_Unary(t *testing.T) {
+	provider := newLocalProvider(t)
+	interceptor := interceptors.NewAuthInterceptor(provider, nil,
+		[]string{pb.ProductService_GetProduct_FullMethodName},
+		map[string][]string{pb.UserService_ListUsers_FullMethodName: {"admin"}},
+	).Unary()
+
+	userTokens, err := provider.IssueToken(auth.UserClaims{UserID: "5d0a6bd2-0ad5-4d1c-9a4e-6f3f1d2b7c11"})
+	require.NoError(t, err)
+	adminTokens, err := provider.IssueToken(auth.UserClaims{
+		UserID:      "0f6f3f0e-8a4c-4b8e-9d39-2d0c1b6f5a77",
+		RealmAccess: auth.RoleClaims{Roles: []string{"admin"}},
+	})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name         string
+		method       string
+		token        string
+		expectCode   codes.Code
+		expectClaims bool
+	}{
+		{"public method skips authentication", pb.ProductService_GetProduct_FullMethodName, "", codes.OK, false},
+		{"missing token", pb.OrderService_GetOrder_FullMethodName, "", codes.Unauthenticated, false},
+		{"invalid token", pb.OrderService_GetOrder_FullMethodName, "not-a-token", codes.Unauthenticated, false},
+		{"authenticated user without a role requirement", pb.OrderService_GetOrder_FullMethodName, userTokens.AccessToken, codes.OK, true},
+		{"user without the required role", pb.UserService_ListUsers_FullMethodName, userTokens.AccessToken, codes.PermissionDenied, false},
+		{"admin with the required role", pb.UserService_ListUsers_FullMethodName, adminTokens.AccessToken, codes.OK, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.token != "" {
+				ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("authorization", "Bearer "+tt.token))
+			}
+
+			called := false
+			_, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: tt.method}, func(ctx context.Context, req interface{}) (interface{}, error) {
+				called = true
+				_, ok := auth.UserClaimsFromContext(ctx)
+				assert.Equal(t, tt.expectClaims, ok)
+				return nil, nil
+			})
+
+			assert.Equal(t, tt.expectCode, status.Code(err))
+			assert.Equal(t, tt.expectCode == codes.OK, called)
+		})
+	}
+}
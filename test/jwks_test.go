@@ -0,0 +1,207 @@
+package test
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"goclean/internal/infrastructure/auth"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// jwksServer serves a mutable JWKS document and counts requests
+type jwksServer struct {
+	*httptest.Server
+	mu       sync.Mutex
+	keys     map[string]*rsa.PrivateKey
+	requests atomic.Int32
+}
+
+func newJWKSServer(t *testing.T, path string) *jwksServer {
+	s := &jwksServer{keys: make(map[string]*rsa.PrivateKey)}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != path {
+			http.NotFound(w, r)
+			return
+		}
+		s.requests.Add(1)
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		keys := []map[string]string{}
+		for kid, key := range s.keys {
+			keys = append(keys, map[string]string{
+				"kid": kid,
+				"kty": "RSA",
+				"alg": "RS256",
+				"use": "sig",
+				"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+			})
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"keys": keys})
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *jwksServer) setKeys(t *testing.T, kids ...string) map[string]*rsa.PrivateKey {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.keys = make(map[string]*rsa.PrivateKey)
+	for _, kid := range kids {
+		key, err := rsa.GenerateKey(rand.Reader, 2048)
+		require.NoError(t, err)
+		s.keys[kid] = key
+	}
+	return s.keys
+}
+
+func TestJWKSKeyProvider_SelectsKeyByKid(t *testing.T) {
+	server := newJWKSServer(t, "/certs")
+	keys := server.setKeys(t, "key-1", "key-2")
+
+	provider := auth.NewJWKSKeyProvider(auth.JWKSConfig{URL: server.URL + "/certs"})
+
+	key, err := provider.GetKey(context.Background(), "key-2")
+	require.NoError(t, err)
+	assert.Equal(t, keys["key-2"].PublicKey.N, key.N)
+	assert.Equal(t, keys["key-2"].PublicKey.E, key.E)
+
+	// Cached keys are served without another request
+	_, err = provider.GetKey(context.Background(), "key-1")
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), server.requests.Load())
+
+	// An empty kid is ambiguous when several keys are published
+	_, err = provider.GetKey(context.Background(), "")
+	assert.ErrorIs(t, err, auth.ErrKeyNotFound)
+}
+
+func TestJWKSKeyProvider_RotationAndRateLimit(t *testing.T) {
+	server := newJWKSServer(t, "/certs")
+	server.setKeys(t, "old")
+
+	provider := auth.NewJWKSKeyProvider(auth.JWKSConfig{
+		URL:                server.URL + "/certs",
+		CacheTTL:           time.Hour,
+		MinRefreshInterval: 50 * time.Millisecond,
+	})
+
+	_, err := provider.GetKey(context.Background(), "old")
+	require.NoError(t, err)
+
+	// Unknown kids within the refresh interval do not hit the endpoint
+	_, err = provider.GetKey(context.Background(), "unknown")
+	assert.ErrorIs(t, err, auth.ErrKeyNotFound)
+	assert.Equal(t, int32(1), server.requests.Load())
+
+	// After rotation the new kid is fetched once the interval has passed
+	rotated := server.setKeys(t, "new")
+	time.Sleep(60 * time.Millisecond)
+
+	key, err := provider.GetKey(context.Background(), "new")
+	require.NoError(t, err)
+	assert.Equal(t, rotated["new"].PublicKey.N, key.N)
+	assert.Equal(t, int32(2), server.requests.Load())
+}
+
+func TestAuthService_ValidateToken(t *testing.T) {
+	server := newJWKSServer(t, "/realms/goclean/protocol/openid-connect/certs")
+	keys := server.setKeys(t, "signing-key")
+
+	authService := auth.NewAuthService(auth.KeycloakConfig{
+		BaseURL:  server.URL,
+		Realm:    "goclean",
+		ClientID: "goclean-api",
+	})
+
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+		"sub":                "5d0a6bd2-0ad5-4d1c-9a4e-6f3f1d2b7c11",
+		"preferred_username": "jane",
+		"iss":                server.URL + "/realms/goclean",
+		"aud":                "account",
+		"azp":                "goclean-api",
+		"exp":                time.Now().Add(time.Minute).Unix(),
+		"realm_access":       map[string]interface{}{"roles": []string{"user"}},
+		"resource_access": map[string]interface{}{
+			"goclean-api":  map[string]interface{}{"roles": []string{"admin"}},
+			"other-client": map[string]interface{}{"roles": []string{"auditor"}},
+		},
+	})
+	token.Header["kid"] = "signing-key"
+	signed, err := token.SignedString(keys["signing-key"])
+	require.NoError(t, err)
+
+	claims, err := authService.ValidateToken(context.Background(), "Bearer "+signed)
+	require.NoError(t, err)
+	assert.Equal(t, "5d0a6bd2-0ad5-4d1c-9a4e-6f3f1d2b7c11", claims.UserID)
+	assert.Equal(t, "jane", claims.Username)
+	assert.True(t, claims.HasRole("user"))
+	assert.True(t, claims.HasRole("admin"))
+	assert.False(t, claims.HasRole("auditor"))
+	assert.True(t, claims.HasClientRole("other-client", "auditor"))
+	assert.ElementsMatch(t, []string{"user", "admin"}, claims.Roles())
+
+	// A token signed with an unpublished key is rejected
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	forged, err := token.SignedString(otherKey)
+	require.NoError(t, err)
+
+	_, err = authService.ValidateToken(context.Background(), forged)
+	assert.Error(t, err)
+}
+
+func TestAuthService_ValidateToken_RejectsForeignIssuerAndAudience(t *testing.T) {
+	server := newJWKSServer(t, "/realms/goclean/protocol/openid-connect/certs")
+	keys := server.setKeys(t, "signing-key")
+
+	authService := auth.NewAuthService(auth.KeycloakConfig{
+		BaseURL:  server.URL,
+		Realm:    "goclean",
+		ClientID: "goclean-api",
+	})
+
+	sign := func(claims jwt.MapClaims) string {
+		claims["sub"] = "5d0a6bd2-0ad5-4d1c-9a4e-6f3f1d2b7c11"
+		claims["exp"] = time.Now().Add(time.Minute).Unix()
+		token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+		token.Header["kid"] = "signing-key"
+		signed, err := token.SignedString(keys["signing-key"])
+		require.NoError(t, err)
+		return signed
+	}
+
+	_, err := authService.ValidateToken(context.Background(), sign(jwt.MapClaims{
+		"iss": server.URL + "/realms/other",
+		"azp": "goclean-api",
+	}))
+	assert.ErrorIs(t, err, jwt.ErrTokenInvalidIssuer)
+
+	_, err = authService.ValidateToken(context.Background(), sign(jwt.MapClaims{
+		"iss": server.URL + "/realms/goclean",
+		"aud": "account",
+		"azp": "other-client",
+	}))
+	assert.ErrorIs(t, err, auth.ErrInvalidAudience)
+
+	// The client may also be named in aud when azp differs
+	_, err = authService.ValidateToken(context.Background(), sign(jwt.MapClaims{
+		"iss": server.URL + "/realms/goclean",
+		"aud": []string{"account", "goclean-api"},
+		"azp": "frontend",
+	}))
+	assert.NoError(t, err)
+}
@@ -3,6 +3,7 @@ package mocks
 import (
 	"context"
 	"goclean/internal/domain/entities"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/mock"
@@ -86,6 +87,14 @@ func (m *MockUserRepository) ListDeleted(ctx context.Context, offset, limit int)
 	return args.Get(0).([]*entities.User), args.Error(1)
 }
 
+func (m *MockUserRepository) ListDeletedBefore(ctx context.Context, before time.Time, offset, limit int) ([]*entities.User, error) {
+	args := m.Called(ctx, before, offset, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entities.User), args.Error(1)
+}
+
 func (m *MockUserRepository) List(ctx context.Context, offset, limit int) ([]*entities.User, error) {
 	args := m.Called(ctx, offset, limit)
 	if args.Get(0) == nil {
@@ -148,6 +157,22 @@ func (m *MockProfileRepository) Restore(ctx context.Context, id uuid.UUID) error
 	return args.Error(0)
 }
 
+func (m *MockProfileRepository) ListDeleted(ctx context.Context, offset, limit int) ([]*entities.Profile, error) {
+	args := m.Called(ctx, offset, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entities.Profile), args.Error(1)
+}
+
+func (m *MockProfileRepository) ListDeletedBefore(ctx context.Context, before time.Time, offset, limit int) ([]*entities.Profile, error) {
+	args := m.Called(ctx, before, offset, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entities.Profile), args.Error(1)
+}
+
 // MockProductRepository is a mock implementation of ProductRepository
 type MockProductRepository struct {
 	mock.Mock
@@ -242,6 +267,14 @@ func (m *MockProductRepository) ListDeleted(ctx context.Context, offset, limit i
 	return args.Get(0).([]*entities.Product), args.Error(1)
 }
 
+func (m *MockProductRepository) ListDeletedBefore(ctx context.Context, before time.Time, offset, limit int) ([]*entities.Product, error) {
+	args := m.Called(ctx, before, offset, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entities.Product), args.Error(1)
+}
+
 // MockOrderRepository is a mock implementation of OrderRepository
 type MockOrderRepository struct {
 	mock.Mock
@@ -324,3 +357,140 @@ func (m *MockOrderRepository) ListDeleted(ctx context.Context, offset, limit int
 	}
 	return args.Get(0).([]*entities.Order), args.Error(1)
 }
+
+func (m *MockOrderRepository) ListDeletedBefore(ctx context.Context, before time.Time, offset, limit int) ([]*entities.Order, error) {
+	args := m.Called(ctx, before, offset, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entities.Order), args.Error(1)
+}
+
+func (m *MockOrderRepository) GetByUserIDIncludeDeleted(ctx context.Context, userID uuid.UUID, offset, limit int) ([]*entities.Order, error) {
+	args := m.Called(ctx, userID, offset, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entities.Order), args.Error(1)
+}
+
+func (m *MockOrderRepository) ListStatusChangesBy(ctx context.Context, userID uuid.UUID, offset, limit int) ([]*entities.OrderStatusHistory, error) {
+	args := m.Called(ctx, userID, offset, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entities.OrderStatusHistory), args.Error(1)
+}
+
+// MockUnitOfWork is a mock implementation of UnitOfWork that runs the work without a transaction
+type MockUnitOfWork struct{}
+
+func (m *MockUnitOfWork) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+// MockInventoryRepository is a mock implementation of InventoryRepository
+type MockInventoryRepository struct {
+	mock.Mock
+}
+
+func (m *MockInventoryRepository) Create(ctx context.Context, item *entities.InventoryItem) error {
+	args := m.Called(ctx, item)
+	return args.Error(0)
+}
+
+func (m *MockInventoryRepository) GetByProductID(ctx context.Context, productID uuid.UUID) (*entities.InventoryItem, error) {
+	args := m.Called(ctx, productID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.InventoryItem), args.Error(1)
+}
+
+func (m *MockInventoryRepository) GetByProductIDForUpdate(ctx context.Context, productID uuid.UUID) (*entities.InventoryItem, error) {
+	args := m.Called(ctx, productID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.InventoryItem), args.Error(1)
+}
+
+func (m *MockInventoryRepository) Update(ctx context.Context, item *entities.InventoryItem) error {
+	args := m.Called(ctx, item)
+	return args.Error(0)
+}
+
+// MockStockReservationRepository is a mock implementation of StockReservationRepository
+type MockStockReservationRepository struct {
+	mock.Mock
+}
+
+func (m *MockStockReservationRepository) Create(ctx context.Context, reservation *entities.StockReservation) error {
+	args := m.Called(ctx, reservation)
+	return args.Error(0)
+}
+
+func (m *MockStockReservationRepository) GetActiveByOrderID(ctx context.Context, orderID uuid.UUID) ([]*entities.StockReservation, error) {
+	args := m.Called(ctx, orderID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entities.StockReservation), args.Error(1)
+}
+
+func (m *MockStockReservationRepository) Update(ctx context.Context, reservation *entities.StockReservation) error {
+	args := m.Called(ctx, reservation)
+	return args.Error(0)
+}
+
+// MockAPIKeyRepository is a mock implementation of APIKeyRepository
+type MockAPIKeyRepository struct {
+	mock.Mock
+}
+
+func (m *MockAPIKeyRepository) Create(ctx context.Context, key *entities.APIKey) error {
+	args := m.Called(ctx, key)
+	return args.Error(0)
+}
+
+func (m *MockAPIKeyRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.APIKey, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.APIKey), args.Error(1)
+}
+
+func (m *MockAPIKeyRepository) GetByKeyID(ctx context.Context, keyID string) (*entities.APIKey, error) {
+	args := m.Called(ctx, keyID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.APIKey), args.Error(1)
+}
+
+func (m *MockAPIKeyRepository) Update(ctx context.Context, key *entities.APIKey) error {
+	args := m.Called(ctx, key)
+	return args.Error(0)
+}
+
+func (m *MockAPIKeyRepository) UpdateLastUsed(ctx context.Context, id uuid.UUID, usedAt time.Time) error {
+	args := m.Called(ctx, id, usedAt)
+	return args.Error(0)
+}
+
+func (m *MockAPIKeyRepository) List(ctx context.Context, offset, limit int) ([]*entities.APIKey, error) {
+	args := m.Called(ctx, offset, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entities.APIKey), args.Error(1)
+}
+
+func (m *MockAPIKeyRepository) ListByOwnerID(ctx context.Context, ownerID uuid.UUID, offset, limit int) ([]*entities.APIKey, error) {
+	args := m.Called(ctx, ownerID, offset, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entities.APIKey), args.Error(1)
+}
@@ -0,0 +1,102 @@
+package test
+
+import (
+	"context"
+	"encoding/json"
+	"goclean/internal/application/authz"
+	"goclean/internal/application/bus"
+	"goclean/internal/application/commands"
+	"goclean/internal/application/dto"
+	"goclean/internal/application/queries"
+	"goclean/internal/domain/entities"
+	"goclean/internal/domain/services"
+	"goclean/internal/infrastructure/auth"
+	"goclean/internal/infrastructure/persistence"
+	gormPersistence "goclean/internal/infrastructure/persistence/gorm"
+	"goclean/internal/interfaces/http/handlers"
+	"goclean/pkg/logger"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrderHandler_OwnershipChecks(t *testing.T) {
+	ctx := context.Background()
+	db := newTestDatabase(t)
+	unitOfWork := persistence.NewGormUnitOfWork(db)
+	userRepo := gormPersistence.NewUserRepository(db)
+	orderRepo := persistence.NewOrderGormRepository(db)
+	inventoryService := services.NewInventoryDomainService(unitOfWork, persistence.NewInventoryGormRepository(db), persistence.NewStockReservationGormRepository(db))
+	orderService := services.NewOrderDomainService(unitOfWork, orderRepo, persistence.NewProductGormRepository(db), userRepo, inventoryService)
+
+	authorizer := authz.NewPolicyAuthorizer(authz.DefaultPolicy()...)
+	commandBus := bus.NewCommandBus(bus.Authorization(), bus.Transaction(unitOfWork))
+	queryBus := bus.NewQueryBus(bus.Authorization())
+	commands.NewOrderCommandHandler(orderService, authorizer).Register(commandBus)
+	queries.NewOrderQueryHandler(orderRepo, authorizer).Register(queryBus)
+	orderHandler := handlers.NewOrderHandler(commandBus, queryBus)
+
+	owner := entities.NewUser("jane@example.com", "jane", "Jane", "Doe")
+	require.NoError(t, userRepo.Create(ctx, owner))
+	item := entities.NewOrderItem(uuid.Nil, uuid.New(), 1, entities.Money{Amount: 1500, Currency: entities.DefaultCurrency})
+	order, err := entities.NewOrder(owner.ID, []entities.OrderItem{*item})
+	require.NoError(t, err)
+	require.NoError(t, orderRepo.Create(ctx, order))
+
+	ownerClaims := &auth.UserClaims{UserID: owner.ID.String()}
+	otherClaims := &auth.UserClaims{UserID: uuid.NewString()}
+
+	e := echo.New()
+	e.HTTPErrorHandler = handlers.ErrorHandler(logger.NewDefault())
+
+	// serve runs a request through the order routes as the given user
+	serve := func(method, target string, claims *auth.UserClaims) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		c := e.NewContext(httptest.NewRequest(method, target, nil), rec)
+		c.Set("user_claims", claims)
+		c.SetRequest(c.Request().WithContext(auth.WithUserClaims(c.Request().Context(), claims)))
+		c.SetParamNames("id")
+		c.SetParamValues(order.ID.String())
+
+		var err error
+		if method == http.MethodGet {
+			err = orderHandler.GetOrder(c)
+		} else {
+			err = orderHandler.CancelOrder(c)
+		}
+		if err != nil {
+			e.HTTPErrorHandler(err, c)
+		}
+		return rec
+	}
+
+	orderURL := "/api/v1/orders/" + order.ID.String()
+
+	// Another user's order is reported as not found rather than forbidden
+	assert.Equal(t, http.StatusNotFound, serve(http.MethodGet, orderURL, otherClaims).Code)
+	assert.Equal(t, http.StatusNotFound, serve(http.MethodPost, orderURL+"/cancel", otherClaims).Code)
+
+	stored, err := orderRepo.GetByID(ctx, order.ID)
+	require.NoError(t, err)
+	assert.Equal(t, entities.OrderStatusPending, stored.Status, "a non-owner cannot cancel the order")
+
+	assert.Equal(t, http.StatusOK, serve(http.MethodGet, orderURL, ownerClaims).Code)
+
+	// Cancelling returns the cancelled order
+	rec := serve(http.MethodPost, orderURL+"/cancel", ownerClaims)
+	require.Equal(t, http.StatusOK, rec.Code)
+	var resp dto.OrderAPIResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	require.NotNil(t, resp.Data)
+	assert.Equal(t, order.ID, resp.Data.ID)
+	assert.Equal(t, string(entities.OrderStatusCancelled), resp.Data.Status)
+
+	stored, err = orderRepo.GetByID(ctx, order.ID)
+	require.NoError(t, err)
+	assert.Equal(t, entities.OrderStatusCancelled, stored.Status)
+}
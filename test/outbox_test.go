@@ -0,0 +1,183 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"goclean/internal/domain/entities"
+	"goclean/internal/domain/events"
+	"goclean/internal/infrastructure/outbox"
+	"goclean/pkg/logger"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+var errBrokerUnavailable = errors.New("broker unavailable")
+
+// recordingPublisher records published events and fails with err when it is set
+type recordingPublisher struct {
+	published []entities.DomainEvent
+	err       error
+}
+
+func (p *recordingPublisher) Publish(ctx context.Context, events []entities.DomainEvent) error {
+	if p.err != nil {
+		return p.err
+	}
+	p.published = append(p.published, events...)
+	return nil
+}
+
+// outboxMessages loads the outbox messages raised by an aggregate
+func outboxMessages(t *testing.T, db *gorm.DB, aggregateID uuid.UUID) []outbox.Message {
+	t.Helper()
+	var messages []outbox.Message
+	require.NoError(t, db.Where("aggregate_id = ?", aggregateID).Order("created_at").Find(&messages).Error)
+	return messages
+}
+
+// saveUser stores a new user and its UserCreated event in the outbox
+func saveUser(t *testing.T, db *gorm.DB) *entities.User {
+	t.Helper()
+	user := entities.NewUser(uuid.NewString()+"@example.com", uuid.NewString(), "Jane", "Doe")
+	require.NoError(t, outbox.SaveWithEvents(db, "User", user.ID, &user.AggregateRoot, func(tx *gorm.DB) error {
+		return tx.Create(user).Error
+	}))
+	return user
+}
+
+func TestSaveWithEvents_CommitsEventsWithAggregate(t *testing.T) {
+	db := newTestDatabase(t)
+
+	user := saveUser(t, db)
+
+	var count int64
+	require.NoError(t, db.Model(&entities.User{}).Where("id = ?", user.ID).Count(&count).Error)
+	assert.EqualValues(t, 1, count)
+
+	messages := outboxMessages(t, db, user.ID)
+	require.Len(t, messages, 1)
+	assert.Equal(t, "User", messages[0].AggregateType)
+	assert.Equal(t, "UserCreated", messages[0].EventType)
+	assert.Nil(t, messages[0].DeliveredAt)
+	assert.Empty(t, user.DomainEvents(), "events are cleared once the transaction commits")
+}
+
+func TestSaveWithEvents_RollsBackEventsWithAggregate(t *testing.T) {
+	db := newTestDatabase(t)
+	user := entities.NewUser("jane@example.com", "jane", "Jane", "Doe")
+
+	err := outbox.SaveWithEvents(db, "User", user.ID, &user.AggregateRoot, func(tx *gorm.DB) error {
+		if err := tx.Create(user).Error; err != nil {
+			return err
+		}
+		return errBrokerUnavailable
+	})
+	require.ErrorIs(t, err, errBrokerUnavailable)
+
+	var count int64
+	require.NoError(t, db.Model(&entities.User{}).Where("id = ?", user.ID).Count(&count).Error)
+	assert.Zero(t, count)
+	assert.Empty(t, outboxMessages(t, db, user.ID))
+	assert.Len(t, user.DomainEvents(), 1, "events are kept when the transaction rolls back")
+}
+
+func TestRelay_PublishesAndMarksDelivered(t *testing.T) {
+	ctx := context.Background()
+	db := newTestDatabase(t)
+	user := saveUser(t, db)
+
+	publisher := &recordingPublisher{}
+	relay := outbox.NewRelay(db, events.NewDefaultEventRegistry(), publisher, outbox.RelayConfig{}, logger.NewDefault())
+
+	processed, err := relay.ProcessBatch(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, processed)
+
+	require.Len(t, publisher.published, 1)
+	created, ok := publisher.published[0].(entities.UserCreatedEvent)
+	require.True(t, ok, "the stored payload is decoded to its concrete event type")
+	assert.Equal(t, user.ID, created.UserID)
+
+	messages := outboxMessages(t, db, user.ID)
+	require.Len(t, messages, 1)
+	assert.NotNil(t, messages[0].DeliveredAt)
+	assert.Equal(t, 1, messages[0].Attempts)
+	assert.Empty(t, messages[0].LastError)
+
+	// Delivered messages are not claimed again
+	processed, err = relay.ProcessBatch(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, processed)
+	assert.Len(t, publisher.published, 1)
+}
+
+func TestRelay_BacksOffOnPublishFailure(t *testing.T) {
+	ctx := context.Background()
+	db := newTestDatabase(t)
+	user := saveUser(t, db)
+
+	publisher := &recordingPublisher{err: errBrokerUnavailable}
+	relay := outbox.NewRelay(db, events.NewDefaultEventRegistry(), publisher, outbox.RelayConfig{
+		MaxAttempts: 3,
+		BaseBackoff: time.Hour,
+		MaxBackoff:  90 * time.Minute,
+	}, logger.NewDefault())
+
+	// makeDue moves the retry of every pending message into the past
+	makeDue := func() {
+		require.NoError(t, db.Model(&outbox.Message{}).Where("delivered_at IS NULL").
+			Update("next_attempt_at", time.Now().Add(-time.Second)).Error)
+	}
+
+	tests := []struct {
+		name          string
+		expectBackoff time.Duration
+	}{
+		{"first failure waits the base backoff", time.Hour},
+		{"second failure doubles the backoff up to the maximum", 90 * time.Minute},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			before := time.Now()
+			processed, err := relay.ProcessBatch(ctx)
+			require.NoError(t, err)
+			assert.Equal(t, 1, processed)
+
+			messages := outboxMessages(t, db, user.ID)
+			require.Len(t, messages, 1)
+			assert.Nil(t, messages[0].DeliveredAt)
+			assert.Equal(t, i+1, messages[0].Attempts)
+			assert.Equal(t, errBrokerUnavailable.Error(), messages[0].LastError)
+			assert.WithinRange(t, messages[0].NextAttemptAt, before.Add(tt.expectBackoff), time.Now().Add(tt.expectBackoff))
+
+			// The message is not retried before its backoff has elapsed
+			processed, err = relay.ProcessBatch(ctx)
+			require.NoError(t, err)
+			assert.Zero(t, processed)
+
+			makeDue()
+		})
+	}
+
+	// Messages that reached MaxAttempts are no longer claimed
+	processed, err := relay.ProcessBatch(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, processed)
+	makeDue()
+	processed, err = relay.ProcessBatch(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, processed)
+
+	// The publisher recovering does not resurrect the exhausted message
+	publisher.err = nil
+	processed, err = relay.ProcessBatch(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, processed)
+	assert.Empty(t, publisher.published)
+}
@@ -0,0 +1,186 @@
+package test
+
+import (
+	"context"
+	"encoding/json"
+	"goclean/internal/application/authz"
+	"goclean/internal/application/bus"
+	"goclean/internal/application/commands"
+	"goclean/internal/application/dto"
+	"goclean/internal/domain/entities"
+	"goclean/internal/domain/services"
+	"goclean/internal/infrastructure/outbox"
+	"goclean/internal/infrastructure/persistence"
+	gormPersistence "goclean/internal/infrastructure/persistence/gorm"
+	"goclean/internal/interfaces/http/handlers"
+	"goclean/pkg/logger"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// privacyFixture is a user with a profile, a cancelled and deleted order and an API key
+type privacyFixture struct {
+	db      *gorm.DB
+	service *services.UserPrivacyService
+	user    *entities.User
+	order   *entities.Order
+	apiKey  *entities.APIKey
+}
+
+func newPrivacyFixture(t *testing.T) privacyFixture {
+	t.Helper()
+	ctx := context.Background()
+	db := newTestDatabase(t)
+	userRepo := gormPersistence.NewUserRepository(db)
+	orderRepo := persistence.NewOrderGormRepository(db)
+	apiKeyRepo := persistence.NewAPIKeyGormRepository(db)
+
+	dateOfBirth := time.Date(1990, 4, 1, 0, 0, 0, 0, time.UTC)
+	user := entities.NewUser("jane@example.com", "jane", "Jane", "Doe")
+	user.Profile = entities.NewProfile(user.ID, "Gardener", "https://example.com/jane.png", &dateOfBirth)
+	require.NoError(t, userRepo.Create(ctx, user))
+
+	item := entities.NewOrderItem(uuid.Nil, uuid.New(), 2, entities.Money{Amount: 1500, Currency: entities.DefaultCurrency})
+	order, err := entities.NewOrder(user.ID, []entities.OrderItem{*item})
+	require.NoError(t, err)
+	require.NoError(t, orderRepo.Create(ctx, order))
+	require.NoError(t, order.Cancel(user.ID, "changed my mind"))
+	require.NoError(t, order.Delete("duplicate"))
+	require.NoError(t, orderRepo.Update(ctx, order))
+
+	apiKey, _, err := entities.NewAPIKey("jane's script", user.ID, nil, nil, uuid.New())
+	require.NoError(t, err)
+	require.NoError(t, apiKeyRepo.Create(ctx, apiKey))
+
+	service := services.NewUserPrivacyService(persistence.NewGormUnitOfWork(db), userRepo, persistence.NewProfileGormRepository(db), orderRepo, apiKeyRepo)
+	return privacyFixture{db: db, service: service, user: user, order: order, apiKey: apiKey}
+}
+
+// outboxEventCount counts the outbox messages of an event type raised by an aggregate
+func outboxEventCount(t *testing.T, db *gorm.DB, aggregateID uuid.UUID, eventType string) int64 {
+	t.Helper()
+	var count int64
+	require.NoError(t, db.Model(&outbox.Message{}).Where("aggregate_id = ? AND event_type = ?", aggregateID, eventType).Count(&count).Error)
+	return count
+}
+
+func TestUserPrivacyService_EraseKeepsOrders(t *testing.T) {
+	ctx := context.Background()
+	f := newPrivacyFixture(t)
+
+	require.NoError(t, f.service.Erase(ctx, f.user.ID))
+	assert.ErrorIs(t, f.service.Erase(ctx, f.user.ID), entities.ErrUserErased)
+
+	erased, err := gormPersistence.NewUserRepository(f.db).GetByIDIncludeDeleted(ctx, f.user.ID)
+	require.NoError(t, err)
+	assert.True(t, erased.IsErased())
+	assert.False(t, erased.IsActive)
+	assert.NotContains(t, erased.Email, "jane")
+	assert.NotContains(t, erased.Username, "jane")
+	assert.Empty(t, erased.FirstName)
+	assert.Empty(t, erased.LastName)
+	require.NotNil(t, erased.Profile)
+	assert.Empty(t, erased.Profile.Bio)
+	assert.Empty(t, erased.Profile.Avatar)
+	assert.Nil(t, erased.Profile.DateOfBirth)
+
+	// Orders are kept for accounting; API keys stop working
+	order, err := persistence.NewOrderGormRepository(f.db).GetByIDIncludeDeleted(ctx, f.order.ID)
+	require.NoError(t, err)
+	assert.Equal(t, f.user.ID, order.UserID)
+	assert.Len(t, order.Items, 1)
+	apiKey, err := persistence.NewAPIKeyGormRepository(f.db).GetByID(ctx, f.apiKey.ID)
+	require.NoError(t, err)
+	assert.NotNil(t, apiKey.RevokedAt)
+
+	assert.EqualValues(t, 1, outboxEventCount(t, f.db, f.user.ID, "UserErased"))
+
+	// Signing in again must not bring the personal data back from the token claims
+	unitOfWork := persistence.NewGormUnitOfWork(f.db)
+	userService := services.NewUserDomainService(unitOfWork, gormPersistence.NewUserRepository(f.db), persistence.NewProfileGormRepository(f.db))
+	_, err = userService.ProvisionUser(ctx, f.user.ID, "jane@example.com", "jane", "Jane", "Doe")
+	assert.ErrorIs(t, err, entities.ErrUserErased)
+
+	// Nor can the erased user place orders
+	inventoryService := services.NewInventoryDomainService(unitOfWork, persistence.NewInventoryGormRepository(f.db), persistence.NewStockReservationGormRepository(f.db))
+	orderService := services.NewOrderDomainService(unitOfWork, persistence.NewOrderGormRepository(f.db), persistence.NewProductGormRepository(f.db), gormPersistence.NewUserRepository(f.db), inventoryService)
+	item := entities.NewOrderItem(uuid.Nil, uuid.New(), 1, entities.Money{})
+	_, err = orderService.CreateOrder(ctx, f.user.ID, []entities.OrderItem{*item})
+	assert.ErrorIs(t, err, entities.ErrUserErased)
+}
+
+// recordingRevoker records the users whose sessions were revoked
+type recordingRevoker struct {
+	revoked []string
+	err     error
+}
+
+func (r *recordingRevoker) RevokeUserSessions(ctx context.Context, userID string) error {
+	r.revoked = append(r.revoked, userID)
+	return r.err
+}
+
+func TestPrivacyCommandHandler_EraseRevokesSessions(t *testing.T) {
+	f := newPrivacyFixture(t)
+	revoker := &recordingRevoker{}
+	handler := commands.NewPrivacyCommandHandler(f.service, revoker, authz.NewPolicyAuthorizer(authz.DefaultPolicy()...))
+	admin := authz.NewPrincipal(uuid.New().String(), []string{"admin"}, map[string]string{authz.AttrAuthMethod: authz.AuthMethodToken})
+
+	require.NoError(t, handler.HandleEraseUserData(authz.WithPrincipal(context.Background(), admin), commands.EraseUserDataCommand{UserID: f.user.ID}))
+	assert.Equal(t, []string{f.user.ID.String()}, revoker.revoked)
+}
+
+func TestPrivacyHandler_ExportUserData(t *testing.T) {
+	f := newPrivacyFixture(t)
+
+	commandBus := bus.NewCommandBus()
+	commands.NewPrivacyCommandHandler(f.service, &recordingRevoker{}, authz.NewPolicyAuthorizer(authz.DefaultPolicy()...)).Register(commandBus)
+	privacyHandler := handlers.NewPrivacyHandler(commandBus)
+
+	e := echo.New()
+	e.HTTPErrorHandler = handlers.ErrorHandler(logger.NewDefault())
+	e.POST("/api/v1/admin/users/:id/export", privacyHandler.ExportUserData)
+	export := func(roles ...string) *httptest.ResponseRecorder {
+		principal := authz.NewPrincipal(uuid.New().String(), roles, nil)
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/admin/users/"+f.user.ID.String()+"/export", nil)
+		req = req.WithContext(authz.WithPrincipal(req.Context(), principal))
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+
+	assert.Equal(t, http.StatusForbidden, export().Code)
+
+	rec := export("admin")
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Header().Get(echo.HeaderContentDisposition), "attachment")
+
+	var archive dto.UserDataExportDTO
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &archive))
+	assert.Equal(t, "jane@example.com", archive.User.Email)
+	require.NotNil(t, archive.User.Profile)
+	assert.Equal(t, "Gardener", archive.User.Profile.Bio)
+
+	// Deleted orders are still stored, so they are exported too
+	require.Len(t, archive.Orders, 1)
+	assert.Equal(t, f.order.ID, archive.Orders[0].ID)
+	assert.Len(t, archive.Orders[0].Items, 1)
+	require.Len(t, archive.APIKeys, 1)
+	assert.Equal(t, f.apiKey.ID, archive.APIKeys[0].ID)
+
+	// Placing and cancelling the order, oldest first
+	require.Len(t, archive.AuditEntries, 2)
+	assert.Equal(t, f.order.ID, archive.AuditEntries[1].OrderID)
+	assert.Equal(t, "pending", archive.AuditEntries[0].ToStatus)
+	assert.Equal(t, "cancelled", archive.AuditEntries[1].ToStatus)
+
+	assert.EqualValues(t, 1, outboxEventCount(t, f.db, f.user.ID, "UserDataExported"))
+}
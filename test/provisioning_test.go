@@ -0,0 +1,119 @@
+package test
+
+import (
+	"context"
+	"goclean/internal/application/authz"
+	"goclean/internal/application/bus"
+	"goclean/internal/application/commands"
+	"goclean/internal/domain/entities"
+	"goclean/internal/domain/services"
+	"goclean/internal/infrastructure/auth"
+	"goclean/internal/infrastructure/persistence"
+	gormPersistence "goclean/internal/infrastructure/persistence/gorm"
+	"goclean/internal/interfaces/grpc/interceptors"
+	"goclean/internal/interfaces/http/middleware"
+	"goclean/pkg/logger"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// failingProvisioner returns a provisioner whose ProvisionUserCommand handler fails with err.
+// Failed provisions are never cached, so it needs no account checker.
+func failingProvisioner(err error) *commands.UserProvisioner {
+	commandBus := bus.NewCommandBus()
+	bus.RegisterCommand(commandBus, func(ctx context.Context, cmd commands.ProvisionUserCommand) error {
+		return err
+	})
+	return commands.NewUserProvisioner(commandBus, nil, time.Minute)
+}
+
+func provisionedClaims() *auth.UserClaims {
+	return &auth.UserClaims{UserID: uuid.New().String(), Email: "jane@example.com", Username: "jane"}
+}
+
+func TestProvisioningMiddleware_DeniesClosedAccounts(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		expectCode int
+	}{
+		{"deleted user", services.ErrUserDeleted, http.StatusForbidden},
+		{"erased user", entities.ErrUserErased, http.StatusForbidden},
+		{"other failures do not block the request", services.ErrUserAlreadyExists, http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provisioning := middleware.NewProvisioningMiddleware(failingProvisioner(tt.err), logger.NewDefault())
+			handler := provisioning.Provision(func(c echo.Context) error {
+				return c.NoContent(http.StatusOK)
+			})
+
+			rec := httptest.NewRecorder()
+			c := echo.New().NewContext(httptest.NewRequest(http.MethodGet, "/api/v1/orders", nil), rec)
+			c.Set("user_claims", provisionedClaims())
+			if err := handler(c); err != nil {
+				var httpErr *echo.HTTPError
+				require.ErrorAs(t, err, &httpErr)
+				assert.Equal(t, tt.expectCode, httpErr.Code)
+				return
+			}
+			assert.Equal(t, tt.expectCode, rec.Code)
+		})
+	}
+}
+
+func TestProvisioningInterceptor_DeniesClosedAccounts(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		expectCode codes.Code
+	}{
+		{"deleted user", services.ErrUserDeleted, codes.PermissionDenied},
+		{"erased user", entities.ErrUserErased, codes.PermissionDenied},
+		{"other failures do not block the call", services.ErrUserAlreadyExists, codes.OK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			interceptor := interceptors.NewProvisioningInterceptor(failingProvisioner(tt.err), logger.NewDefault()).Unary()
+			ctx := auth.WithUserClaims(context.Background(), provisionedClaims())
+
+			_, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: "/goclean.v1.OrderService/ListOrders"}, func(ctx context.Context, req interface{}) (interface{}, error) {
+				return nil, nil
+			})
+			assert.Equal(t, tt.expectCode, status.Code(err))
+		})
+	}
+}
+
+func TestUserProvisioner_DeniesUserDeletedAfterSync(t *testing.T) {
+	ctx := context.Background()
+	db := newTestDatabase(t)
+	userRepo := gormPersistence.NewUserRepository(db)
+	userService := services.NewUserDomainService(persistence.NewGormUnitOfWork(db), userRepo, persistence.NewProfileGormRepository(db))
+
+	commandBus := bus.NewCommandBus()
+	commands.NewUserCommandHandler(userService, authz.NewPolicyAuthorizer(authz.DefaultPolicy()...)).Register(commandBus)
+	provisioner := commands.NewUserProvisioner(commandBus, userService, time.Hour)
+
+	claims := provisionedClaims()
+	cmd := commands.ProvisionUserCommand{ID: uuid.MustParse(claims.UserID), Email: claims.Email, Username: claims.Username}
+	require.NoError(t, provisioner.Provision(ctx, cmd))
+	require.NoError(t, provisioner.Provision(ctx, cmd))
+
+	// The identity is still cached, but the closed account is denied at once
+	require.NoError(t, userRepo.SoftDelete(ctx, cmd.ID))
+	assert.ErrorIs(t, provisioner.Provision(ctx, cmd), services.ErrUserDeleted)
+	assert.ErrorIs(t, provisioner.Provision(ctx, cmd), services.ErrUserDeleted)
+}
@@ -0,0 +1,319 @@
+package test
+
+import (
+	"context"
+	"goclean/internal/domain/entities"
+	"goclean/internal/domain/repositories"
+	"goclean/internal/domain/services"
+	"goclean/internal/infrastructure/outbox"
+	"goclean/internal/infrastructure/persistence"
+	gormPersistence "goclean/internal/infrastructure/persistence/gorm"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/logger"
+	"gorm.io/gorm/schema"
+)
+
+// sqliteDialector runs the schema on SQLite, which needs function defaults such as
+// gen_random_uuid() in parentheses
+type sqliteDialector struct{ sqlite.Dialector }
+
+func (d sqliteDialector) Migrator(db *gorm.DB) gorm.Migrator {
+	return sqliteMigrator{d.Dialector.Migrator(db).(sqlite.Migrator)}
+}
+
+type sqliteMigrator struct{ sqlite.Migrator }
+
+func (m sqliteMigrator) FullDataTypeOf(field *schema.Field) clause.Expr {
+	if strings.HasSuffix(field.DefaultValue, "()") {
+		withDefault := *field
+		withDefault.DefaultValue = "(" + field.DefaultValue + ")"
+		return m.Migrator.FullDataTypeOf(&withDefault)
+	}
+	return m.Migrator.FullDataTypeOf(field)
+}
+
+// newTestDatabase opens a migrated in-memory database for one test
+func newTestDatabase(t *testing.T) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(sqliteDialector{sqlite.Dialector{DSN: "file::memory:"}}, &gorm.Config{
+		Logger:         logger.Default.LogMode(logger.Silent),
+		TranslateError: true,
+	})
+	require.NoError(t, err)
+
+	// Every connection to :memory: is a database of its own
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	sqlDB.SetMaxOpenConns(1)
+	t.Cleanup(func() { sqlDB.Close() })
+
+	require.NoError(t, persistence.MigrateDatabase(db))
+	return db
+}
+
+func TestUserRepository_SoftDeleteModes(t *testing.T) {
+	ctx := context.Background()
+	db := newTestDatabase(t)
+	userRepo := gormPersistence.NewUserRepository(db)
+	profileRepo := persistence.NewProfileGormRepository(db)
+
+	deleted := entities.NewUser("jane@example.com", "jane", "Jane", "Doe")
+	deleted.Profile = entities.NewProfile(deleted.ID, "Gardener", "", nil)
+	active := entities.NewUser("john@example.com", "john", "John", "Doe")
+	require.NoError(t, userRepo.Create(ctx, deleted))
+	require.NoError(t, userRepo.Create(ctx, active))
+
+	require.NoError(t, deleted.Delete("requested by customer"))
+	require.NoError(t, userRepo.Update(ctx, deleted))
+
+	// Excluding deleted users is the default
+	_, err := userRepo.GetByID(ctx, deleted.ID)
+	assert.ErrorIs(t, err, repositories.ErrNotFound)
+	_, err = userRepo.GetByEmail(ctx, deleted.Email)
+	assert.ErrorIs(t, err, repositories.ErrNotFound)
+	users, err := userRepo.List(ctx, 0, 10)
+	require.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{active.ID}, userIDs(users))
+
+	// Including deleted users
+	found, err := userRepo.GetByIDIncludeDeleted(ctx, deleted.ID)
+	require.NoError(t, err)
+	_, reason := found.DeletionInfo()
+	assert.Equal(t, "requested by customer", reason)
+	users, err = userRepo.ListIncludeDeleted(ctx, 0, 10)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []uuid.UUID{deleted.ID, active.ID}, userIDs(users))
+
+	// Only deleted users
+	users, err = userRepo.ListDeleted(ctx, 0, 10)
+	require.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{deleted.ID}, userIDs(users))
+
+	// The profile is deleted along with its user
+	_, err = profileRepo.GetByUserID(ctx, deleted.ID)
+	assert.ErrorIs(t, err, repositories.ErrNotFound)
+	profiles, err := profileRepo.ListDeleted(ctx, 0, 10)
+	require.NoError(t, err)
+	require.Len(t, profiles, 1)
+	_, reason = profiles[0].DeletionInfo()
+	assert.Equal(t, "requested by customer", reason)
+
+	// ... and restored along with it
+	require.NoError(t, found.Restore())
+	require.NoError(t, userRepo.Update(ctx, found))
+	restored, err := userRepo.GetByID(ctx, deleted.ID)
+	require.NoError(t, err)
+	require.NotNil(t, restored.Profile)
+	assert.False(t, restored.Profile.IsDeleted())
+}
+
+func TestUserRepository_RestoreKeepsSeparatelyDeletedProfile(t *testing.T) {
+	ctx := context.Background()
+	db := newTestDatabase(t)
+	userRepo := gormPersistence.NewUserRepository(db)
+	profileRepo := persistence.NewProfileGormRepository(db)
+
+	user := entities.NewUser("jane@example.com", "jane", "Jane", "Doe")
+	user.Profile = entities.NewProfile(user.ID, "Gardener", "", nil)
+	require.NoError(t, userRepo.Create(ctx, user))
+
+	require.NoError(t, user.Profile.Delete("offensive bio"))
+	require.NoError(t, profileRepo.Update(ctx, user.Profile))
+	require.NoError(t, userRepo.SoftDelete(ctx, user.ID))
+	require.NoError(t, userRepo.Restore(ctx, user.ID))
+
+	_, err := userRepo.GetByID(ctx, user.ID)
+	require.NoError(t, err)
+	profile, err := profileRepo.GetByIDIncludeDeleted(ctx, user.Profile.ID)
+	require.NoError(t, err)
+	_, reason := profile.DeletionInfo()
+	assert.Equal(t, "offensive bio", reason)
+}
+
+func TestOrderRepository_SoftDeleteCascadesToItems(t *testing.T) {
+	ctx := context.Background()
+	db := newTestDatabase(t)
+	orderRepo := persistence.NewOrderGormRepository(db)
+
+	item := entities.NewOrderItem(uuid.Nil, uuid.New(), 2, entities.Money{Amount: 1500, Currency: entities.DefaultCurrency})
+	order, err := entities.NewOrder(uuid.New(), []entities.OrderItem{*item})
+	require.NoError(t, err)
+	require.NoError(t, orderRepo.Create(ctx, order))
+
+	require.NoError(t, order.Cancel(order.UserID, "changed my mind"))
+	require.NoError(t, order.Delete("duplicate"))
+	require.NoError(t, orderRepo.Update(ctx, order))
+
+	_, err = orderRepo.GetByID(ctx, order.ID)
+	assert.ErrorIs(t, err, repositories.ErrNotFound)
+	var liveItems int64
+	require.NoError(t, db.Model(&entities.OrderItem{}).Where("order_id = ?", order.ID).Count(&liveItems).Error)
+	assert.Zero(t, liveItems)
+
+	// Including deleted orders loads their deleted items too
+	found, err := orderRepo.GetByIDIncludeDeleted(ctx, order.ID)
+	require.NoError(t, err)
+	assert.Len(t, found.Items, 1)
+	orders, err := orderRepo.ListDeleted(ctx, 0, 10)
+	require.NoError(t, err)
+	require.Len(t, orders, 1)
+
+	require.NoError(t, found.Restore())
+	require.NoError(t, orderRepo.Update(ctx, found))
+	restored, err := orderRepo.GetByID(ctx, order.ID)
+	require.NoError(t, err)
+	assert.Len(t, restored.Items, 1)
+}
+
+func TestOrderRepository_PurgeRemovesDependents(t *testing.T) {
+	ctx := context.Background()
+	db := newTestDatabase(t)
+	orderRepo := persistence.NewOrderGormRepository(db)
+	service := services.NewOrderSoftDeleteService(persistence.NewGormUnitOfWork(db), orderRepo)
+
+	item := entities.NewOrderItem(uuid.Nil, uuid.New(), 2, entities.Money{Amount: 1500, Currency: entities.DefaultCurrency})
+	order, err := entities.NewOrder(uuid.New(), []entities.OrderItem{*item})
+	require.NoError(t, err)
+	require.NoError(t, orderRepo.Create(ctx, order))
+	require.NoError(t, db.Create(entities.NewStockReservation(order.ID, item.ProductID, 2)).Error)
+
+	require.NoError(t, order.Cancel(order.UserID, "changed my mind"))
+	require.NoError(t, order.Delete("duplicate"))
+	order.DeletedAt.Time = time.Now().Add(-100 * 24 * time.Hour)
+	require.NoError(t, orderRepo.Update(ctx, order))
+
+	purged, err := service.Purge(ctx, time.Now().Add(-90*24*time.Hour), false)
+	require.NoError(t, err)
+	require.Len(t, purged, 1)
+
+	// No rows of the order are left behind, whether or not the schema cascades
+	for _, model := range []interface{}{&entities.OrderItem{}, &entities.OrderStatusHistory{}, &entities.StockReservation{}} {
+		var remaining int64
+		require.NoError(t, db.Unscoped().Model(model).Where("order_id = ?", order.ID).Count(&remaining).Error)
+		assert.Zero(t, remaining, "%T", model)
+	}
+	_, err = orderRepo.GetByIDIncludeDeleted(ctx, order.ID)
+	assert.ErrorIs(t, err, repositories.ErrNotFound)
+}
+
+func TestProductRepository_SoftDeleteKeepsRow(t *testing.T) {
+	ctx := context.Background()
+	db := newTestDatabase(t)
+	productRepo := persistence.NewProductGormRepository(db)
+
+	product := entities.NewProduct("Keyboard", "", "KB-1", "accessories", entities.Money{Amount: 4999, Currency: entities.DefaultCurrency}, uuid.New())
+	require.NoError(t, productRepo.Create(ctx, product))
+
+	require.NoError(t, productRepo.SoftDelete(ctx, product.ID))
+	_, err := productRepo.GetByID(ctx, product.ID)
+	assert.ErrorIs(t, err, repositories.ErrNotFound)
+
+	// Deleting an unknown or already deleted product reports it as not found
+	assert.ErrorIs(t, productRepo.SoftDelete(ctx, uuid.New()), repositories.ErrNotFound)
+	assert.ErrorIs(t, productRepo.SoftDelete(ctx, product.ID), repositories.ErrNotFound)
+
+	products, err := productRepo.List(ctx, 0, 10)
+	require.NoError(t, err)
+	assert.Empty(t, products)
+	products, err = productRepo.ListDeleted(ctx, 0, 10)
+	require.NoError(t, err)
+	assert.Len(t, products, 1)
+
+	require.NoError(t, productRepo.Restore(ctx, product.ID))
+	_, err = productRepo.GetByID(ctx, product.ID)
+	assert.NoError(t, err)
+}
+
+func TestProductRepository_PurgeRemovesExpiredRows(t *testing.T) {
+	ctx := context.Background()
+	db := newTestDatabase(t)
+	productRepo := persistence.NewProductGormRepository(db)
+	service := services.NewProductSoftDeleteService(persistence.NewGormUnitOfWork(db), productRepo)
+
+	expired := entities.NewProduct("Keyboard", "", "KB-1", "accessories", entities.Money{Amount: 4999, Currency: entities.DefaultCurrency}, uuid.New())
+	recent := entities.NewProduct("Mouse", "", "MS-1", "accessories", entities.Money{Amount: 1999, Currency: entities.DefaultCurrency}, uuid.New())
+	for _, product := range []*entities.Product{expired, recent} {
+		require.NoError(t, productRepo.Create(ctx, product))
+		require.NoError(t, db.Create(entities.NewInventoryItem(product.ID, 5)).Error)
+		require.NoError(t, db.Create(entities.NewStockReservation(uuid.New(), product.ID, 1)).Error)
+		require.NoError(t, product.Delete("discontinued"))
+	}
+	expired.DeletedAt.Time = time.Now().Add(-100 * 24 * time.Hour)
+	require.NoError(t, productRepo.Update(ctx, expired))
+	require.NoError(t, productRepo.Update(ctx, recent))
+	cutoff := time.Now().Add(-90 * 24 * time.Hour)
+
+	// A dry run changes nothing
+	purged, err := service.Purge(ctx, cutoff, true)
+	require.NoError(t, err)
+	require.Len(t, purged, 1)
+	assert.Equal(t, expired.ID, purged[0].ID)
+	_, err = productRepo.GetByIDIncludeDeleted(ctx, expired.ID)
+	require.NoError(t, err)
+
+	purged, err = service.Purge(ctx, cutoff, false)
+	require.NoError(t, err)
+	require.Len(t, purged, 1)
+	_, err = productRepo.GetByIDIncludeDeleted(ctx, expired.ID)
+	assert.ErrorIs(t, err, repositories.ErrNotFound)
+	_, err = productRepo.GetByIDIncludeDeleted(ctx, recent.ID)
+	assert.NoError(t, err)
+
+	// Inventory and reservations are purged with their product
+	for _, model := range []interface{}{&entities.InventoryItem{}, &entities.StockReservation{}} {
+		var remaining []uuid.UUID
+		require.NoError(t, db.Unscoped().Model(model).Distinct().Pluck("product_id", &remaining).Error)
+		assert.Equal(t, []uuid.UUID{recent.ID}, remaining)
+	}
+
+	// The purge is published through the outbox
+	var messages []outbox.Message
+	require.NoError(t, db.Where("aggregate_id = ? AND event_type = ?", expired.ID, "ProductPurged").Find(&messages).Error)
+	assert.Len(t, messages, 1)
+}
+
+func TestMigrateDatabase_BackfillsInventory(t *testing.T) {
+	ctx := context.Background()
+	db := newTestDatabase(t)
+	inventoryService := services.NewInventoryDomainService(persistence.NewGormUnitOfWork(db), persistence.NewInventoryGormRepository(db), persistence.NewStockReservationGormRepository(db))
+
+	// A product from before stock was tracked has no inventory record
+	product := entities.NewProduct("Keyboard", "", "KB-1", "accessories", entities.Money{Amount: 4999, Currency: entities.DefaultCurrency}, uuid.New())
+	require.NoError(t, persistence.NewProductGormRepository(db).Create(ctx, product))
+	_, err := inventoryService.GetStock(ctx, product.ID)
+	assert.ErrorIs(t, err, services.ErrInventoryNotFound)
+
+	require.NoError(t, persistence.MigrateDatabase(db))
+	require.NoError(t, persistence.MigrateDatabase(db))
+	var count int64
+	require.NoError(t, db.Model(&entities.InventoryItem{}).Where("product_id = ?", product.ID).Count(&count).Error)
+	assert.EqualValues(t, 1, count)
+
+	item, err := inventoryService.Restock(ctx, product.ID, 5)
+	require.NoError(t, err)
+	assert.Equal(t, 5, item.Available())
+	item, err = inventoryService.GetStock(ctx, product.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 5, item.OnHand)
+
+	_, err = inventoryService.Restock(ctx, uuid.New(), 5)
+	assert.ErrorIs(t, err, services.ErrInventoryNotFound)
+}
+
+// userIDs returns the IDs of users
+func userIDs(users []*entities.User) []uuid.UUID {
+	ids := make([]uuid.UUID, len(users))
+	for i, user := range users {
+		ids[i] = user.ID
+	}
+	return ids
+}
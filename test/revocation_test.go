@@ -0,0 +1,118 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"goclean/internal/infrastructure/auth"
+	"goclean/internal/interfaces/http/middleware"
+	"goclean/pkg/logger"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryRevocationStore is an in-memory RevocationStore that can simulate outages
+type memoryRevocationStore struct {
+	tokens   map[string]bool
+	sessions map[string]bool
+	users    map[string]time.Time
+	err      error
+}
+
+func newMemoryRevocationStore() *memoryRevocationStore {
+	return &memoryRevocationStore{
+		tokens:   make(map[string]bool),
+		sessions: make(map[string]bool),
+		users:    make(map[string]time.Time),
+	}
+}
+
+func (s *memoryRevocationStore) RevokeToken(ctx context.Context, jti string, ttl time.Duration) error {
+	s.tokens[jti] = true
+	return s.err
+}
+
+func (s *memoryRevocationStore) RevokeSession(ctx context.Context, sessionID string, ttl time.Duration) error {
+	s.sessions[sessionID] = true
+	return s.err
+}
+
+func (s *memoryRevocationStore) RevokeUser(ctx context.Context, userID string, revokedAt time.Time, ttl time.Duration) error {
+	s.users[userID] = revokedAt
+	return s.err
+}
+
+func (s *memoryRevocationStore) IsRevoked(ctx context.Context, claims *auth.UserClaims) (bool, error) {
+	if s.err != nil {
+		return false, s.err
+	}
+	if revokedAt, ok := s.users[claims.UserID]; ok && !claims.IssuedAt.After(revokedAt) {
+		return true, nil
+	}
+	return s.tokens[claims.ID] || s.sessions[claims.Session()], nil
+}
+
+func authenticate(t *testing.T, authMiddleware *middleware.AuthMiddleware, token string) int {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/orders", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	rec := httptest.NewRecorder()
+
+	err := authMiddleware.Authenticate(func(c echo.Context) error {
+		return c.NoContent(http.StatusOK)
+	})(e.NewContext(req, rec))
+	if err != nil {
+		var httpErr *echo.HTTPError
+		require.ErrorAs(t, err, &httpErr)
+		return httpErr.Code
+	}
+	return rec.Code
+}
+
+func TestAuthMiddleware_Revocation(t *testing.T) {
+	provider := newLocalProvider(t)
+	store := newMemoryRevocationStore()
+	revocations := auth.NewRevocationService(store, provider, auth.RevocationConfig{}, logger.NewDefault())
+	authMiddleware := middleware.NewAuthMiddleware(provider, revocations, nil, nil)
+
+	tokens, err := provider.IssueToken(auth.UserClaims{UserID: "5d0a6bd2-0ad5-4d1c-9a4e-6f3f1d2b7c11"})
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, authenticate(t, authMiddleware, tokens.AccessToken))
+
+	// Logging out revokes every access token of the validated token's session
+	claims, err := provider.ValidateToken(context.Background(), tokens.AccessToken)
+	require.NoError(t, err)
+	require.NoError(t, revocations.RevokeSession(context.Background(), claims))
+	assert.Equal(t, http.StatusUnauthorized, authenticate(t, authMiddleware, tokens.AccessToken))
+
+	// Refreshing keeps the session, so the new access token is rejected as well
+	refreshed, err := provider.RefreshToken(context.Background(), tokens.RefreshToken)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusUnauthorized, authenticate(t, authMiddleware, refreshed.AccessToken))
+
+	// Revoking a user rejects tokens issued before the revocation
+	other, err := provider.IssueToken(auth.UserClaims{UserID: "0f6f3f0e-8a4c-4b8e-9d39-2d0c1b6f5a77"})
+	require.NoError(t, err)
+	require.NoError(t, revocations.RevokeUserSessions(context.Background(), "0f6f3f0e-8a4c-4b8e-9d39-2d0c1b6f5a77"))
+	assert.Equal(t, http.StatusUnauthorized, authenticate(t, authMiddleware, other.AccessToken))
+}
+
+func TestAuthMiddleware_RevocationStoreUnavailable(t *testing.T) {
+	provider := newLocalProvider(t)
+	tokens, err := provider.IssueToken(auth.UserClaims{UserID: "5d0a6bd2-0ad5-4d1c-9a4e-6f3f1d2b7c11"})
+	require.NoError(t, err)
+
+	store := newMemoryRevocationStore()
+	store.err = errors.New("redis: connection refused")
+
+	failClosed := auth.NewRevocationService(store, provider, auth.RevocationConfig{FailOpen: false}, logger.NewDefault())
+	assert.Equal(t, http.StatusServiceUnavailable, authenticate(t, middleware.NewAuthMiddleware(provider, failClosed, nil, nil), tokens.AccessToken))
+
+	failOpen := auth.NewRevocationService(store, provider, auth.RevocationConfig{FailOpen: true}, logger.NewDefault())
+	assert.Equal(t, http.StatusOK, authenticate(t, middleware.NewAuthMiddleware(provider, failOpen, nil, nil), tokens.AccessToken))
+}
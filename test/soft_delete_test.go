@@ -0,0 +1,219 @@
+package test
+
+import (
+	"context"
+	"encoding/json"
+	"goclean/internal/application/authz"
+	"goclean/internal/application/bus"
+	"goclean/internal/application/commands"
+	"goclean/internal/application/dto"
+	"goclean/internal/application/queries"
+	"goclean/internal/application/validation"
+	"goclean/internal/domain/entities"
+	"goclean/internal/domain/services"
+	"goclean/internal/interfaces/http/handlers"
+	"goclean/pkg/logger"
+	"goclean/test/mocks"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSoftDeleteService_DeleteAndRestore(t *testing.T) {
+	user := entities.NewUser("jane@example.com", "jane", "Jane", "Doe")
+	user.ClearDomainEvents()
+
+	userRepo := &mocks.MockUserRepository{}
+	userRepo.On("GetByIDIncludeDeleted", mock.Anything, user.ID).Return(user, nil)
+	userRepo.On("Update", mock.Anything, user).Return(nil).Twice()
+	service := services.NewUserSoftDeleteService(&mocks.MockUnitOfWork{}, userRepo)
+
+	require.NoError(t, service.Delete(context.Background(), user.ID, "requested by customer"))
+	deletedAt, reason := user.DeletionInfo()
+	assert.NotNil(t, deletedAt)
+	assert.Equal(t, "requested by customer", reason)
+	assert.False(t, user.IsActive)
+	assert.ErrorIs(t, service.Delete(context.Background(), user.ID, ""), entities.ErrAlreadyDeleted)
+
+	require.NoError(t, service.Restore(context.Background(), user.ID))
+	deletedAt, reason = user.DeletionInfo()
+	assert.Nil(t, deletedAt)
+	assert.Empty(t, reason)
+	assert.True(t, user.IsActive)
+	assert.ErrorIs(t, service.Restore(context.Background(), user.ID), entities.ErrNotDeleted)
+
+	events := user.DomainEvents()
+	require.Len(t, events, 2)
+	assert.Equal(t, "requested by customer", events[0].(entities.UserDeletedEvent).Reason)
+	assert.Equal(t, "UserRestored", events[1].EventType())
+	userRepo.AssertExpectations(t)
+}
+
+func TestSoftDeleteService_OnlyDeletesFinalOrders(t *testing.T) {
+	item := entities.NewOrderItem(uuid.Nil, uuid.New(), 1, entities.Money{Amount: 1500, Currency: entities.DefaultCurrency})
+	order, err := entities.NewOrder(uuid.New(), []entities.OrderItem{*item})
+	require.NoError(t, err)
+
+	orderRepo := &mocks.MockOrderRepository{}
+	orderRepo.On("GetByIDIncludeDeleted", mock.Anything, order.ID).Return(order, nil)
+	orderRepo.On("Update", mock.Anything, order).Return(nil).Once()
+	service := services.NewOrderSoftDeleteService(&mocks.MockUnitOfWork{}, orderRepo)
+
+	assert.ErrorIs(t, service.Delete(context.Background(), order.ID, "duplicate"), entities.ErrOrderNotFinal)
+	assert.False(t, order.IsDeleted())
+
+	require.NoError(t, order.Cancel(order.UserID, "changed my mind"))
+	require.NoError(t, service.Delete(context.Background(), order.ID, "duplicate"))
+	assert.True(t, order.IsDeleted())
+	orderRepo.AssertExpectations(t)
+}
+
+func TestSoftDeleteCommandHandler_IsAdminOnly(t *testing.T) {
+	product := entities.NewProduct("Keyboard", "", "KB-1", "accessories", entities.Money{Amount: 4999, Currency: entities.DefaultCurrency}, uuid.New())
+	productRepo := &mocks.MockProductRepository{}
+	productRepo.On("GetByIDIncludeDeleted", mock.Anything, product.ID).Return(product, nil)
+	productRepo.On("Update", mock.Anything, product).Return(nil).Once()
+
+	var names []string
+	commandBus := bus.NewCommandBus(recordingMiddleware("bus", &names), bus.Validation(validation.New()))
+	service := services.NewProductSoftDeleteService(&mocks.MockUnitOfWork{}, productRepo)
+	commands.NewSoftDeleteCommandHandler(service, authz.NewPolicyAuthorizer(authz.DefaultPolicy()...), authz.ResourceProduct).Register(commandBus)
+
+	cmd := commands.SoftDeleteProductCommand{ID: product.ID, Reason: "discontinued"}
+	user := authz.WithPrincipal(context.Background(), authz.NewPrincipal(uuid.New().String(), nil, nil))
+	assert.ErrorIs(t, commandBus.Dispatch(user, cmd), authz.ErrForbidden)
+
+	admin := authz.WithPrincipal(context.Background(), authz.NewPrincipal(uuid.New().String(), []string{"admin"}, nil))
+	require.NoError(t, commandBus.Dispatch(admin, cmd))
+	assert.True(t, product.IsDeleted())
+	assert.Equal(t, "bus>SoftDeleteCommand[*entities.Product]", names[0])
+	productRepo.AssertExpectations(t)
+}
+
+func TestRetentionJob_PurgesExpiredEntities(t *testing.T) {
+	user := entities.NewUser("jane@example.com", "jane", "Jane", "Doe")
+	require.NoError(t, user.Delete("requested by customer"))
+	user.ClearDomainEvents()
+
+	// Only users have a retention period, so no other repository is consulted
+	expired := mock.MatchedBy(func(before time.Time) bool {
+		return time.Since(before) > 29*24*time.Hour && time.Since(before) < 31*24*time.Hour
+	})
+	userRepo := &mocks.MockUserRepository{}
+	userRepo.On("ListDeletedBefore", mock.Anything, expired, 0, 100).Return([]*entities.User{user}, nil)
+	userRepo.On("Update", mock.Anything, user).Return(nil).Once()
+	userRepo.On("Delete", mock.Anything, user.ID).Return(nil).Once()
+
+	commandBus := bus.NewCommandBus(bus.Authorization(), bus.Validation(validation.New()))
+	authorizer := authz.NewPolicyAuthorizer(authz.DefaultPolicy()...)
+	commands.NewSoftDeleteCommandHandler(services.NewUserSoftDeleteService(&mocks.MockUnitOfWork{}, userRepo), authorizer, authz.ResourceUser).Register(commandBus)
+	commands.NewSoftDeleteCommandHandler(services.NewProductSoftDeleteService(&mocks.MockUnitOfWork{}, &mocks.MockProductRepository{}), authorizer, authz.ResourceProduct).Register(commandBus)
+	job := commands.NewRetentionJob(commandBus, commands.RetentionPolicy{Users: 30 * 24 * time.Hour}, logger.NewDefault())
+
+	// A dry run reports the user without purging it
+	purged, err := job.Purge(context.Background(), true)
+	require.NoError(t, err)
+	require.Len(t, purged, 1)
+	assert.Equal(t, commands.PurgedEntity{ResourceType: authz.ResourceUser, ID: user.ID, DeletedAt: user.DeletedAt.Time, Reason: "requested by customer"}, purged[0])
+	assert.Empty(t, user.DomainEvents())
+	userRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+
+	purged, err = job.Purge(context.Background(), false)
+	require.NoError(t, err)
+	assert.Len(t, purged, 1)
+	require.Len(t, user.DomainEvents(), 1)
+	assert.Equal(t, "UserPurged", user.DomainEvents()[0].EventType())
+	userRepo.AssertExpectations(t)
+
+	// Purging is reserved for the system principal
+	admin := authz.WithPrincipal(context.Background(), authz.NewPrincipal(uuid.New().String(), []string{"admin"}, nil))
+	_, err = bus.DispatchWithResult[[]*entities.User](admin, commandBus, commands.PurgeDeletedUsersCommand{DeletedBefore: time.Now()})
+	assert.ErrorIs(t, err, authz.ErrForbidden)
+}
+
+func TestSoftDeleteHandler_ListDeleted(t *testing.T) {
+	product := entities.NewProduct("Keyboard", "", "KB-1", "accessories", entities.Money{Amount: 4999, Currency: entities.DefaultCurrency}, uuid.New())
+	require.NoError(t, product.Delete("discontinued"))
+
+	queryBus := bus.NewQueryBus()
+	bus.RegisterQuery(queryBus, func(ctx context.Context, query queries.ListDeletedQuery[*entities.Product]) ([]*entities.Product, error) {
+		return []*entities.Product{product}, nil
+	})
+
+	e := echo.New()
+	e.HTTPErrorHandler = handlers.ErrorHandler(logger.NewDefault())
+	e.GET("/api/v1/admin/products/deleted", handlers.NewSoftDeleteHandlers(nil, queryBus).Products.ListDeleted)
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/admin/products/deleted", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var response dto.PaginatedResponse[[]dto.DeletedResourceDTO[dto.ProductDTO]]
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	require.Len(t, response.Data, 1)
+	assert.Equal(t, product.ID, response.Data[0].Resource.ID)
+	assert.NotNil(t, response.Data[0].DeletedAt)
+	assert.Equal(t, "discontinued", response.Data[0].DeletionReason)
+}
+
+func TestSoftDeleteHandler_SoftDeleteReadsReason(t *testing.T) {
+	var handled commands.SoftDeleteOrderCommand
+	commandBus := bus.NewCommandBus()
+	bus.RegisterCommand(commandBus, func(ctx context.Context, cmd commands.SoftDeleteOrderCommand) error {
+		handled = cmd
+		return nil
+	})
+
+	e := echo.New()
+	e.HTTPErrorHandler = handlers.ErrorHandler(logger.NewDefault())
+	e.DELETE("/api/v1/admin/orders/:id", handlers.NewSoftDeleteHandlers(commandBus, nil).Orders.SoftDelete)
+
+	id := uuid.New()
+	req := httptest.NewRequest(http.MethodDelete, "/api/v1/admin/orders/"+id.String(), strings.NewReader(`{"reason": "test order"}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, commands.SoftDeleteOrderCommand{ID: id, Reason: "test order"}, handled)
+
+	// The body is optional
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodDelete, "/api/v1/admin/orders/"+id.String(), nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Empty(t, handled.Reason)
+}
+
+func TestUserHandler_DeleteUsesSoftDeleteLifecycle(t *testing.T) {
+	var handled []commands.SoftDeleteUserCommand
+	commandBus := bus.NewCommandBus()
+	bus.RegisterCommand(commandBus, func(ctx context.Context, cmd commands.SoftDeleteUserCommand) error {
+		handled = append(handled, cmd)
+		return nil
+	})
+
+	e := echo.New()
+	e.HTTPErrorHandler = handlers.ErrorHandler(logger.NewDefault())
+	e.DELETE("/api/v1/users/:id", handlers.NewUserHandler(commandBus, nil).DeleteUser)
+	e.DELETE("/api/v1/admin/users/:id", handlers.NewSoftDeleteHandlers(commandBus, nil).Users.SoftDelete)
+
+	// Both routes dispatch the same command, so the reason is kept either way
+	id := uuid.New()
+	for _, target := range []string{"/api/v1/users/", "/api/v1/admin/users/"} {
+		req := httptest.NewRequest(http.MethodDelete, target+id.String(), strings.NewReader(`{"reason": "requested by customer"}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		require.Equal(t, http.StatusOK, rec.Code)
+	}
+
+	expected := commands.SoftDeleteUserCommand{ID: id, Reason: "requested by customer"}
+	assert.Equal(t, []commands.SoftDeleteUserCommand{expected, expected}, handled)
+}
@@ -0,0 +1,173 @@
+package test
+
+import (
+	"encoding/json"
+	"goclean/internal/application/commands"
+	"goclean/internal/application/dto"
+	"goclean/internal/application/queries"
+	"goclean/internal/application/validation"
+	"goclean/internal/domain/entities"
+	"goclean/internal/interfaces/http/handlers"
+	"goclean/pkg/logger"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ptr returns a pointer to v for optional fields
+func ptr[T any](v T) *T {
+	return &v
+}
+
+// failedRules returns the rule that failed for each field
+func failedRules(t *testing.T, err error) map[string]string {
+	t.Helper()
+	if err == nil {
+		return nil
+	}
+	var fieldErrors validation.Errors
+	require.ErrorAs(t, err, &fieldErrors)
+
+	rules := make(map[string]string, len(fieldErrors))
+	for _, fieldError := range fieldErrors {
+		rules[fieldError.Field] = fieldError.Rule
+	}
+	return rules
+}
+
+func TestValidator_ValidateTags(t *testing.T) {
+	validator := validation.New()
+	validUser := commands.CreateUserCommand{Email: "jane@example.com", Username: "jane", FirstName: "Jane", LastName: "Doe"}
+	validItem := commands.CreateOrderItemData{ProductID: uuid.New(), Quantity: 1}
+
+	tests := []struct {
+		name     string
+		payload  any
+		expected map[string]string
+	}{
+		{
+			name:    "valid user",
+			payload: validUser,
+		},
+		{
+			name: "invalid email and short username",
+			payload: commands.CreateUserCommand{
+				Email: "not-an-email", Username: "jo", FirstName: "Jo", LastName: "Doe",
+			},
+			expected: map[string]string{"email": "email", "username": "min"},
+		},
+		{
+			name:     "missing required fields",
+			payload:  &commands.CreateUserCommand{},
+			expected: map[string]string{"email": "required", "username": "required", "first_name": "required", "last_name": "required"},
+		},
+		{
+			name:    "partial user update",
+			payload: commands.UpdateUserCommand{ID: uuid.New(), FirstName: ptr("Janet")},
+		},
+		{
+			name:     "invalid user update",
+			payload:  commands.UpdateUserCommand{ID: uuid.New(), Email: ptr("nope")},
+			expected: map[string]string{"email": "email"},
+		},
+		{
+			name:     "blanked user name",
+			payload:  commands.UpdateUserCommand{ID: uuid.New(), FirstName: ptr("")},
+			expected: map[string]string{"first_name": "min"},
+		},
+		{
+			name:     "negative offset and oversized limit",
+			payload:  queries.ListUsersQuery{Offset: -1, Limit: 10000},
+			expected: map[string]string{"offset": "min", "limit": "max"},
+		},
+		{
+			name:     "zero limit",
+			payload:  queries.ListProductsQuery{Limit: 0},
+			expected: map[string]string{"limit": "min"},
+		},
+		{
+			name:    "valid order",
+			payload: commands.CreateOrderCommand{UserID: uuid.New(), Items: []commands.CreateOrderItemData{validItem}},
+		},
+		{
+			name:     "order without items",
+			payload:  commands.CreateOrderCommand{UserID: uuid.New(), Items: []commands.CreateOrderItemData{}},
+			expected: map[string]string{"items": "min"},
+		},
+		{
+			name: "order item without product or quantity",
+			payload: commands.CreateOrderCommand{UserID: uuid.New(), Items: []commands.CreateOrderItemData{
+				validItem, {Quantity: -2},
+			}},
+			expected: map[string]string{"items[1].product_id": "required", "items[1].quantity": "gt"},
+		},
+		{
+			name: "product without price or creator",
+			payload: commands.CreateProductCommand{
+				Name: "Lamp", SKU: "LAMP-1", Category: "home", Stock: 3,
+			},
+			expected: map[string]string{"price": "required", "created_by": "required"},
+		},
+		{
+			name: "product with negative stock",
+			payload: commands.CreateProductCommand{
+				Name: "Lamp", SKU: "LAMP-1", Category: "home", Stock: -1,
+				Price: entities.Money{Amount: 1999, Currency: "USD"}, CreatedBy: uuid.New(),
+			},
+			expected: map[string]string{"stock": "min"},
+		},
+		{
+			name:     "request currency",
+			payload:  dto.CreateProductRequest{Name: "Lamp", SKU: "LAMP-1", Category: "home", Price: dto.MoneyAmountRequest{Amount: 5, Currency: "XYZ"}},
+			expected: map[string]string{"price.currency": "iso4217"},
+		},
+		{
+			name:     "unknown order status",
+			payload:  dto.UpdateOrderStatusRequest{Status: "lost"},
+			expected: map[string]string{"status": "oneof"},
+		},
+		{
+			name:    "untagged value",
+			payload: "anything",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validator.Validate(tt.payload)
+			if tt.expected == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.Equal(t, tt.expected, failedRules(t, err))
+		})
+	}
+}
+
+func TestUserHandler_CreateUserReturnsFieldErrors(t *testing.T) {
+	e := echo.New()
+	e.Validator = validation.New()
+	e.HTTPErrorHandler = handlers.ErrorHandler(logger.NewDefault())
+	// Validation fails before anything is dispatched, so no buses are needed
+	e.POST("/api/v1/users", handlers.NewUserHandler(nil, nil).CreateUser)
+
+	body := `{"email": "not-an-email", "username": "jane", "first_name": "Jane", "last_name": "Doe"}`
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/users", strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	var problem dto.ProblemDetails
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &problem))
+	assert.Equal(t, "validation_failed", problem.Code)
+	require.Len(t, problem.Errors, 1)
+	assert.Equal(t, "email", problem.Errors[0].Field)
+	assert.Equal(t, "email", problem.Errors[0].Rule)
+}